@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+const (
+	// ComponentAttribute is the attribute the Component of a VolatileCriteria
+	// is matched against
+	ComponentAttribute = "component"
+	// ApplicationAttribute is the attribute the Application of a
+	// VolatileCriteria is matched against
+	ApplicationAttribute = "application"
+)
+
+// MatchesAttributes reports whether the criteria applies given the attributes
+// supplied at evaluation time: the Component and the Application, if set,
+// equal the component and application attributes, and each of the Attributes
+// equals the attribute with the same key. Criteria with any of those set never
+// match when the attribute is not supplied.
+func (c VolatileCriteria) MatchesAttributes(attributes map[string]string) bool {
+	if !matchesAttribute(attributes, ComponentAttribute, c.Component) {
+		return false
+	}
+
+	if !matchesAttribute(attributes, ApplicationAttribute, c.Application) {
+		return false
+	}
+
+	for k, v := range c.Attributes {
+		if actual, ok := attributes[k]; !ok || actual != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesAttribute reports whether the attribute equals the expected value,
+// always when no value is expected
+func matchesAttribute(attributes map[string]string, key, expected string) bool {
+	if expected == "" {
+		return true
+	}
+
+	actual, ok := attributes[key]
+
+	return ok && actual == expected
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "testing"
+
+func TestMatchesAttributes(t *testing.T) {
+	supplied := map[string]string{
+		ComponentAttribute:   "api",
+		ApplicationAttribute: "shop",
+		"environment":        "prod",
+		"team":               "payments",
+	}
+
+	tests := []struct {
+		name       string
+		criteria   VolatileCriteria
+		attributes map[string]string
+		want       bool
+	}{
+		{"Unconstrained", VolatileCriteria{}, supplied, true},
+		{"Unconstrained without attributes", VolatileCriteria{}, nil, true},
+		{"Component", VolatileCriteria{Component: "api"}, supplied, true},
+		{"Other component", VolatileCriteria{Component: "web"}, supplied, false},
+		{"Component not supplied", VolatileCriteria{Component: "api"}, nil, false},
+		{"Application", VolatileCriteria{Application: "shop"}, supplied, true},
+		{"Other application", VolatileCriteria{Application: "bank"}, supplied, false},
+		{"Component and application", VolatileCriteria{Component: "api", Application: "shop"}, supplied, true},
+		{"Component of other application", VolatileCriteria{Component: "api", Application: "bank"}, supplied, false},
+		{"Attributes", VolatileCriteria{Attributes: map[string]string{"environment": "prod", "team": "payments"}}, supplied, true},
+		{"Other attribute value", VolatileCriteria{Attributes: map[string]string{"environment": "stage"}}, supplied, false},
+		{"Attribute not supplied", VolatileCriteria{Attributes: map[string]string{"region": "eu"}}, supplied, false},
+		{"Empty attribute value", VolatileCriteria{Attributes: map[string]string{"region": ""}}, map[string]string{"region": ""}, true},
+		{"Empty attribute value not supplied", VolatileCriteria{Attributes: map[string]string{"region": ""}}, supplied, false},
+		{"All", VolatileCriteria{Component: "api", Application: "shop", Attributes: map[string]string{"team": "payments"}}, supplied, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.criteria.MatchesAttributes(tt.attributes); got != tt.want {
+				t.Errorf("MatchesAttributes(%v) = %v, want %v", tt.attributes, got, tt.want)
+			}
+		})
+	}
+}
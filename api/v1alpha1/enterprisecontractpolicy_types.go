@@ -57,13 +57,22 @@ type Source struct {
 	// List of go-getter style policy source urls
 	// +kubebuilder:validation:MinItems:=1
 	Policy []string `json:"policy,omitempty"`
-	// List of go-getter style policy data source urls
+	// List of go-getter style policy data source urls, or k8s://namespace/name
+	// urls referring to ConfigMaps in the namespace of the policy holding the
+	// data, each key of the ConfigMap being a data file
 	// +optional
 	Data []string `json:"data,omitempty"`
 	// Arbitrary rule data that will be visible to policy rules
 	// +optional
 	// +kubebuilder:validation:Type:=object
 	RuleData *extv1.JSON `json:"ruleData,omitempty"`
+	// RuleDataFrom lists ConfigMaps and Secrets, in the namespace of the
+	// policy, holding rule data. The rule data of the references is merged in
+	// order, values of later references replacing the values of the same
+	// rule data keys of earlier references. The inline rule data is merged
+	// last.
+	// +optional
+	RuleDataFrom []RuleDataReference `json:"ruleDataFrom,omitempty"`
 	// Config specifies which policy rules are included, or excluded, from the
 	// provided policy source urls.
 	// +optional
@@ -74,6 +83,70 @@ type Source struct {
 	// +optional
 	// +kubebuilder:validation:Type:=object
 	VolatileConfig *VolatileSourceConfig `json:"volatileConfig,omitempty"`
+	// BundleSignature requires the OCI bundles of the source, i.e. the
+	// policy and data urls referring to OCI artifacts, to be signed. The
+	// signature is verified against the digest the reference resolves to and
+	// the bundle is fetched by that digest.
+	// +optional
+	BundleSignature *BundleSignature `json:"bundleSignature,omitempty"`
+	// CommitSignature requires the commits the git repositories of the
+	// source, i.e. the policy and data urls referring to git repositories,
+	// resolve to, to be signed with one of the allowed keys
+	// +optional
+	CommitSignature *CommitSignature `json:"commitSignature,omitempty"`
+}
+
+// CommitSignature defines the keys git commits may be signed with
+type CommitSignature struct {
+	// AllowedKeys lists the public keys commits may be signed with, either
+	// ASCII armored GPG public keys or SSH public keys in the authorized_keys
+	// format
+	// +kubebuilder:validation:MinItems:=1
+	AllowedKeys []string `json:"allowedKeys"`
+}
+
+// BundleSignature defines how the signatures of OCI bundles are verified,
+// exactly one of the public key and the identity must be set
+// +kubebuilder:validation:MinProperties:=1
+// +kubebuilder:validation:MaxProperties:=1
+type BundleSignature struct {
+	// PublicKey used to verify the signatures, either PEM encoded or a
+	// k8s://namespace/name reference to a Secret in the namespace of the
+	// policy holding the PEM encoded key under the cosign.pub key
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+	// Identity of the keyless signatures
+	// +optional
+	Identity *Identity `json:"identity,omitempty"`
+}
+
+// RuleDataReference refers to rule data held in a ConfigMap or a Secret,
+// exactly one of which must be set
+// +kubebuilder:validation:MinProperties:=1
+// +kubebuilder:validation:MaxProperties:=1
+type RuleDataReference struct {
+	// ConfigMapRef selects rule data from a ConfigMap
+	// +optional
+	ConfigMapRef *RuleDataKeySelector `json:"configMapRef,omitempty"`
+	// SecretRef selects rule data from a Secret
+	// +optional
+	SecretRef *RuleDataKeySelector `json:"secretRef,omitempty"`
+}
+
+// RuleDataKeySelector selects rule data from the keys of a ConfigMap or a
+// Secret
+type RuleDataKeySelector struct {
+	// Name of the ConfigMap or Secret
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+	// Key holding the rule data as a JSON or YAML object. When not set, each
+	// key is a rule data key with the value parsed as JSON or YAML.
+	// +optional
+	Key string `json:"key,omitempty"`
+	// Optional allows the ConfigMap or Secret, or the selected key, not to
+	// exist
+	// +optional
+	Optional bool `json:"optional,omitempty"`
 }
 
 // SourceConfig specifies config options for a policy source.
@@ -112,10 +185,31 @@ type VolatileCriteria struct {
 	ImageDigest string `json:"imageDigest,omitempty"`
 
 	// ImageUrl is used to specify an image by its URL without a tag.
+	// The URL may contain wildcards: * matches any characters within a path
+	// component, e.g. quay.io/acme/app-*, and ** as a whole component matches
+	// one or more path components, e.g. quay.io/acme/** for all repositories
+	// under quay.io/acme/. The registry may include a port.
 	// +optional
-	// +kubebuilder:validation:Pattern=`^[a-z0-9][a-z0-9.-]*[a-z0-9](?:\/[a-z0-9][a-z0-9-]*[a-z0-9]){2,}$`
+	// +kubebuilder:validation:Pattern=`^[a-z0-9*][a-z0-9.*-]*[a-z0-9*](?::[0-9]+)?(?:\/(?:[a-z0-9*][a-z0-9*-]*[a-z0-9*]|\*)){2,}$`
 	ImageUrl string `json:"imageUrl,omitempty"`
 
+	// Component is used to specify the name of the component the image
+	// belongs to, matched against the component attribute supplied when the
+	// policy is evaluated.
+	// +optional
+	Component string `json:"component,omitempty"`
+
+	// Application is used to specify the name of the application the image
+	// belongs to, matched against the application attribute supplied when the
+	// policy is evaluated.
+	// +optional
+	Application string `json:"application,omitempty"`
+
+	// Attributes are used to specify arbitrary key/value pairs that all must
+	// equal the attributes supplied when the policy is evaluated.
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+
 	// Reference is used to include a link to related information such as a Jira issue URL.
 	// +optional
 	Reference string `json:"reference,omitempty"`
@@ -173,12 +267,169 @@ type Identity struct {
 
 // EnterpriseContractPolicyStatus defines the observed state of EnterpriseContractPolicy
 type EnterpriseContractPolicyStatus struct {
-	// TODO what to add here?
-	// ideas;
-	// - on what the policy was applied
-	// - history of changes
+	// ObservedGeneration is the generation of the policy last reconciled
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions describe the state of the policy
+	// +optional
+	// +listType:=map
+	// +listMapKey:=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Sources holds the observed state of each of the policy sources, in the
+	// order they are specified in the policy
+	// +optional
+	Sources []SourceStatus `json:"sources,omitempty"`
+	// RuleCatalogue is the name of the ConfigMap, in the namespace of the
+	// policy, holding the catalogue of the rules found in the policy rules of
+	// each source. Empty when a ConfigMap of that name not controlled by the
+	// policy exists, see the CataloguePublished condition.
+	// +optional
+	RuleCatalogue string `json:"ruleCatalogue,omitempty"`
+	// RekorUrl is the effective URL of the Rekor instance, only set when
+	// rewritten by a SourceRewrite
+	// +optional
+	RekorUrl string `json:"rekorUrl,omitempty"`
+}
+
+// SourceStatus defines the observed state of a policy source
+type SourceStatus struct {
+	// Name of the source
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Conditions describe the state of the source
+	// +optional
+	// +listType:=map
+	// +listMapKey:=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Policy lists the effective policy source urls, only set when any of
+	// them is rewritten by a SourceRewrite
+	// +optional
+	Policy []string `json:"policy,omitempty"`
+	// Data lists the effective policy data source urls, only set when any of
+	// them is rewritten by a SourceRewrite
+	// +optional
+	Data []string `json:"data,omitempty"`
+	// Config is the effective configuration of the source, with the references
+	// to collections defined by RuleCollection resources expanded
+	// +optional
+	Config *SourceConfig `json:"config,omitempty"`
+	// UndefinedCollections lists the collections referred to from the
+	// configuration of the source that are neither defined by a RuleCollection
+	// resource nor in the policy rules of the source, or that are nested too
+	// deeply or too large to be expanded. These are left as is in the
+	// effective configuration.
+	// +optional
+	// +listType:=set
+	UndefinedCollections []string `json:"undefinedCollections,omitempty"`
+	// UnmatchedRules lists the values of the effective includes and excludes
+	// that match no rule or package in the policy rules of the source. Only
+	// reported when the policy rules of the source could be fetched.
+	// +optional
+	// +listType:=set
+	UnmatchedRules []string `json:"unmatchedRules,omitempty"`
+	// RuleData is the effective rule data of the source, the rule data of the
+	// ruleDataFrom references merged with the inline rule data. Values from
+	// Secrets are left out. Only set when the source has ruleDataFrom
+	// references.
+	// +optional
+	// +kubebuilder:validation:Type:=object
+	RuleData *extv1.JSON `json:"ruleData,omitempty"`
+	// RuleDataHash is the SHA-256 hash of the effective rule data. The
+	// values from Secrets are not hashed, the UIDs and resource versions of
+	// the Secrets are hashed in their place. Only set when the source has
+	// ruleDataFrom references.
+	// +optional
+	RuleDataHash string `json:"ruleDataHash,omitempty"`
+	// RuleDataErrors lists the violations of the rule data schema shipped
+	// with the policy rules of the source by the inline rule data and the
+	// rule data in the data of the source, limited to the first 20
+	// +optional
+	RuleDataErrors []RuleDataError `json:"ruleDataErrors,omitempty"`
+	// Mirror holds the URLs the policy rules and data of the source are
+	// served at by the manager, only set when mirroring is enabled
+	// +optional
+	Mirror *SourceMirror `json:"mirror,omitempty"`
+	// VerifiedBundles lists the OCI bundles of the source with verified
+	// signatures, pinned to the verified digests, only set when the source
+	// requires signed bundles
+	// +optional
+	VerifiedBundles []string `json:"verifiedBundles,omitempty"`
+	// VerifiedCommits lists the signed commits of the git repositories of
+	// the source, only set when the source requires signed commits
+	// +optional
+	VerifiedCommits []VerifiedCommit `json:"verifiedCommits,omitempty"`
+}
+
+// VerifiedCommit is a git commit with a verified signature
+type VerifiedCommit struct {
+	// Url of the git repository as fetched
+	Url string `json:"url"`
+	// Commit is the hash of the commit the url resolved to
+	Commit string `json:"commit"`
+	// Signer is the user ID of the GPG key or the comment of the SSH key the
+	// commit is signed with
+	// +optional
+	Signer string `json:"signer,omitempty"`
+	// Fingerprint of the key the commit is signed with
+	Fingerprint string `json:"fingerprint"`
 }
 
+// SourceMirror holds the URLs of the mirrored policy rules and data of a
+// source, each an archive named after the SHA-256 digest of its content
+type SourceMirror struct {
+	// Policy lists the mirror URLs of the policy rules, in the order of the
+	// policy URLs of the source
+	// +optional
+	Policy []string `json:"policy,omitempty"`
+	// Data lists the mirror URLs of the data, in the order of the data URLs
+	// of the source. Data held in ConfigMaps is not mirrored, its k8s:// URL
+	// is listed as is.
+	// +optional
+	Data []string `json:"data,omitempty"`
+}
+
+// RuleDataError is a violation of the rule data schema
+type RuleDataError struct {
+	// Data is the URL of the data source the document was fetched from,
+	// empty for the inline rule data
+	// +optional
+	Data string `json:"data,omitempty"`
+	// Document the violation was found in, "ruleData" for the inline rule
+	// data or the path of the data document within the data source
+	Document string `json:"document"`
+	// Pointer is the JSON pointer to the offending value within the document
+	// +optional
+	Pointer string `json:"pointer,omitempty"`
+	// Message describes the violation
+	Message string `json:"message"`
+}
+
+const (
+	// ConditionCollectionsResolved is set to true when all collections
+	// referred to from the policy sources are defined
+	ConditionCollectionsResolved = "CollectionsResolved"
+	// ConditionSourcesReady is set to true when all policy sources are ready
+	ConditionSourcesReady = "SourcesReady"
+	// ConditionRulesMatched is set to true when all includes and excludes of
+	// the policy sources match rules in the policy rules of the sources
+	ConditionRulesMatched = "RulesMatched"
+	// ConditionRuleDataValid is set to true when the rule data of all policy
+	// sources conforms to the rule data schema of the sources
+	ConditionRuleDataValid = "RuleDataValid"
+	// ConditionRulesConsistent is set to true when the includes and excludes
+	// of the policy, its sources and their volatile config neither conflict
+	// nor are redundant
+	ConditionRulesConsistent = "RulesConsistent"
+	// ConditionCataloguePublished is set to true when the rule catalogue is
+	// published in the ConfigMap named in ruleCatalogue, false when a
+	// ConfigMap of that name not controlled by the policy exists
+	ConditionCataloguePublished = "CataloguePublished"
+	// ConditionReady is set on a source to true when its rule data could be
+	// resolved and its policy rules and data could be fetched
+	ConditionReady = "Ready"
+)
+
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:categories={all},shortName={ecp}
 // +kubebuilder:subresource:status
@@ -167,11 +167,20 @@ func TestImageUrlPattern(t *testing.T) {
 		{"Registry with hyphens", "my-registry.example.com/org-name/repo-name", true, false},
 		{"Registry with numbers", "registry123.example.com/org123/repo123", true, false},
 		{"Extra path component", "registry/org/repo/extra", true, false},
+		{"Localhost with port", "localhost:5000/org/repo", true, false},
+		{"Registry with port", "registry.example.com:8443/org/repo", true, false},
+		{"Repository glob", "quay.io/org/repo-*", true, false},
+		{"Organization glob", "quay.io/*/repo", true, false},
+		{"Any path", "quay.io/org/**", true, false},
+		{"Registry glob", "*.example.com/org/repo", true, false},
 		{"Omitted field", "", true, true}, // Field is omitted entirely
 
 		// Invalid cases
 		{"URL with HTTPS", "https://quay.io/org/repo", false, false},
-		{"Localhost with port", "localhost:5000/org/repo", false, false},
+		{"Port without number", "localhost:/org/repo", false, false},
+		{"Glob with tag", "quay.io/org/*:latest", false, false},
+		{"Glob with trailing slash", "quay.io/org/*/", false, false},
+		{"Question mark", "quay.io/org/repo?", false, false},
 		{"Invalid character @", "invalid@registry/org/repo", false, false},
 		{"Missing repo", "registry/org", false, false},
 		{"Double slash", "registry//org/repo", false, false},
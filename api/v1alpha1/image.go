@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"path"
+	"strings"
+)
+
+const (
+	dockerHub        = "docker.io"
+	dockerHubIndex   = "index.docker.io"
+	dockerHubLibrary = "library"
+	// anyPath is the ImageUrl path component matching one or more path
+	// components
+	anyPath = "**"
+)
+
+// ImageRepository returns the repository of the image reference without the
+// tag and the digest, e.g. quay.io/acme/app for quay.io/acme/app:v1@sha256:...
+// Docker Hub references are normalized to docker.io/<org>/<repo>, i.e. nginx
+// and index.docker.io/library/nginx both become docker.io/library/nginx.
+func ImageRepository(ref string) string {
+	repository, _, _ := strings.Cut(ref, "@")
+
+	// a colon after the last slash separates the tag, other colons separate
+	// the port of the registry
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+
+	registry, rest, ok := strings.Cut(repository, "/")
+	switch {
+	case !ok:
+		return dockerHub + "/" + dockerHubLibrary + "/" + repository
+	case registry == dockerHubIndex:
+		repository = dockerHub + "/" + rest
+	case registry != "localhost" && !strings.ContainsAny(registry, ".:"):
+		repository = dockerHub + "/" + repository
+	}
+
+	if registry, rest, _ = strings.Cut(repository, "/"); registry == dockerHub && !strings.Contains(rest, "/") {
+		repository = dockerHub + "/" + dockerHubLibrary + "/" + rest
+	}
+
+	return repository
+}
+
+// ImageDigest returns the digest of the image reference, empty when the image
+// is referred to by tag only
+func ImageDigest(ref string) string {
+	_, digest, _ := strings.Cut(ref, "@")
+
+	return digest
+}
+
+// MatchImageUrl reports whether the image reference matches the ImageUrl
+// pattern of a VolatileCriteria. The tag and the digest of the reference are
+// ignored and the repository is matched component by component, separated by
+// slashes, with the registry being the first component:
+//
+//   - a component without wildcards matches the same component exactly,
+//     including the port of the registry, i.e. quay.io/acme/app matches
+//     quay.io/acme/app:v1 but neither quay.io/acme/app/x nor quay.io:443/acme/app
+//   - * within a component matches any sequence of characters within that
+//     component, i.e. quay.io/acme/app-* matches quay.io/acme/app-api, and
+//     *.example.com/acme/app matches registry.example.com/acme/app
+//   - ** as a whole component matches one or more components, i.e.
+//     quay.io/acme/** matches every repository under quay.io/acme/, but not
+//     quay.io/acme itself
+//
+// Docker Hub references are normalized as by ImageRepository before matching.
+func MatchImageUrl(pattern, ref string) bool {
+	if pattern == "" || ref == "" {
+		return false
+	}
+
+	if registry, rest, ok := strings.Cut(pattern, "/"); ok && registry == dockerHubIndex {
+		pattern = dockerHub + "/" + rest
+	}
+
+	return matchComponents(strings.Split(pattern, "/"), strings.Split(ImageRepository(ref), "/"))
+}
+
+func matchComponents(pattern, components []string) bool {
+	if len(pattern) == 0 {
+		return len(components) == 0
+	}
+
+	if pattern[0] == anyPath {
+		for i := 1; i <= len(components); i++ {
+			if matchComponents(pattern[1:], components[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(components) == 0 {
+		return false
+	}
+
+	if ok, err := path.Match(pattern[0], components[0]); err != nil || !ok {
+		return false
+	}
+
+	return matchComponents(pattern[1:], components[1:])
+}
+
+// MatchesImage reports whether the criteria applies to the image reference
+// regardless of the effective period: the digest of the reference equals the
+// ImageDigest, or the deprecated ImageRef, and the reference matches the
+// ImageUrl pattern, if set. Criteria with a digest never match references by
+// tag only.
+func (c VolatileCriteria) MatchesImage(ref string) bool {
+	digest := ImageDigest(ref)
+	for _, d := range []string{c.ImageDigest, c.ImageRef} {
+		if d != "" && !strings.EqualFold(d, digest) {
+			return false
+		}
+	}
+
+	return c.ImageUrl == "" || MatchImageUrl(c.ImageUrl, ref)
+}
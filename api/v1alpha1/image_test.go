@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "testing"
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestImageRepository(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"quay.io/acme/app", "quay.io/acme/app"},
+		{"quay.io/acme/app:v1", "quay.io/acme/app"},
+		{"quay.io/acme/app@" + testDigest, "quay.io/acme/app"},
+		{"quay.io/acme/app:v1@" + testDigest, "quay.io/acme/app"},
+		{"localhost:5000/acme/app", "localhost:5000/acme/app"},
+		{"localhost:5000/acme/app:v1", "localhost:5000/acme/app"},
+		{"localhost/acme/app", "localhost/acme/app"},
+		{"nginx", "docker.io/library/nginx"},
+		{"nginx:1.27", "docker.io/library/nginx"},
+		{"acme/app", "docker.io/acme/app"},
+		{"docker.io/nginx", "docker.io/library/nginx"},
+		{"index.docker.io/library/nginx", "docker.io/library/nginx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			if got := ImageRepository(tt.ref); got != tt.want {
+				t.Errorf("ImageRepository(%q) = %q, want %q", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchImageUrl(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		ref     string
+		want    bool
+	}{
+		{"Exact", "quay.io/acme/app", "quay.io/acme/app", true},
+		{"Tag ignored", "quay.io/acme/app", "quay.io/acme/app:v1", true},
+		{"Digest ignored", "quay.io/acme/app", "quay.io/acme/app@" + testDigest, true},
+		{"Tag and digest ignored", "quay.io/acme/app", "quay.io/acme/app:v1@" + testDigest, true},
+		{"Other repository", "quay.io/acme/app", "quay.io/acme/api", false},
+		{"No prefix match", "quay.io/acme/app", "quay.io/acme/app/extra", false},
+		{"Shorter repository", "quay.io/acme/app", "quay.io/acme", false},
+		{"Port", "localhost:5000/acme/app", "localhost:5000/acme/app:v1", true},
+		{"Other port", "localhost:5000/acme/app", "localhost:5001/acme/app", false},
+		{"Port required", "quay.io/acme/app", "quay.io:443/acme/app", false},
+		{"Component glob", "quay.io/acme/app-*", "quay.io/acme/app-api:v1", true},
+		{"Component glob across slash", "quay.io/acme/app-*", "quay.io/acme/app-api/x", false},
+		{"Organization glob", "quay.io/*/app", "quay.io/acme/app", true},
+		{"Registry glob", "*.example.com/acme/app", "registry.example.com/acme/app", true},
+		{"Registry glob and port", "*.example.com/acme/app", "registry.example.com:5000/acme/app", false},
+		{"Any path one", "quay.io/acme/**", "quay.io/acme/app", true},
+		{"Any path many", "quay.io/acme/**", "quay.io/acme/team/app@" + testDigest, true},
+		{"Any path none", "quay.io/acme/**", "quay.io/acme", false},
+		{"Any path other organization", "quay.io/acme/**", "quay.io/acme-labs/app", false},
+		{"Any path in the middle", "quay.io/**/app", "quay.io/acme/team/app", true},
+		{"Any path in the middle other", "quay.io/**/app", "quay.io/acme/team/api", false},
+		{"Docker Hub", "docker.io/library/nginx", "nginx:1.27", true},
+		{"Docker Hub index", "index.docker.io/library/nginx", "docker.io/nginx", true},
+		{"Empty pattern", "", "quay.io/acme/app", false},
+		{"Empty reference", "quay.io/acme/app", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchImageUrl(tt.pattern, tt.ref); got != tt.want {
+				t.Errorf("MatchImageUrl(%q, %q) = %v, want %v", tt.pattern, tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria VolatileCriteria
+		ref      string
+		want     bool
+	}{
+		{"Unconstrained", VolatileCriteria{}, "quay.io/acme/app:v1", true},
+		{"Digest", VolatileCriteria{ImageDigest: testDigest}, "quay.io/acme/app@" + testDigest, true},
+		{"Other digest", VolatileCriteria{ImageDigest: testDigest}, "quay.io/acme/app@sha256:ff", false},
+		{"Digest of image by tag", VolatileCriteria{ImageDigest: testDigest}, "quay.io/acme/app:v1", false},
+		{"Deprecated image ref", VolatileCriteria{ImageRef: testDigest}, "quay.io/acme/app@" + testDigest, true},
+		{"Url", VolatileCriteria{ImageUrl: "quay.io/acme/**"}, "quay.io/acme/app:v1", true},
+		{"Url and digest", VolatileCriteria{ImageUrl: "quay.io/acme/**", ImageDigest: testDigest}, "quay.io/acme/app@" + testDigest, true},
+		{"Other url and digest", VolatileCriteria{ImageUrl: "quay.io/other/**", ImageDigest: testDigest}, "quay.io/acme/app@" + testDigest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.criteria.MatchesImage(tt.ref); got != tt.want {
+				t.Errorf("MatchesImage(%q) = %v, want %v", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
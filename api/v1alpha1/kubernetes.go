@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// KubernetesURLPrefix is the prefix of URLs referring to Kubernetes resources
+// by namespace and name, e.g. k8s://namespace/name, as used by the public key
+// and by data sources held in ConfigMaps
+const KubernetesURLPrefix = "k8s://"
+
+// IsKubernetesURL reports whether the URL refers to a Kubernetes resource
+func IsKubernetesURL(url string) bool {
+	return strings.HasPrefix(url, KubernetesURLPrefix)
+}
+
+// ParseKubernetesURL returns the namespace and the name of the Kubernetes
+// resource the k8s://namespace/name URL refers to
+func ParseKubernetesURL(url string) (namespace, name string, err error) {
+	if !IsKubernetesURL(url) {
+		return "", "", fmt.Errorf("%q does not start with %s", url, KubernetesURLPrefix)
+	}
+
+	namespace, name, ok := strings.Cut(strings.TrimPrefix(url, KubernetesURLPrefix), "/")
+	if !ok {
+		return "", "", fmt.Errorf("%q is not of the form %snamespace/name", url, KubernetesURLPrefix)
+	}
+
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid namespace %q in %q: %s", namespace, url, strings.Join(errs, ", "))
+	}
+
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid name %q in %q: %s", name, url, strings.Join(errs, ", "))
+	}
+
+	return namespace, name, nil
+}
+
+// ParseKubernetesURLIn returns the name of the Kubernetes resource the
+// k8s://namespace/name URL refers to, requiring the resource to be in the
+// given namespace, the namespace of the resource referring to it
+func ParseKubernetesURLIn(url, namespace string) (string, error) {
+	ns, name, err := ParseKubernetesURL(url)
+	if err != nil {
+		return "", err
+	}
+
+	if ns != namespace {
+		return "", fmt.Errorf("%q is not in namespace %q, only resources in the same namespace can be referred to", url, namespace)
+	}
+
+	return name, nil
+}
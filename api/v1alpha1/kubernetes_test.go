@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "testing"
+
+func TestParseKubernetesURL(t *testing.T) {
+	cases := []struct {
+		url       string
+		namespace string
+		name      string
+		err       bool
+	}{
+		{url: "k8s://acme/policy-data", namespace: "acme", name: "policy-data"},
+		{url: "k8s://acme/policy.data", namespace: "acme", name: "policy.data"},
+		{url: "oci::registry.io/acme/data:latest", err: true},
+		{url: "k8s://policy-data", err: true},
+		{url: "k8s:///policy-data", err: true},
+		{url: "k8s://acme/", err: true},
+		{url: "k8s://acme/policy/data", err: true},
+		{url: "k8s://Acme/policy-data", err: true},
+		{url: "k8s://acme.io/policy-data", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.url, func(t *testing.T) {
+			namespace, name, err := ParseKubernetesURL(c.url)
+			if c.err {
+				if err == nil {
+					t.Errorf("expected an error, got %q and %q", namespace, name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if namespace != c.namespace || name != c.name {
+				t.Errorf("expected %q and %q, got %q and %q", c.namespace, c.name, namespace, name)
+			}
+		})
+	}
+}
+
+func TestParseKubernetesURLIn(t *testing.T) {
+	if name, err := ParseKubernetesURLIn("k8s://acme/policy-data", "acme"); err != nil || name != "policy-data" {
+		t.Errorf("expected policy-data, got %q (%v)", name, err)
+	}
+
+	for _, url := range []string{"k8s://other/policy-data", "k8s://policy-data"} {
+		if _, err := ParseKubernetesURLIn(url, "acme"); err == nil {
+			t.Errorf("expected an error for %q", url)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicySnapshotSpec refers to the policy to capture
+type PolicySnapshotSpec struct {
+	// Policy is the name of the EnterpriseContractPolicy, in the namespace of
+	// the snapshot, to capture
+	// +kubebuilder:validation:MinLength:=1
+	Policy string `json:"policy"`
+	// Export pushes the captured policy as an OCI artifact
+	// +optional
+	Export *PolicyExport `json:"export,omitempty"`
+}
+
+// PolicyExport configures the OCI artifact the captured policy is pushed as
+type PolicyExport struct {
+	// Repository is the reference the artifact is pushed to, e.g.
+	// quay.io/acme/policy or quay.io/acme/policy:release, tagged with the
+	// name of the snapshot when no tag is given
+	// +kubebuilder:validation:MinLength:=1
+	Repository string `json:"repository"`
+	// SigningKey is the k8s://<namespace>/<name> reference to the Secret
+	// holding the private key the artifact is signed with, in the cosign.key
+	// key, and its password, if encrypted, in the cosign.password key. The
+	// Secret must be in the namespace of the snapshot. The artifact is not
+	// signed when not set.
+	// +optional
+	SigningKey string `json:"signingKey,omitempty"`
+	// PushSecret is the k8s://<namespace>/<name> reference to the
+	// kubernetes.io/dockerconfigjson Secret holding the credentials the
+	// artifact is pushed with. The Secret must be in the namespace of the
+	// snapshot. The artifact is pushed without credentials when not set, the
+	// credentials of the controller are never used.
+	// +optional
+	PushSecret string `json:"pushSecret,omitempty"`
+}
+
+// PolicySnapshotStatus holds the captured policy
+type PolicySnapshotStatus struct {
+	// CapturedAt is the time the policy was captured
+	// +optional
+	CapturedAt *metav1.Time `json:"capturedAt,omitempty"`
+	// PolicyGeneration is the generation of the captured policy
+	// +optional
+	PolicyGeneration int64 `json:"policyGeneration,omitempty"`
+	// Policy is the captured policy, flattened: rule collections are
+	// expanded, the urls of OCI artifacts are pinned to digests and of git
+	// repositories to commits, rule data from ConfigMaps is inlined and public
+	// keys held in Secrets are resolved. Rule data from Secrets is not
+	// inlined, the references to the Secrets are kept in ruleDataFrom and
+	// the ruleDataHash records their revisions.
+	// +optional
+	Policy *EnterpriseContractPolicySpec `json:"policy,omitempty"`
+	// RuleDataHash lists the SHA-256 hashes of the effective rule data of
+	// the sources, covering the revisions of the Secrets rule data is taken
+	// from, in the order of the sources, empty for sources without rule data
+	// references
+	// +optional
+	RuleDataHash []string `json:"ruleDataHash,omitempty"`
+	// Artifact is the digest reference to the OCI artifact the captured
+	// policy is exported as
+	// +optional
+	Artifact string `json:"artifact,omitempty"`
+	// Conditions describe the state of the snapshot
+	// +optional
+	// +listType:=map
+	// +listMapKey:=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ConditionCaptured is set on a snapshot to true once the policy is captured
+const ConditionCaptured = "Captured"
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName={ecps}
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Policy",type=string,JSONPath=`.spec.policy`
+// +kubebuilder:printcolumn:name="Captured",type=date,JSONPath=`.status.capturedAt`
+// PolicySnapshot is an immutable copy of an EnterpriseContractPolicy captured
+// when the snapshot is created, for use as release evidence and for
+// pipelines to refer to the exact policy by name
+type PolicySnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicySnapshotSpec   `json:"spec,omitempty"`
+	Status PolicySnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolicySnapshotList contains a list of PolicySnapshot
+type PolicySnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PolicySnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PolicySnapshot{}, &PolicySnapshotList{})
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicySubscriptionSpec refers to the EnterpriseContractPolicy manifests to
+// keep the policies in the namespace of the subscription in sync with
+type PolicySubscriptionSpec struct {
+	// Source is the go-getter style URL of the git repository path or the OCI
+	// artifact holding the YAML or JSON EnterpriseContractPolicy manifests,
+	// e.g. git::https://github.com/acme/policies.git//prod?ref=main
+	// +kubebuilder:validation:MinLength:=1
+	Source string `json:"source"`
+	// Interval between the syncs of the policies with the source, at least
+	// 1m: shorter intervals are raised to 1m
+	// +kubebuilder:default:="5m"
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// Prune deletes the policies of the subscription no longer in the source
+	// +optional
+	Prune bool `json:"prune,omitempty"`
+}
+
+// PolicySubscriptionStatus reports the sync of the policies with the source
+type PolicySubscriptionStatus struct {
+	// LastAttemptedRevision is the source URL pinned to the commit or the
+	// digest last synced or attempted to be synced
+	// +optional
+	LastAttemptedRevision string `json:"lastAttemptedRevision,omitempty"`
+	// LastAppliedRevision is the source URL pinned to the commit or the
+	// digest the policies were last synced with
+	// +optional
+	LastAppliedRevision string `json:"lastAppliedRevision,omitempty"`
+	// LastSyncTime is the time the policies were last synced
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// Policies are the names of the policies synced from the source
+	// +optional
+	// +listType:=set
+	Policies []string `json:"policies,omitempty"`
+	// Drifted are the names of the policies changed in the cluster since
+	// they were applied, restored to the source by the last sync
+	// +optional
+	// +listType:=set
+	Drifted []string `json:"drifted,omitempty"`
+	// Conditions describe the state of the subscription
+	// +optional
+	// +listType:=map
+	// +listMapKey:=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ConditionSynced is set on a subscription to true once the policies are in
+// sync with the source
+const ConditionSynced = "Synced"
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName={ecpsub}
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.source`
+// +kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+// +kubebuilder:printcolumn:name="Revision",type=string,JSONPath=`.status.lastAppliedRevision`,priority=1
+// PolicySubscription keeps the EnterpriseContractPolicy objects in its
+// namespace in sync with the manifests in a git repository or an OCI
+// artifact, the synced policies are owned by the subscription
+type PolicySubscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicySubscriptionSpec   `json:"spec,omitempty"`
+	Status PolicySubscriptionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolicySubscriptionList contains a list of PolicySubscription
+type PolicySubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PolicySubscription `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PolicySubscription{}, &PolicySubscriptionList{})
+}
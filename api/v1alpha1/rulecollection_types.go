@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CollectionPrefix is the prefix of the values in includes and excludes that
+// refer to a collection of rules
+const CollectionPrefix = "@"
+
+// RuleCollectionSpec defines a named collection of policy rules
+type RuleCollectionSpec struct {
+	// Description of the collection or its intended use
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Rules in the collection, given in the same form as the values of
+	// includes and excludes, i.e. package names, rule codes or other
+	// collections prefixed with "@".
+	// +kubebuilder:validation:MinItems:=1
+	// +listType:=set
+	Rules []string `json:"rules"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories={all},shortName={rc}
+// RuleCollection defines a collection of policy rules that can be referred to
+// from the includes and excludes of policies in the same namespace using the
+// name of the collection prefixed with "@"
+type RuleCollection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RuleCollectionSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RuleCollectionList contains a list of RuleCollection
+type RuleCollectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuleCollection `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RuleCollection{}, &RuleCollectionList{})
+}
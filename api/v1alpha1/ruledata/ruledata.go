@@ -0,0 +1,246 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ruledata validates the rule data of policy sources against the JSON
+// schema shipped with the policy rules.
+//
+// Policy rules declare the schema of their rule data in a file named
+// rule_data.schema.json anywhere within the policy rules. The schema applies
+// to the inline rule data of a source and to the value of the top level
+// "rule_data" key of the JSON and YAML documents in the data of a source.
+package ruledata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+const (
+	// SchemaFileName is the name of the file holding the JSON schema of the
+	// rule data in the policy rules
+	SchemaFileName = "rule_data.schema.json"
+	// RuleDataKey is the key of the rule data in data documents
+	RuleDataKey = "rule_data"
+	// InlineDocument is the name of the document reported in errors found
+	// in the inline rule data of a source
+	InlineDocument = "ruleData"
+)
+
+// FindSchema returns the content of the rule data schema found within the
+// given directories, or nil if there is none. Finding more than one schema
+// with different content is an error.
+func FindSchema(dirs ...string) ([]byte, error) {
+	var found []byte
+	var foundPath string
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() || d.Name() != SchemaFileName {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			if found != nil && !bytes.Equal(found, content) {
+				return fmt.Errorf("found different rule data schemas in %s and %s", foundPath, path)
+			}
+			found, foundPath = content, path
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return found, nil
+}
+
+// Validator validates rule data against a rule data schema
+type Validator struct {
+	schema *jsonschema.Schema
+}
+
+// NewValidator returns a Validator for the given JSON schema. Only the
+// references within the schema resolve: the schema is shipped with the policy
+// rules, references to files or URLs would read the file system or the
+// network of the validating process.
+func NewValidator(schema []byte) (*Validator, error) {
+	c := jsonschema.NewCompiler()
+	c.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("references to %q are not allowed, only references within the rule data schema are", url)
+	}
+	if err := c.AddResource(SchemaFileName, bytes.NewReader(schema)); err != nil {
+		return nil, fmt.Errorf("unable to load the rule data schema: %w", err)
+	}
+
+	s, err := c.Compile(SchemaFileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile the rule data schema: %w", err)
+	}
+
+	return &Validator{schema: s}, nil
+}
+
+// Validate validates the rule data, given as a value as unmarshalled from
+// JSON, reporting errors as found in the named document
+func (v *Validator) Validate(document string, ruleData any) []ecc.RuleDataError {
+	err := v.schema.Validate(ruleData)
+	if err == nil {
+		return nil
+	}
+
+	var ve *jsonschema.ValidationError
+	if !errors.As(err, &ve) {
+		return []ecc.RuleDataError{{Document: document, Message: err.Error()}}
+	}
+
+	var errs []ecc.RuleDataError
+	var collect func(*jsonschema.ValidationError)
+	collect = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			errs = append(errs, ecc.RuleDataError{Document: document, Pointer: e.InstanceLocation, Message: e.Message})
+			return
+		}
+		for _, c := range e.Causes {
+			collect(c)
+		}
+	}
+	collect(ve)
+
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Pointer < errs[j].Pointer })
+
+	return errs
+}
+
+// ValidateInline validates the inline rule data of a source
+func (v *Validator) ValidateInline(ruleData *extv1.JSON) ([]ecc.RuleDataError, error) {
+	if ruleData == nil {
+		return nil, nil
+	}
+
+	value, err := unmarshal(ruleData.Raw, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the rule data: %w", err)
+	}
+
+	return v.Validate(InlineDocument, value), nil
+}
+
+// ValidateData validates the rule data in the JSON and YAML documents within
+// the given data directory
+func (v *Validator) ValidateData(dir string) ([]ecc.RuleDataError, error) {
+	var errs []ecc.RuleDataError
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		ext := filepath.Ext(path)
+		if d.IsDir() || (ext != ".json" && ext != ".yaml" && ext != ".yml") || d.Name() == SchemaFileName {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		doc, err := unmarshal(content, ext != ".json")
+		if err != nil {
+			return fmt.Errorf("unable to parse %s: %w", rel, err)
+		}
+
+		m, ok := doc.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		if ruleData, ok := m[RuleDataKey]; ok {
+			for _, e := range v.Validate(rel, ruleData) {
+				e.Pointer = "/" + RuleDataKey + e.Pointer
+				errs = append(errs, e)
+			}
+		}
+
+		return nil
+	})
+
+	return errs, err
+}
+
+func unmarshal(content []byte, isYAML bool) (any, error) {
+	if isYAML {
+		var err error
+		if content, err = yaml.YAMLToJSON(content); err != nil {
+			return nil, err
+		}
+	}
+
+	// numbers are decoded as json.Number as the validator expects
+	d := json.NewDecoder(bytes.NewReader(content))
+	d.UseNumber()
+
+	var value any
+	if err := d.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// Join formats the errors as a single message
+func Join(errs []ecc.RuleDataError) string {
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		pointer := e.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		document := e.Document
+		if e.Data != "" {
+			document = e.Data + " " + document
+		}
+		messages = append(messages, fmt.Sprintf("%s#%s: %s", document, pointer, e.Message))
+	}
+
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ruledata
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+func validator(t *testing.T) *Validator {
+	t.Helper()
+
+	schema, err := FindSchema("testdata/policy")
+	if err != nil {
+		t.Fatalf("unexpected error finding schema: %v", err)
+	}
+	if schema == nil {
+		t.Fatal("expected to find the schema")
+	}
+
+	v, err := NewValidator(schema)
+	if err != nil {
+		t.Fatalf("unexpected error creating validator: %v", err)
+	}
+
+	return v
+}
+
+func TestFindSchema(t *testing.T) {
+	schema, err := FindSchema(t.TempDir())
+	if err != nil || schema != nil {
+		t.Errorf("expected no schema, got %s, %v", schema, err)
+	}
+
+	// the same schema found twice is fine
+	if _, err := FindSchema("testdata/policy", "testdata/policy"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	other := t.TempDir()
+	if err := os.WriteFile(filepath.Join(other, SchemaFileName), []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FindSchema("testdata/policy", other); err == nil {
+		t.Error("expected an error finding different schemas")
+	}
+}
+
+func TestNewValidatorInvalidSchema(t *testing.T) {
+	if _, err := NewValidator([]byte(`{"type": 1}`)); err == nil {
+		t.Error("expected an error compiling an invalid schema")
+	}
+}
+
+func TestNewValidatorExternalReferences(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "secret.json")
+	if err := os.WriteFile(file, []byte(`{"type": "string", "const": "s3cr3t"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ref := range []string{"file://" + file, file, "secret.json", "https://schemas.example.com/rule_data.json"} {
+		t.Run(ref, func(t *testing.T) {
+			schema := fmt.Sprintf(`{"properties": {"token": {"$ref": %q}}}`, ref)
+			if _, err := NewValidator([]byte(schema)); err == nil || !strings.Contains(err.Error(), "are not allowed") {
+				t.Errorf("expected an error for the reference to %s, got %v", ref, err)
+			}
+		})
+	}
+
+	// references within the schema resolve
+	schema := `{"$defs": {"days": {"type": "integer"}}, "properties": {"max_age_days": {"$ref": "#/$defs/days"}}}`
+	if _, err := NewValidator([]byte(schema)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateInline(t *testing.T) {
+	v := validator(t)
+
+	cases := []struct {
+		name     string
+		ruleData *extv1.JSON
+		expected []ecc.RuleDataError
+	}{
+		{name: "nil"},
+		{name: "valid", ruleData: &extv1.JSON{Raw: []byte(`{"allowed_registry_prefixes": ["registry.io/"], "max_age_days": 1}`)}},
+		{
+			name:     "invalid",
+			ruleData: &extv1.JSON{Raw: []byte(`{"allowed_registry_prefixes": ["registry.io/", 1], "max_age_days": 1.5}`)},
+			expected: []ecc.RuleDataError{
+				{Document: InlineDocument, Pointer: "/allowed_registry_prefixes/1", Message: "expected string, but got number"},
+				{Document: InlineDocument, Pointer: "/max_age_days", Message: "expected integer, but got number"},
+			},
+		},
+		{
+			name:     "not an object",
+			ruleData: &extv1.JSON{Raw: []byte(`[]`)},
+			expected: []ecc.RuleDataError{
+				{Document: InlineDocument, Pointer: "", Message: "expected object, but got array"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs, err := v.ValidateInline(c.ruleData)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(c.expected, errs) {
+				t.Errorf("expected %v, got %v", c.expected, errs)
+			}
+		})
+	}
+}
+
+func TestValidateData(t *testing.T) {
+	errs, err := validator(t).ValidateData("testdata/data")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []ecc.RuleDataError{
+		{Document: "rule_data.yml", Pointer: "/rule_data/allowed_registry_prefixes/1", Message: "length must be >= 1, but got 0"},
+		{Document: "rule_data.yml", Pointer: "/rule_data/max_age_days", Message: "must be >= 1 but found 0"},
+	}
+	if !reflect.DeepEqual(expected, errs) {
+		t.Errorf("expected %v, got %v", expected, errs)
+	}
+}
+
+func TestValidateDataUnparsable(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := validator(t).ValidateData(dir); err == nil {
+		t.Error("expected an error parsing a broken document")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	msg := Join([]ecc.RuleDataError{
+		{Document: InlineDocument, Message: "a"},
+		{Data: "oci::registry.io/data", Document: "rule_data.yml", Pointer: "/rule_data/x", Message: "b"},
+	})
+
+	if expected := "ruleData#/: a; oci::registry.io/data rule_data.yml#/rule_data/x: b"; msg != expected {
+		t.Errorf("expected %q, got %q", expected, msg)
+	}
+}
@@ -1,7 +1,28 @@
 package v1alpha1
 
-import _ "embed"
+import (
+	_ "embed"
+	"fmt"
+	"sync"
 
-//go:generate go run -modfile ../../schema/go.mod ../../schema/export.go . github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1 .
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:generate go run -modfile ../../schema/go.mod ../../schema/export.go ../../schema/markers.go ../../schema/compat.go . github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1 .
 //go:embed policy_spec.json
 var Schema string
+
+var compiledSchema = sync.OnceValues(func() (*jsonschema.Schema, error) {
+	return jsonschema.CompileString("policy_spec.json", Schema)
+})
+
+// ValidateSpec validates the policy spec, decoded from JSON or YAML into maps,
+// slices and scalars, against the Schema
+func ValidateSpec(spec any) error {
+	s, err := compiledSchema()
+	if err != nil {
+		return fmt.Errorf("unable to compile the policy schema: %w", err)
+	}
+
+	return s.Validate(spec)
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateSpec(t *testing.T) {
+	cases := []struct {
+		name  string
+		spec  string
+		valid bool
+	}{
+		{name: "empty", spec: `{}`, valid: true},
+		{name: "sources", spec: `{"sources": [{"name": "Default", "policy": ["oci::quay.io/acme/policy:latest"]}]}`, valid: true},
+		{name: "unknown field", spec: `{"sourcez": []}`},
+		{name: "wrong type", spec: `{"sources": {"name": "Default"}}`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var spec any
+			if err := json.Unmarshal([]byte(c.spec), &spec); err != nil {
+				t.Fatal(err)
+			}
+
+			err := ValidateSpec(spec)
+			if c.valid && err != nil {
+				t.Errorf("expected the spec to be valid, got %v", err)
+			}
+			if !c.valid && err == nil {
+				t.Error("expected the spec to be invalid")
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SourceRewriteSpec defines prefix rewrite rules of the URLs of policy rules,
+// data and the Rekor instance
+type SourceRewriteSpec struct {
+	// Rewrites lists the prefix rewrite rules
+	// +kubebuilder:validation:MinItems:=1
+	Rewrites []PrefixRewrite `json:"rewrites"`
+}
+
+// PrefixRewrite replaces a URL prefix with the prefix of a mirror
+type PrefixRewrite struct {
+	// Source is the prefix of the URLs to rewrite, e.g.
+	// oci::quay.io/enterprise-contract/. URLs are matched as written in the
+	// policy, including the go-getter forced getter prefix.
+	// +kubebuilder:validation:MinLength:=1
+	Source string `json:"source"`
+	// Mirror replaces the source prefix, e.g.
+	// oci::registry.internal/enterprise-contract/
+	// +kubebuilder:validation:MinLength:=1
+	Mirror string `json:"mirror"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName={srw}
+// SourceRewrite rewrites the URLs of the policy rules, data and the Rekor
+// instance of all policies in the cluster, pointing them to mirrors reachable
+// from disconnected clusters, similar to ImageContentSourcePolicy for images.
+// The longest matching source prefix of all SourceRewrite resources applies.
+type SourceRewrite struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SourceRewriteSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SourceRewriteList contains a list of SourceRewrite
+type SourceRewriteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SourceRewrite `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SourceRewrite{}, &SourceRewriteList{})
+}
@@ -0,0 +1,351 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testing provides builders, fixtures and a fake client for use in
+// tests of code that consumes the v1alpha1 API.
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// PolicySpecBuilder builds an EnterpriseContractPolicySpec
+type PolicySpecBuilder struct {
+	spec ecc.EnterpriseContractPolicySpec
+}
+
+// NewPolicySpec returns a builder of an empty EnterpriseContractPolicySpec
+func NewPolicySpec() *PolicySpecBuilder {
+	return &PolicySpecBuilder{}
+}
+
+// WithName sets the name of the policy
+func (b *PolicySpecBuilder) WithName(name string) *PolicySpecBuilder {
+	b.spec.Name = name
+	return b
+}
+
+// WithDescription sets the description of the policy
+func (b *PolicySpecBuilder) WithDescription(description string) *PolicySpecBuilder {
+	b.spec.Description = description
+	return b
+}
+
+// WithSources appends the built sources to the policy
+func (b *PolicySpecBuilder) WithSources(sources ...*SourceBuilder) *PolicySpecBuilder {
+	for _, s := range sources {
+		b.spec.Sources = append(b.spec.Sources, s.Build())
+	}
+	return b
+}
+
+// WithPublicKey sets the public key used to verify signatures
+func (b *PolicySpecBuilder) WithPublicKey(publicKey string) *PolicySpecBuilder {
+	b.spec.PublicKey = publicKey
+	return b
+}
+
+// WithRekorUrl sets the URL of the Rekor instance
+func (b *PolicySpecBuilder) WithRekorUrl(url string) *PolicySpecBuilder {
+	b.spec.RekorUrl = url
+	return b
+}
+
+// WithIdentity sets the identity used for keyless verification
+func (b *PolicySpecBuilder) WithIdentity(identity ecc.Identity) *PolicySpecBuilder {
+	b.spec.Identity = &identity
+	return b
+}
+
+// WithConfiguration sets the deprecated policy wide configuration
+func (b *PolicySpecBuilder) WithConfiguration(configuration ecc.EnterpriseContractPolicyConfiguration) *PolicySpecBuilder {
+	b.spec.Configuration = &configuration
+	return b
+}
+
+// Build returns a copy of the built EnterpriseContractPolicySpec
+func (b *PolicySpecBuilder) Build() ecc.EnterpriseContractPolicySpec {
+	return *b.spec.DeepCopy()
+}
+
+// Policy returns an EnterpriseContractPolicy with the given namespace and name
+// and the built spec
+func (b *PolicySpecBuilder) Policy(namespace, name string) *ecc.EnterpriseContractPolicy {
+	return &ecc.EnterpriseContractPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "EnterpriseContractPolicy",
+			APIVersion: ecc.GroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: b.Build(),
+	}
+}
+
+// SourceBuilder builds a Source
+type SourceBuilder struct {
+	source ecc.Source
+}
+
+// NewSource returns a builder of a Source with the given name
+func NewSource(name string) *SourceBuilder {
+	return &SourceBuilder{source: ecc.Source{Name: name}}
+}
+
+// WithPolicy appends go-getter style policy source URLs
+func (b *SourceBuilder) WithPolicy(urls ...string) *SourceBuilder {
+	b.source.Policy = append(b.source.Policy, urls...)
+	return b
+}
+
+// WithData appends go-getter style data source URLs
+func (b *SourceBuilder) WithData(urls ...string) *SourceBuilder {
+	b.source.Data = append(b.source.Data, urls...)
+	return b
+}
+
+// WithRuleData sets the rule data to the JSON representation of the given
+// value, panics if the value cannot be represented as JSON
+func (b *SourceBuilder) WithRuleData(data any) *SourceBuilder {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		panic(fmt.Sprintf("unable to marshal rule data: %v", err))
+	}
+	b.source.RuleData = &extv1.JSON{Raw: raw}
+	return b
+}
+
+// WithRuleDataFromConfigMap appends a reference to rule data in a ConfigMap,
+// all keys of the ConfigMap are used when key is empty
+func (b *SourceBuilder) WithRuleDataFromConfigMap(name, key string, optional bool) *SourceBuilder {
+	b.source.RuleDataFrom = append(b.source.RuleDataFrom, ecc.RuleDataReference{
+		ConfigMapRef: &ecc.RuleDataKeySelector{Name: name, Key: key, Optional: optional},
+	})
+	return b
+}
+
+// WithRuleDataFromSecret appends a reference to rule data in a Secret, all
+// keys of the Secret are used when key is empty
+func (b *SourceBuilder) WithRuleDataFromSecret(name, key string, optional bool) *SourceBuilder {
+	b.source.RuleDataFrom = append(b.source.RuleDataFrom, ecc.RuleDataReference{
+		SecretRef: &ecc.RuleDataKeySelector{Name: name, Key: key, Optional: optional},
+	})
+	return b
+}
+
+// WithInclude appends policy rules to include
+func (b *SourceBuilder) WithInclude(values ...string) *SourceBuilder {
+	b.config().Include = append(b.config().Include, values...)
+	return b
+}
+
+// WithExclude appends policy rules to exclude
+func (b *SourceBuilder) WithExclude(values ...string) *SourceBuilder {
+	b.config().Exclude = append(b.config().Exclude, values...)
+	return b
+}
+
+// WithVolatileInclude appends the built criteria to the volatile includes
+func (b *SourceBuilder) WithVolatileInclude(criteria ...*VolatileCriteriaBuilder) *SourceBuilder {
+	for _, c := range criteria {
+		b.volatileConfig().Include = append(b.volatileConfig().Include, c.Build())
+	}
+	return b
+}
+
+// WithVolatileExclude appends the built criteria to the volatile excludes
+func (b *SourceBuilder) WithVolatileExclude(criteria ...*VolatileCriteriaBuilder) *SourceBuilder {
+	for _, c := range criteria {
+		b.volatileConfig().Exclude = append(b.volatileConfig().Exclude, c.Build())
+	}
+	return b
+}
+
+// WithBundleSignature requires the OCI bundles of the source to be signed
+func (b *SourceBuilder) WithBundleSignature(signature ecc.BundleSignature) *SourceBuilder {
+	b.source.BundleSignature = &signature
+	return b
+}
+
+// WithCommitSignature requires the git commits of the source to be signed
+// with one of the allowed keys
+func (b *SourceBuilder) WithCommitSignature(allowedKeys ...string) *SourceBuilder {
+	b.source.CommitSignature = &ecc.CommitSignature{AllowedKeys: allowedKeys}
+	return b
+}
+
+// Build returns a copy of the built Source
+func (b *SourceBuilder) Build() ecc.Source {
+	return *b.source.DeepCopy()
+}
+
+func (b *SourceBuilder) config() *ecc.SourceConfig {
+	if b.source.Config == nil {
+		b.source.Config = &ecc.SourceConfig{}
+	}
+	return b.source.Config
+}
+
+func (b *SourceBuilder) volatileConfig() *ecc.VolatileSourceConfig {
+	if b.source.VolatileConfig == nil {
+		b.source.VolatileConfig = &ecc.VolatileSourceConfig{}
+	}
+	return b.source.VolatileConfig
+}
+
+// VolatileCriteriaBuilder builds a VolatileCriteria
+type VolatileCriteriaBuilder struct {
+	criteria ecc.VolatileCriteria
+}
+
+// NewVolatileCriteria returns a builder of a VolatileCriteria for the given
+// rule, collection or package
+func NewVolatileCriteria(value string) *VolatileCriteriaBuilder {
+	return &VolatileCriteriaBuilder{criteria: ecc.VolatileCriteria{Value: value}}
+}
+
+// EffectiveOn sets the time from which the criteria is in effect
+func (b *VolatileCriteriaBuilder) EffectiveOn(t time.Time) *VolatileCriteriaBuilder {
+	b.criteria.EffectiveOn = t.UTC().Format(time.RFC3339)
+	return b
+}
+
+// EffectiveUntil sets the time until which the criteria is in effect
+func (b *VolatileCriteriaBuilder) EffectiveUntil(t time.Time) *VolatileCriteriaBuilder {
+	b.criteria.EffectiveUntil = t.UTC().Format(time.RFC3339)
+	return b
+}
+
+// ForImageDigest limits the criteria to the image with the given digest
+func (b *VolatileCriteriaBuilder) ForImageDigest(digest string) *VolatileCriteriaBuilder {
+	b.criteria.ImageDigest = digest
+	return b
+}
+
+// ForImageUrl limits the criteria to images from the given repository URL
+func (b *VolatileCriteriaBuilder) ForImageUrl(url string) *VolatileCriteriaBuilder {
+	b.criteria.ImageUrl = url
+	return b
+}
+
+// ForComponent limits the criteria to the given component
+func (b *VolatileCriteriaBuilder) ForComponent(component string) *VolatileCriteriaBuilder {
+	b.criteria.Component = component
+	return b
+}
+
+// ForApplication limits the criteria to the given application
+func (b *VolatileCriteriaBuilder) ForApplication(application string) *VolatileCriteriaBuilder {
+	b.criteria.Application = application
+	return b
+}
+
+// WithAttribute limits the criteria to evaluations with the given attribute
+func (b *VolatileCriteriaBuilder) WithAttribute(key, value string) *VolatileCriteriaBuilder {
+	if b.criteria.Attributes == nil {
+		b.criteria.Attributes = map[string]string{}
+	}
+	b.criteria.Attributes[key] = value
+	return b
+}
+
+// WithReference sets the link to related information, e.g. a Jira issue URL
+func (b *VolatileCriteriaBuilder) WithReference(reference string) *VolatileCriteriaBuilder {
+	b.criteria.Reference = reference
+	return b
+}
+
+// Build returns a copy of the built VolatileCriteria
+func (b *VolatileCriteriaBuilder) Build() ecc.VolatileCriteria {
+	return *b.criteria.DeepCopy()
+}
+
+// NewRuleCollection returns a RuleCollection with the given rules
+func NewRuleCollection(namespace, name string, rules ...string) *ecc.RuleCollection {
+	return &ecc.RuleCollection{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RuleCollection",
+			APIVersion: ecc.GroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: ecc.RuleCollectionSpec{
+			Rules: rules,
+		},
+	}
+}
+
+// NewSourceRewrite returns a SourceRewrite with the given rewrite rules
+func NewSourceRewrite(name string, rewrites ...ecc.PrefixRewrite) *ecc.SourceRewrite {
+	return &ecc.SourceRewrite{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "SourceRewrite",
+			APIVersion: ecc.GroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: ecc.SourceRewriteSpec{
+			Rewrites: rewrites,
+		},
+	}
+}
+
+// NewPolicySnapshot returns a PolicySnapshot of the given policy
+func NewPolicySnapshot(namespace, name, policy string) *ecc.PolicySnapshot {
+	return &ecc.PolicySnapshot{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PolicySnapshot",
+			APIVersion: ecc.GroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: ecc.PolicySnapshotSpec{
+			Policy: policy,
+		},
+	}
+}
+
+// NewPolicySubscription returns a PolicySubscription syncing the policies in
+// the namespace with the source
+func NewPolicySubscription(namespace, name, source string) *ecc.PolicySubscription {
+	return &ecc.PolicySubscription{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PolicySubscription",
+			APIVersion: ecc.GroupVersion.Identifier(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: ecc.PolicySubscriptionSpec{
+			Source: source,
+		},
+	}
+}
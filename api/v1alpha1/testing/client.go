@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// NewScheme returns a scheme with the Kubernetes and the v1alpha1 types
+// registered
+func NewScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(ecc.AddToScheme(scheme))
+
+	return scheme
+}
+
+// NewFakeClient returns a fake client with the v1alpha1 types registered,
+// populated with the given objects
+func NewFakeClient(objs ...client.Object) client.WithWatch {
+	return NewFakeClientBuilder().WithObjects(objs...).Build()
+}
+
+// NewFakeClientBuilder returns a fake client builder with the v1alpha1 types
+// registered and the status subresource enabled, for further customization
+func NewFakeClientBuilder() *fake.ClientBuilder {
+	return fake.NewClientBuilder().
+		WithScheme(NewScheme()).
+		WithStatusSubresource(&ecc.EnterpriseContractPolicy{}, &ecc.PolicySnapshot{}, &ecc.PolicySubscription{})
+}
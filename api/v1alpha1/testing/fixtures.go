@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"time"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+const (
+	// ReleasePolicyURL is the URL of the Enterprise Contract release policy bundle
+	ReleasePolicyURL = "oci::quay.io/enterprise-contract/ec-release-policy:latest"
+	// AcceptableBundlesURL is the URL of the acceptable Tekton bundles data
+	AcceptableBundlesURL = "oci::quay.io/konflux-ci/tekton-catalog/data-acceptable-bundles:latest"
+	// PolicyDataURL is the URL of the policy data in git
+	PolicyDataURL = "github.com/release-engineering/rhtap-ec-policy//data"
+	// PublicKey is a Kubernetes reference to the public key used to verify signatures
+	PublicKey = "k8s://openshift-pipelines/public-key"
+	// RekorURL is the URL of the public Rekor instance
+	RekorURL = "https://rekor.sigstore.dev"
+	// ImageDigest is the digest of an image referenced by the fixtures
+	ImageDigest = "sha256:cfe1335814d92eabecfe9802f13298539caa7bbd0a13b61f320dc45bdded473d"
+	// ImageUrl is the repository of an image referenced by the fixtures
+	ImageUrl = "quay.io/acme/widget"
+)
+
+var (
+	// EffectiveOn is the start of the volatile configuration in the fixtures
+	EffectiveOn = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// EffectiveUntil is the end of the volatile configuration in the fixtures
+	EffectiveUntil = time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// MinimalPolicySpec returns a policy with a single source of policy rules
+func MinimalPolicySpec() *PolicySpecBuilder {
+	return NewPolicySpec().
+		WithSources(NewSource("Default").WithPolicy(ReleasePolicyURL))
+}
+
+// DefaultPolicySpec returns a policy similar to the default Enterprise
+// Contract policy, verifying signatures with a public key
+func DefaultPolicySpec() *PolicySpecBuilder {
+	return NewPolicySpec().
+		WithName("Default").
+		WithDescription("Includes rules for levels 1, 2 & 3 of SLSA v0.1. This is the default config used for new Konflux applications.").
+		WithPublicKey(PublicKey).
+		WithRekorUrl(RekorURL).
+		WithSources(NewSource("Default").
+			WithPolicy(ReleasePolicyURL).
+			WithData(AcceptableBundlesURL, PolicyDataURL).
+			WithInclude("@slsa3").
+			WithExclude("cve.cve_results_found"))
+}
+
+// KeylessPolicySpec returns a policy verifying signatures made using keyless
+// signing from GitHub Actions workflows
+func KeylessPolicySpec() *PolicySpecBuilder {
+	return NewPolicySpec().
+		WithName("Keyless").
+		WithDescription("Verifies images signed using keyless signing in GitHub Actions").
+		WithRekorUrl(RekorURL).
+		WithIdentity(ecc.Identity{
+			SubjectRegExp: `^https://github\.com/acme/widget/\.github/workflows/release\.yaml@refs/heads/main$`,
+			Issuer:        "https://token.actions.githubusercontent.com",
+		}).
+		WithSources(NewSource("Default").
+			WithPolicy(ReleasePolicyURL).
+			WithData(AcceptableBundlesURL).
+			WithInclude("@slsa3"))
+}
+
+// VolatilePolicySpec returns a policy with time bound includes and excludes
+// scoped to a specific image digest and repository
+func VolatilePolicySpec() *PolicySpecBuilder {
+	return NewPolicySpec().
+		WithName("Volatile").
+		WithPublicKey(PublicKey).
+		WithSources(NewSource("Default").
+			WithPolicy(ReleasePolicyURL).
+			WithData(AcceptableBundlesURL).
+			WithInclude("@slsa3").
+			WithVolatileExclude(
+				NewVolatileCriteria("test.no_failed_tests").
+					EffectiveUntil(EffectiveUntil).
+					ForImageDigest(ImageDigest).
+					WithReference("https://issues.redhat.com/browse/EC-1246"),
+				NewVolatileCriteria("cve.cve_blockers").
+					EffectiveOn(EffectiveOn).
+					EffectiveUntil(EffectiveUntil).
+					ForImageUrl(ImageUrl).
+					WithReference("https://issues.redhat.com/browse/EC-1101"),
+			).
+			WithVolatileInclude(
+				NewVolatileCriteria("@redhat").
+					EffectiveOn(EffectiveUntil),
+			))
+}
+
+// MultiSourcePolicySpec returns a policy combining the release policy with
+// custom rules, each with their own rule data
+func MultiSourcePolicySpec() *PolicySpecBuilder {
+	return NewPolicySpec().
+		WithName("ACME").
+		WithDescription("ACME Enterprise Contract Policy configuration").
+		WithPublicKey(PublicKey).
+		WithSources(
+			NewSource("Default EC Policies").
+				WithPolicy(ReleasePolicyURL).
+				WithData("git::https://github.com/conforma/policy//example/data").
+				WithRuleData(map[string]any{
+					"allowed_registry_prefixes": []string{
+						"registry.access.redhat.com/",
+						"registry.redhat.io/",
+						"registry.io/acme/",
+					},
+				}).
+				WithInclude("@slsa1", "@slsa2"),
+			NewSource("ACME Policies").
+				WithPolicy("oci::registry.io/acme/enterprise-rules:latest").
+				WithRuleData(map[string]any{
+					"friday_deployments": false,
+				}).
+				WithInclude("@acme").
+				WithExclude("acme.room_temperature"),
+		)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+func TestFixturesAreValid(t *testing.T) {
+	crd := v1.CustomResourceDefinition{}
+	bytes, err := os.ReadFile("../../config/appstudio.redhat.com_enterprisecontractpolicies.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error reading CRD: %s", err)
+	}
+	if err := yaml.Unmarshal(bytes, &crd); err != nil {
+		t.Fatalf("unexpected error when decoding schema: %s", err)
+	}
+
+	crdv := apiextensions.CustomResourceValidation{}
+	if err := v1.Convert_v1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(crd.Spec.Versions[0].Schema, &crdv, nil); err != nil {
+		t.Fatalf("failed in CRD validation conversion: %s", err)
+	}
+
+	s, err := schema.NewStructural(crdv.OpenAPIV3Schema)
+	if err != nil {
+		t.Fatalf("unexpected error when creating structural: %s", err)
+	}
+
+	v := validation.NewSchemaValidatorFromOpenAPI(s.ToKubeOpenAPI())
+
+	fixtures := map[string]*PolicySpecBuilder{
+		"minimal":      MinimalPolicySpec(),
+		"default":      DefaultPolicySpec(),
+		"keyless":      KeylessPolicySpec(),
+		"volatile":     VolatilePolicySpec(),
+		"multi-source": MultiSourcePolicySpec(),
+	}
+
+	for name, fixture := range fixtures {
+		t.Run(name, func(t *testing.T) {
+			policyBytes, err := json.Marshal(fixture.Policy("acme", name))
+			if err != nil {
+				t.Fatalf("unexpected error marshaling policy: %s", err)
+			}
+
+			obj := unstructured.Unstructured{}
+			if err := json.Unmarshal(policyBytes, &obj.Object); err != nil {
+				t.Fatalf("unexpected error unmarshaling policy: %s", err)
+			}
+
+			if result := v.Validate(&obj); !result.IsValid() {
+				t.Errorf("fixture failed schema validation with: %v", result.Errors)
+			}
+		})
+	}
+}
+
+func TestBuilders(t *testing.T) {
+	spec := NewPolicySpec().
+		WithName("name").
+		WithSources(NewSource("source").
+			WithPolicy("a", "b").
+			WithRuleData(map[string]int{"x": 1}).
+			WithInclude("i").
+			WithExclude("e").
+			WithVolatileExclude(NewVolatileCriteria("v").
+				EffectiveOn(EffectiveOn).
+				ForImageDigest(ImageDigest))).
+		Build()
+
+	expected := ecc.EnterpriseContractPolicySpec{
+		Name: "name",
+		Sources: []ecc.Source{{
+			Name:     "source",
+			Policy:   []string{"a", "b"},
+			RuleData: &v1.JSON{Raw: []byte(`{"x":1}`)},
+			Config: &ecc.SourceConfig{
+				Include: []string{"i"},
+				Exclude: []string{"e"},
+			},
+			VolatileConfig: &ecc.VolatileSourceConfig{
+				Exclude: []ecc.VolatileCriteria{{
+					Value:       "v",
+					EffectiveOn: "2024-01-01T00:00:00Z",
+					ImageDigest: ImageDigest,
+				}},
+			},
+		}},
+	}
+
+	if !reflect.DeepEqual(expected, spec) {
+		t.Errorf("expected %#v, got %#v", expected, spec)
+	}
+}
+
+func TestBuildReturnsCopies(t *testing.T) {
+	b := MinimalPolicySpec()
+	first := b.Build()
+	first.Sources[0].Policy[0] = "changed"
+
+	if b.Build().Sources[0].Policy[0] != ReleasePolicyURL {
+		t.Error("modifying the built spec modified the builder")
+	}
+}
+
+func TestFakeClient(t *testing.T) {
+	ctx := context.Background()
+	policy := DefaultPolicySpec().Policy("acme", "default")
+	c := NewFakeClient(policy)
+
+	got := ecc.EnterpriseContractPolicy{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(policy), &got); err != nil {
+		t.Fatalf("unexpected error getting policy: %v", err)
+	}
+
+	if !reflect.DeepEqual(policy.Spec, got.Spec) {
+		t.Errorf("expected %v, got %v", policy.Spec, got.Spec)
+	}
+
+	if err := c.Status().Update(ctx, &got); err != nil {
+		t.Errorf("unexpected error updating status: %v", err)
+	}
+}
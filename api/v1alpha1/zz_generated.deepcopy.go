@@ -22,16 +22,57 @@ package v1alpha1
 
 import (
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleSignature) DeepCopyInto(out *BundleSignature) {
+	*out = *in
+	if in.Identity != nil {
+		in, out := &in.Identity, &out.Identity
+		*out = new(Identity)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleSignature.
+func (in *BundleSignature) DeepCopy() *BundleSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommitSignature) DeepCopyInto(out *CommitSignature) {
+	*out = *in
+	if in.AllowedKeys != nil {
+		in, out := &in.AllowedKeys, &out.AllowedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitSignature.
+func (in *CommitSignature) DeepCopy() *CommitSignature {
+	if in == nil {
+		return nil
+	}
+	out := new(CommitSignature)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnterpriseContractPolicy) DeepCopyInto(out *EnterpriseContractPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnterpriseContractPolicy.
@@ -149,6 +190,20 @@ func (in *EnterpriseContractPolicySpec) DeepCopy() *EnterpriseContractPolicySpec
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnterpriseContractPolicyStatus) DeepCopyInto(out *EnterpriseContractPolicyStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]SourceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnterpriseContractPolicyStatus.
@@ -176,6 +231,395 @@ func (in *Identity) DeepCopy() *Identity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyExport) DeepCopyInto(out *PolicyExport) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExport.
+func (in *PolicyExport) DeepCopy() *PolicyExport {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySnapshot) DeepCopyInto(out *PolicySnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySnapshot.
+func (in *PolicySnapshot) DeepCopy() *PolicySnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicySnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySnapshotList) DeepCopyInto(out *PolicySnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PolicySnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySnapshotList.
+func (in *PolicySnapshotList) DeepCopy() *PolicySnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicySnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySnapshotSpec) DeepCopyInto(out *PolicySnapshotSpec) {
+	*out = *in
+	if in.Export != nil {
+		in, out := &in.Export, &out.Export
+		*out = new(PolicyExport)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySnapshotSpec.
+func (in *PolicySnapshotSpec) DeepCopy() *PolicySnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySnapshotStatus) DeepCopyInto(out *PolicySnapshotStatus) {
+	*out = *in
+	if in.CapturedAt != nil {
+		in, out := &in.CapturedAt, &out.CapturedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(EnterpriseContractPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuleDataHash != nil {
+		in, out := &in.RuleDataHash, &out.RuleDataHash
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySnapshotStatus.
+func (in *PolicySnapshotStatus) DeepCopy() *PolicySnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySubscription) DeepCopyInto(out *PolicySubscription) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySubscription.
+func (in *PolicySubscription) DeepCopy() *PolicySubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicySubscription) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySubscriptionList) DeepCopyInto(out *PolicySubscriptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PolicySubscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySubscriptionList.
+func (in *PolicySubscriptionList) DeepCopy() *PolicySubscriptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySubscriptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicySubscriptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySubscriptionSpec) DeepCopyInto(out *PolicySubscriptionSpec) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySubscriptionSpec.
+func (in *PolicySubscriptionSpec) DeepCopy() *PolicySubscriptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySubscriptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicySubscriptionStatus) DeepCopyInto(out *PolicySubscriptionStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Drifted != nil {
+		in, out := &in.Drifted, &out.Drifted
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicySubscriptionStatus.
+func (in *PolicySubscriptionStatus) DeepCopy() *PolicySubscriptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicySubscriptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrefixRewrite) DeepCopyInto(out *PrefixRewrite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixRewrite.
+func (in *PrefixRewrite) DeepCopy() *PrefixRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(PrefixRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleCollection) DeepCopyInto(out *RuleCollection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleCollection.
+func (in *RuleCollection) DeepCopy() *RuleCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RuleCollection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleCollectionList) DeepCopyInto(out *RuleCollectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RuleCollection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleCollectionList.
+func (in *RuleCollectionList) DeepCopy() *RuleCollectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleCollectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RuleCollectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleCollectionSpec) DeepCopyInto(out *RuleCollectionSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleCollectionSpec.
+func (in *RuleCollectionSpec) DeepCopy() *RuleCollectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleCollectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleDataError) DeepCopyInto(out *RuleDataError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleDataError.
+func (in *RuleDataError) DeepCopy() *RuleDataError {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleDataError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleDataKeySelector) DeepCopyInto(out *RuleDataKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleDataKeySelector.
+func (in *RuleDataKeySelector) DeepCopy() *RuleDataKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleDataKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleDataReference) DeepCopyInto(out *RuleDataReference) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(RuleDataKeySelector)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(RuleDataKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleDataReference.
+func (in *RuleDataReference) DeepCopy() *RuleDataReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleDataReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
@@ -194,6 +638,13 @@ func (in *Source) DeepCopyInto(out *Source) {
 		*out = new(v1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RuleDataFrom != nil {
+		in, out := &in.RuleDataFrom, &out.RuleDataFrom
+		*out = make([]RuleDataReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(SourceConfig)
@@ -204,6 +655,16 @@ func (in *Source) DeepCopyInto(out *Source) {
 		*out = new(VolatileSourceConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.BundleSignature != nil {
+		in, out := &in.BundleSignature, &out.BundleSignature
+		*out = new(BundleSignature)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CommitSignature != nil {
+		in, out := &in.CommitSignature, &out.CommitSignature
+		*out = new(CommitSignature)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Source.
@@ -241,9 +702,206 @@ func (in *SourceConfig) DeepCopy() *SourceConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceMirror) DeepCopyInto(out *SourceMirror) {
+	*out = *in
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceMirror.
+func (in *SourceMirror) DeepCopy() *SourceMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceRewrite) DeepCopyInto(out *SourceRewrite) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceRewrite.
+func (in *SourceRewrite) DeepCopy() *SourceRewrite {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceRewrite)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SourceRewrite) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceRewriteList) DeepCopyInto(out *SourceRewriteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SourceRewrite, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceRewriteList.
+func (in *SourceRewriteList) DeepCopy() *SourceRewriteList {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceRewriteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SourceRewriteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceRewriteSpec) DeepCopyInto(out *SourceRewriteSpec) {
+	*out = *in
+	if in.Rewrites != nil {
+		in, out := &in.Rewrites, &out.Rewrites
+		*out = make([]PrefixRewrite, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceRewriteSpec.
+func (in *SourceRewriteSpec) DeepCopy() *SourceRewriteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceRewriteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(SourceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UndefinedCollections != nil {
+		in, out := &in.UndefinedCollections, &out.UndefinedCollections
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnmatchedRules != nil {
+		in, out := &in.UnmatchedRules, &out.UnmatchedRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuleData != nil {
+		in, out := &in.RuleData, &out.RuleData
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuleDataErrors != nil {
+		in, out := &in.RuleDataErrors, &out.RuleDataErrors
+		*out = make([]RuleDataError, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(SourceMirror)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerifiedBundles != nil {
+		in, out := &in.VerifiedBundles, &out.VerifiedBundles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VerifiedCommits != nil {
+		in, out := &in.VerifiedCommits, &out.VerifiedCommits
+		*out = make([]VerifiedCommit, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceStatus.
+func (in *SourceStatus) DeepCopy() *SourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerifiedCommit) DeepCopyInto(out *VerifiedCommit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerifiedCommit.
+func (in *VerifiedCommit) DeepCopy() *VerifiedCommit {
+	if in == nil {
+		return nil
+	}
+	out := new(VerifiedCommit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolatileCriteria) DeepCopyInto(out *VolatileCriteria) {
 	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolatileCriteria.
@@ -262,12 +920,16 @@ func (in *VolatileSourceConfig) DeepCopyInto(out *VolatileSourceConfig) {
 	if in.Exclude != nil {
 		in, out := &in.Exclude, &out.Exclude
 		*out = make([]VolatileCriteria, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Include != nil {
 		in, out := &in.Include, &out.Include
 		*out = make([]VolatileCriteria, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -0,0 +1,90 @@
+// Copyright 2025 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package client_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/client/clientset/versioned/fake"
+	"github.com/enterprise-contract/enterprise-contract-controller/client/informers/externalversions"
+)
+
+func TestInformerAndLister(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	clientset := fake.NewSimpleClientset(&ecc.EnterpriseContractPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "acme"},
+	})
+
+	factory := externalversions.NewSharedInformerFactory(clientset, 0)
+	informer := factory.Appstudio().V1alpha1().EnterpriseContractPolicies()
+
+	added := make(chan string, 2)
+	if _, err := informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			added <- obj.(*ecc.EnterpriseContractPolicy).Name
+		},
+	}); err != nil {
+		t.Fatalf("unable to add event handler: %v", err)
+	}
+
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	if _, err := clientset.AppstudioV1alpha1().EnterpriseContractPolicies("acme").Create(ctx, &ecc.EnterpriseContractPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "created", Namespace: "acme"},
+		Spec: ecc.EnterpriseContractPolicySpec{
+			Sources: []ecc.Source{{Policy: []string{"oci::quay.io/acme/policy:latest"}}},
+		},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("unable to create policy: %v", err)
+	}
+
+	seen := map[string]bool{}
+	for len(seen) < 2 {
+		select {
+		case name := <-added:
+			seen[name] = true
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for the informer, seen: %v", seen)
+		}
+	}
+
+	policies, err := informer.Lister().EnterpriseContractPolicies("acme").List(labels.Everything())
+	if err != nil {
+		t.Fatalf("unable to list policies: %v", err)
+	}
+	if len(policies) != 2 {
+		t.Errorf("expected 2 policies in the lister, got %d", len(policies))
+	}
+
+	created, err := informer.Lister().EnterpriseContractPolicies("acme").Get("created")
+	if err != nil {
+		t.Fatalf("unable to get policy: %v", err)
+	}
+	if created.Spec.Sources[0].Policy[0] != "oci::quay.io/acme/policy:latest" {
+		t.Errorf("unexpected policy spec: %v", created.Spec)
+	}
+}
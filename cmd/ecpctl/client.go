@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(ecc.AddToScheme(scheme))
+}
+
+// newClient returns a client reading the objects in the given YAML files,
+// with the objects without a namespace in the given namespace, or the client
+// of the cluster of the current kubeconfig context when no files are given
+func newClient(files []string, namespace string) (client.Client, error) {
+	if len(files) == 0 {
+		config, err := ctrl.GetConfig()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the kubeconfig: %w", err)
+		}
+
+		c, err := client.New(config, client.Options{Scheme: scheme})
+		if err != nil {
+			return nil, fmt.Errorf("unable to create a client: %w", err)
+		}
+
+		return c, nil
+	}
+
+	var objs []client.Object
+	for _, file := range files {
+		loaded, err := load(file, namespace)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, loaded...)
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(), nil
+}
+
+// load decodes the objects in the YAML file, "-" reads the standard input
+func load(file, namespace string) ([]client.Object, error) {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open %s: %w", file, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+
+	var objs []client.Object
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", file, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 || strings.TrimSpace(string(doc)) == "---" {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %s: %w", file, err)
+		}
+
+		o, ok := obj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unsupported object %T in %s", obj, file)
+		}
+		if o.GetNamespace() == "" {
+			o.SetNamespace(namespace)
+		}
+		objs = append(objs, o)
+	}
+
+	return objs, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/controllers"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/artifact"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+)
+
+// passwordEnv holds the password of encrypted signing keys, as with cosign
+const passwordEnv = "COSIGN_PASSWORD"
+
+// files is a flag given multiple times
+type files []string
+
+func (f *files) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *files) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// export flattens a policy, as captured by a PolicySnapshot, and pushes it as
+// an OCI artifact
+func export(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: ecpctl export [flags] POLICY REFERENCE\n\n"+
+			"Flattens the EnterpriseContractPolicy named POLICY, pushes it as an OCI artifact to REFERENCE\n"+
+			"and prints the digest reference of the pushed artifact. The password of encrypted signing\n"+
+			"keys is read from the %s environment variable.\n\nFlags:\n", passwordEnv)
+		flags.PrintDefaults()
+	}
+
+	var from files
+	flags.Var(&from, "f", "YAML file with the policy and the objects it refers to, may be given multiple times, - reads the standard input. The cluster of the current kubeconfig context is used when not given.")
+	namespace := flags.String("n", "default", "Namespace of the policy.")
+	key := flags.String("key", "", "Private key file the artifact is signed with, the artifact is not signed when not given.")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return fmt.Errorf("expected the policy name and the reference, got %d arguments", flags.NArg())
+	}
+	name, ref := flags.Arg(0), flags.Arg(1)
+
+	opts := artifact.Options{}
+	if *key != "" {
+		private, err := os.ReadFile(*key)
+		if err != nil {
+			return fmt.Errorf("unable to read the signing key: %w", err)
+		}
+		if opts.Signer, err = artifact.LoadSigner(private, []byte(os.Getenv(passwordEnv))); err != nil {
+			return err
+		}
+	}
+
+	c, err := newClient(from, *namespace)
+	if err != nil {
+		return err
+	}
+
+	policy := ecc.EnterpriseContractPolicy{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: *namespace, Name: name}, &policy); err != nil {
+		return fmt.Errorf("unable to get policy %s/%s: %w", *namespace, name, err)
+	}
+
+	// run by the user, local git repositories are the user's own to pin
+	flattened, _, err := controllers.Flatten(ctx, c, &policy, fetch.PinLocal)
+	if err != nil {
+		return fmt.Errorf("unable to flatten policy %s/%s: %w", *namespace, name, err)
+	}
+
+	digest, err := artifact.Push(ctx, ref, flattened, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, digest.String())
+
+	return nil
+}
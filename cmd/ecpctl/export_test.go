@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/artifact"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify/verifytest"
+)
+
+const policyYAML = `apiVersion: appstudio.redhat.com/v1alpha1
+kind: EnterpriseContractPolicy
+metadata:
+  name: policy
+spec:
+  sources:
+    - name: Default
+      policy:
+        - /policy
+      config:
+        include:
+          - "@acme"
+---
+apiVersion: appstudio.redhat.com/v1alpha1
+kind: RuleCollection
+metadata:
+  name: acme
+spec:
+  rules:
+    - a
+    - b
+`
+
+func TestExport(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	dir := t.TempDir()
+
+	keys, err := cosign.GenerateKeyPair(func(bool) ([]byte, error) { return []byte("s3cr3t"), nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := filepath.Join(dir, "cosign.key")
+	policy := filepath.Join(dir, "policy.yaml")
+	for file, content := range map[string][]byte{key: keys.PrivateBytes, policy: []byte(policyYAML)} {
+		if err := os.WriteFile(file, content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv(passwordEnv, "s3cr3t")
+
+	var stdout, stderr bytes.Buffer
+	if err := run(context.Background(), []string{"export", "-f", policy, "-n", "acme", "-key", key, "policy", host + "/acme/policy:v1"}, &stdout, &stderr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	digest := strings.TrimSpace(stdout.String())
+	if _, err := verify.Bundle(context.Background(), "oci::"+digest, verify.Options{PublicKey: keys.PublicBytes}); err != nil {
+		t.Errorf("unable to verify the exported policy: %v", err)
+	}
+
+	exported, _, err := artifact.Pull(context.Background(), digest, artifact.Options{})
+	if err != nil {
+		t.Fatalf("unable to pull the exported policy: %v", err)
+	}
+	if include := exported.Sources[0].Config.Include; strings.Join(include, ",") != "a,b" {
+		t.Errorf("expected the collection to be expanded, got %v", include)
+	}
+}
+
+func TestExportMissingPolicy(t *testing.T) {
+	policy := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policy, []byte(policyYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"export", "-f", policy, "other", "registry.io/acme/policy:v1"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "unable to get policy default/other") {
+		t.Errorf("expected the policy not to be found, got %v", err)
+	}
+}
+
+func TestUnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run(context.Background(), []string{"unknown"}, &stdout, &stderr); err == nil || !strings.Contains(err.Error(), `unknown command "unknown"`) {
+		t.Errorf("expected an unknown command error, got %v", err)
+	}
+	if !strings.Contains(stderr.String(), "export") {
+		t.Errorf("expected the usage to list the commands, got %q", stderr.String())
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/lint"
+)
+
+// policyFinding is a finding of the analysis of a policy
+type policyFinding struct {
+	Namespace string `json:"namespace"`
+	Policy    string `json:"policy"`
+	lint.Finding
+}
+
+// lintPolicies reports the conflicting and redundant rule configuration of
+// the policies, failing when there is any
+func lintPolicies(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage: ecpctl lint [flags]\n\n"+
+			"Reports the includes and excludes of the EnterpriseContractPolicies that conflict, are shadowed by\n"+
+			"other entries, duplicate other volatile criteria or can never apply, exiting with an error when\n"+
+			"there are any.\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	var from files
+	flags.Var(&from, "f", "YAML file with the policies, may be given multiple times, - reads the standard input. The cluster of the current kubeconfig context is used when not given.")
+	namespace := flags.String("n", "", "Namespace of the policies, all namespaces when not given.")
+	format := flags.String("o", "text", "Output format, one of text, json.")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return fmt.Errorf("expected no arguments, got %d", flags.NArg())
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown output format %q, expected one of text, json", *format)
+	}
+
+	// objects in files without a namespace are in the default namespace, as
+	// when applied
+	defaultNamespace := *namespace
+	if defaultNamespace == "" {
+		defaultNamespace = "default"
+	}
+
+	c, err := newClient(from, defaultNamespace)
+	if err != nil {
+		return err
+	}
+
+	policies := ecc.EnterpriseContractPolicyList{}
+	if err := c.List(ctx, &policies, client.InNamespace(*namespace)); err != nil {
+		return fmt.Errorf("unable to list policies: %w", err)
+	}
+
+	findings := []policyFinding{}
+	inconsistent := 0
+	for _, p := range policies.Items {
+		analyzed := lint.Analyze(p.Spec)
+		if len(analyzed) > 0 {
+			inconsistent++
+		}
+		for _, f := range analyzed {
+			findings = append(findings, policyFinding{Namespace: p.Namespace, Policy: p.Name, Finding: f})
+		}
+	}
+
+	if *format == "json" {
+		e := json.NewEncoder(stdout)
+		e.SetIndent("", "  ")
+		if err := e.Encode(findings); err != nil {
+			return fmt.Errorf("unable to write the findings: %w", err)
+		}
+	} else {
+		for _, f := range findings {
+			fmt.Fprintf(stdout, "%s/%s: %s: %s\n", f.Namespace, f.Policy, f.Kind, f.Finding)
+		}
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("%d findings in %d of %d policies", len(findings), inconsistent, len(policies.Items))
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const lintYAML = `apiVersion: appstudio.redhat.com/v1alpha1
+kind: EnterpriseContractPolicy
+metadata:
+  name: policy
+  namespace: apps
+spec:
+  sources:
+    - config:
+        include:
+          - cve
+        exclude:
+          - cve
+      volatileConfig:
+        exclude:
+          - value: tasks
+            effectiveOn: "2025-07-01T00:00:00Z"
+            effectiveUntil: "2025-06-01T00:00:00Z"
+---
+apiVersion: appstudio.redhat.com/v1alpha1
+kind: EnterpriseContractPolicy
+metadata:
+  name: other
+spec:
+  sources:
+    - config:
+        exclude:
+          - test
+`
+
+func TestLint(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policies.yaml")
+	if err := os.WriteFile(file, []byte(lintYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"lint", "-f", file}, &stdout, &stderr)
+	if err == nil || err.Error() != "2 findings in 1 of 2 policies" {
+		t.Errorf("expected an error counting the findings, got %v", err)
+	}
+
+	expected := `apps/policy: Conflict: spec.sources[0].config.exclude[0]: "cve" is also included by spec.sources[0].config.include[0], the include takes precedence` + "\n" +
+		"apps/policy: Unreachable: spec.sources[0].volatileConfig.exclude[0].effectiveUntil: 2025-06-01T00:00:00Z is not after effectiveOn 2025-07-01T00:00:00Z, the criteria never applies\n"
+	if got := stdout.String(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	stdout.Reset()
+	err = run(context.Background(), []string{"lint", "-f", file, "-o", "json"}, &stdout, &stderr)
+	if err == nil {
+		t.Error("expected an error for the findings")
+	}
+	var findings []map[string]string
+	if err := json.Unmarshal(stdout.Bytes(), &findings); err != nil {
+		t.Fatalf("unexpected error parsing the findings: %v", err)
+	}
+	if len(findings) != 2 || findings[0]["namespace"] != "apps" || findings[0]["policy"] != "policy" || findings[0]["kind"] != "Conflict" || findings[0]["field"] != "spec.sources[0].config.exclude[0]" {
+		t.Errorf("unexpected findings %v", findings)
+	}
+
+	stdout.Reset()
+	if err := run(context.Background(), []string{"lint", "-f", file, "-n", "acme"}, &stdout, &stderr); err != nil {
+		t.Errorf("unexpected error linting a consistent policy: %v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("expected no findings, got:\n%s", stdout.String())
+	}
+}
+
+func TestLintUnknownFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"lint", "-o", "xml"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), `unknown output format "xml"`) {
+		t.Errorf("expected an unknown output format error, got %v", err)
+	}
+}
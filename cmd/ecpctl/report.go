@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/report"
+)
+
+// now returns the current time, replaced in tests
+var now = time.Now
+
+// exceptionReport reports the active excludes of the policies
+func exceptionReport(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), "Usage: ecpctl report [flags]\n\n"+
+			"Reports the active excludes of the EnterpriseContractPolicies, with their owner namespace, age\n"+
+			"and expiry, highlighting the excludes that never expire and the excludes without a reference.\n\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	var from files
+	flags.Var(&from, "f", "YAML file with the policies, may be given multiple times, - reads the standard input. The cluster of the current kubeconfig context is used when not given.")
+	namespace := flags.String("n", "", "Namespace of the policies, all namespaces when not given.")
+	format := flags.String("o", "json", "Output format, one of "+strings.Join(report.FormatNames(), ", ")+".")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return fmt.Errorf("expected no arguments, got %d", flags.NArg())
+	}
+
+	write, ok := report.Formats[*format]
+	if !ok {
+		return fmt.Errorf("unknown output format %q, expected one of %s", *format, strings.Join(report.FormatNames(), ", "))
+	}
+
+	// objects in files without a namespace are in the default namespace, as
+	// when applied
+	defaultNamespace := *namespace
+	if defaultNamespace == "" {
+		defaultNamespace = "default"
+	}
+
+	c, err := newClient(from, defaultNamespace)
+	if err != nil {
+		return err
+	}
+
+	policies := ecc.EnterpriseContractPolicyList{}
+	if err := c.List(ctx, &policies, client.InNamespace(*namespace)); err != nil {
+		return fmt.Errorf("unable to list policies: %w", err)
+	}
+
+	return write(stdout, report.Generate(policies.Items, now()))
+}
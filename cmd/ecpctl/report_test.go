@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const reportYAML = `apiVersion: appstudio.redhat.com/v1alpha1
+kind: EnterpriseContractPolicy
+metadata:
+  name: policy
+  namespace: apps
+spec:
+  sources:
+    - name: Default
+      config:
+        exclude:
+          - cve
+      volatileConfig:
+        exclude:
+          - value: tasks
+            effectiveUntil: "2025-07-01T00:00:00Z"
+            reference: https://issues.acme.com/SEC-1
+---
+apiVersion: appstudio.redhat.com/v1alpha1
+kind: EnterpriseContractPolicy
+metadata:
+  name: other
+spec:
+  sources:
+    - config:
+        exclude:
+          - test
+`
+
+func TestReport(t *testing.T) {
+	now = func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { now = time.Now })
+
+	file := filepath.Join(t.TempDir(), "policies.yaml")
+	if err := os.WriteFile(file, []byte(reportYAML), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := run(context.Background(), []string{"report", "-f", file, "-o", "csv"}, &stdout, &stderr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "namespace,policy,source,kind,value,since,ageDays,expires,neverExpires,reference,missingReference,imageUrl,imageDigest,component,application\n" +
+		"apps,policy,Default,source,cve,,,,true,,true,,,,\n" +
+		"apps,policy,Default,volatile,tasks,,,2025-07-01T00:00:00Z,false,https://issues.acme.com/SEC-1,false,,,,\n" +
+		"default,other,,source,test,,,,true,,true,,,,\n"
+	if got := stdout.String(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	stdout.Reset()
+	if err := run(context.Background(), []string{"report", "-f", file, "-n", "acme", "-o", "markdown"}, &stdout, &stderr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stdout.String(); !strings.Contains(got, "| acme | other |  | source | `test` |") || strings.Contains(got, "| apps |") {
+		t.Errorf("expected only the excludes of the acme namespace, including those of the policies without a namespace, got:\n%s", got)
+	}
+}
+
+func TestReportUnknownFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"report", "-o", "xml"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), `unknown output format "xml"`) {
+		t.Errorf("expected an unknown output format error, got %v", err)
+	}
+}
@@ -18,45 +18,659 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appstudioredhatcomv1alpha1 "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/catalogue"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/deprecation"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/effective"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/lint"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/mirror"
 )
 
 // EnterpriseContractPolicyReconciler reconciles a EnterpriseContractPolicy object
 type EnterpriseContractPolicyReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// Fetcher fetches the policy rules of the policy sources
+	Fetcher fetch.Fetcher
+	// Mirror, when set, caches the fetched policy rules and data to be served
+	// to policy runners
+	Mirror *mirror.Cache
+	// APIReader, when set, reads the Secrets referred to by the policies
+	// directly from the API server, e.g. mgr.GetAPIReader(), as only their
+	// metadata is cached
+	APIReader client.Reader
 }
 
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=enterprisecontractpolicies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=enterprisecontractpolicies/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=enterprisecontractpolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=rulecollections,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=sourcerewrites,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+// Secrets are only read with get: list and watch cache just their metadata,
+// to find the policies referring to them
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the EnterpriseContractPolicy object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
+// Reconcile computes the effective configuration of the policy sources and
+// records it, along with any problems found doing so, in the policy status.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.2/pkg/reconcile
 func (r *EnterpriseContractPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
+	logger := log.FromContext(ctx)
 
-	// TODO(user): your logic here
+	// counted for created, updated and deleted policies alike
+	if err := r.recordDeprecations(ctx, req.Namespace); err != nil {
+		return ctrl.Result{}, err
+	}
 
-	return ctrl.Result{}, nil
+	policy := appstudioredhatcomv1alpha1.EnterpriseContractPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	collections := appstudioredhatcomv1alpha1.RuleCollectionList{}
+	if err := r.List(ctx, &collections, client.InNamespace(req.Namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to list rule collections: %w", err)
+	}
+
+	sourceRewrites := appstudioredhatcomv1alpha1.SourceRewriteList{}
+	if err := r.List(ctx, &sourceRewrites); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to list source rewrites: %w", err)
+	}
+	rewrites := effective.RewritesFrom(sourceRewrites.Items)
+
+	status := policy.Status.DeepCopy()
+	status.ObservedGeneration = policy.Generation
+	status.Sources = nil
+	status.RekorUrl = ""
+	if rekorUrl, ok := rewrites.Rewrite(policy.Spec.RekorUrl); ok {
+		status.RekorUrl = rekorUrl
+	}
+
+	defined := effective.CollectionsFrom(collections.Items)
+	catalogues := make([]sourceCatalogue, 0, len(policy.Spec.Sources))
+	var undefined, unmatched, notReady, invalidRuleData []string
+	for i, source := range policy.Spec.Sources {
+		var previous []metav1.Condition
+		if i < len(policy.Status.Sources) && policy.Status.Sources[i].Name == source.Name {
+			previous = policy.Status.Sources[i].Conditions
+		}
+
+		sourceStatus, cat := r.reconcileSource(ctx, &policy, source, defined, rewrites, previous)
+		status.Sources = append(status.Sources, sourceStatus)
+
+		if cat != nil {
+			catalogues = append(catalogues, sourceCatalogue{Name: source.Name, Catalogue: cat})
+		}
+		if !meta.IsStatusConditionTrue(sourceStatus.Conditions, appstudioredhatcomv1alpha1.ConditionReady) {
+			notReady = append(notReady, sourceName(i, source))
+		}
+		undefined = append(undefined, sourceStatus.UndefinedCollections...)
+		unmatched = append(unmatched, sourceStatus.UnmatchedRules...)
+		if len(sourceStatus.RuleDataErrors) > 0 {
+			invalidRuleData = append(invalidRuleData, fmt.Sprintf("%s (%d violations)", sourceName(i, source), len(sourceStatus.RuleDataErrors)))
+			if len(sourceStatus.RuleDataErrors) > maxRuleDataErrors {
+				status.Sources[i].RuleDataErrors = sourceStatus.RuleDataErrors[:maxRuleDataErrors]
+			}
+		}
+	}
+
+	catalogueName, published, err := r.reconcileCatalogue(ctx, &policy, catalogues)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	status.RuleCatalogue = catalogueName
+	meta.SetStatusCondition(&status.Conditions, published)
+
+	meta.SetStatusCondition(&status.Conditions, collectionsCondition(policy.Generation, undefined))
+	meta.SetStatusCondition(&status.Conditions, sourcesReadyCondition(policy.Generation, notReady))
+	meta.SetStatusCondition(&status.Conditions, rulesMatchedCondition(policy.Generation, unmatched))
+	meta.SetStatusCondition(&status.Conditions, ruleDataCondition(policy.Generation, invalidRuleData))
+	meta.SetStatusCondition(&status.Conditions, rulesConsistentCondition(policy.Generation, lint.Analyze(policy.Spec)))
+
+	// sources failing to be fetched are retried, the failures are mostly
+	// transient: unavailable registries and git servers, or bundles and
+	// commits yet to be signed
+	result := ctrl.Result{RequeueAfter: retryAfter(time.Now(), status.Sources)}
+	if result.RequeueAfter > 0 {
+		logger.V(1).Info("retrying the sources not ready", "after", result.RequeueAfter)
+	}
+
+	if equality.Semantic.DeepEqual(policy.Status, *status) {
+		return result, nil
+	}
+
+	policy.Status = *status
+	if err := r.Status().Update(ctx, &policy); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to update the policy status: %w", err)
+	}
+	logger.V(1).Info("updated policy status")
+
+	return result, nil
+}
+
+// recordDeprecations records the number of policies in the namespace using
+// each deprecated field
+func (r *EnterpriseContractPolicyReconciler) recordDeprecations(ctx context.Context, namespace string) error {
+	policies := appstudioredhatcomv1alpha1.EnterpriseContractPolicyList{}
+	if err := r.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return fmt.Errorf("unable to list policies: %w", err)
+	}
+
+	deprecation.Record(namespace, policies.Items)
+
+	return nil
+}
+
+const (
+	// minRetryInterval is the shortest wait before retrying sources not ready
+	minRetryInterval = 10 * time.Second
+	// maxRetryInterval is the longest wait before retrying sources not ready
+	maxRetryInterval = 10 * time.Minute
+)
+
+// retryAfter returns the wait before retrying the sources not ready, zero
+// when all are ready. The retries back off exponentially: the wait is as long
+// as the sources have been not ready for, doubling with each retry, within
+// minRetryInterval and maxRetryInterval
+func retryAfter(now time.Time, sources []appstudioredhatcomv1alpha1.SourceStatus) time.Duration {
+	var since *time.Time
+	for _, s := range sources {
+		ready := meta.FindStatusCondition(s.Conditions, appstudioredhatcomv1alpha1.ConditionReady)
+		if ready == nil || ready.Status == metav1.ConditionTrue {
+			continue
+		}
+		if since == nil || ready.LastTransitionTime.Time.Before(*since) {
+			since = &ready.LastTransitionTime.Time
+		}
+	}
+
+	if since == nil {
+		return 0
+	}
+
+	return min(max(now.Sub(*since), minRetryInterval), maxRetryInterval)
+}
+
+// reconcileSource computes the status of a single policy source, returning
+// the catalogue of the rules of the source when the source could be fetched
+func (r *EnterpriseContractPolicyReconciler) reconcileSource(ctx context.Context, policy *appstudioredhatcomv1alpha1.EnterpriseContractPolicy, source appstudioredhatcomv1alpha1.Source, defined effective.Collections, rewrites effective.Rewrites, previous []metav1.Condition) (appstudioredhatcomv1alpha1.SourceStatus, *catalogue.Catalogue) {
+	generation := policy.Generation
+	config, missing := defined.SourceConfig(source.Config)
+
+	status := appstudioredhatcomv1alpha1.SourceStatus{
+		Name:       source.Name,
+		Conditions: append([]metav1.Condition(nil), previous...),
+		Policy:     rewrites.RewriteAll(source.Policy),
+		Data:       rewrites.RewriteAll(source.Data),
+		Config:     config,
+	}
+
+	// the policy rules and data are fetched from the rewritten URLs, pinned
+	// to the verified digests of signed bundles
+	rewritten := source
+	if status.Policy != nil {
+		rewritten.Policy = status.Policy
+	}
+	if status.Data != nil {
+		rewritten.Data = status.Data
+	}
+
+	ruleData, sensitive := source.RuleData, []string(nil)
+	resolved, err := resolveRuleData(ctx, withSecrets(r.Client, r.APIReader), policy.Namespace, source)
+	if err != nil {
+		log.FromContext(ctx).Info("unable to resolve the rule data", "source", source.Name, "error", err.Error())
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               appstudioredhatcomv1alpha1.ConditionReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             "RuleDataUnresolved",
+			Message:            err.Error(),
+		})
+		status.UndefinedCollections = missing
+
+		return status, nil
+	}
+	if resolved != nil {
+		ruleData, sensitive = resolved.effective, resolved.sensitive
+		status.RuleData = resolved.disclosed
+		status.RuleDataHash = resolved.hash
+	}
+
+	if source.BundleSignature != nil {
+		rekorUrl, _ := rewrites.Rewrite(policy.Spec.RekorUrl)
+		if rewritten, status.VerifiedBundles, err = verifyBundles(ctx, withSecrets(r.Client, r.APIReader), rewritten, policy.Namespace, rekorUrl); err != nil {
+			log.FromContext(ctx).Info("unable to verify the source", "source", source.Name, "error", err.Error())
+			meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+				Type:               appstudioredhatcomv1alpha1.ConditionReady,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: generation,
+				Reason:             "VerificationFailed",
+				Message:            err.Error(),
+			})
+			status.UndefinedCollections = missing
+
+			return status, nil
+		}
+	}
+
+	fetched, err := r.fetchSource(ctx, rewritten, policy.Namespace)
+	if err == nil {
+		defer fetched.Close()
+	}
+
+	var cat *catalogue.Catalogue
+	if err == nil {
+		cat, err = catalogue.Load(fetched.policy...)
+	}
+
+	if err != nil {
+		log.FromContext(ctx).Info("unable to fetch the source", "source", source.Name, "error", err.Error())
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               appstudioredhatcomv1alpha1.ConditionReady,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			Reason:             "FetchFailed",
+			Message:            err.Error(),
+		})
+		status.UndefinedCollections = missing
+
+		return status, nil
+	}
+
+	if source.CommitSignature != nil {
+		if status.VerifiedCommits, err = verifyCommits(ctx, rewritten, fetched); err != nil {
+			log.FromContext(ctx).Info("unable to verify the source", "source", source.Name, "error", err.Error())
+			meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+				Type:               appstudioredhatcomv1alpha1.ConditionReady,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: generation,
+				Reason:             "CommitVerificationFailed",
+				Message:            err.Error(),
+			})
+			status.UndefinedCollections = missing
+
+			return status, nil
+		}
+	}
+
+	ready := metav1.Condition{
+		Type:               appstudioredhatcomv1alpha1.ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "Fetched",
+		Message:            "Policy rules and data fetched",
+	}
+
+	if r.Mirror != nil {
+		if status.Mirror, err = mirrorSource(r.Mirror, source, fetched); err != nil {
+			log.FromContext(ctx).Error(err, "unable to mirror the source", "source", source.Name)
+			ready.Status, ready.Reason, ready.Message = metav1.ConditionFalse, "MirrorFailed", err.Error()
+		}
+	}
+
+	meta.SetStatusCondition(&status.Conditions, ready)
+
+	for _, name := range missing {
+		if !cat.HasCollection(name) {
+			status.UndefinedCollections = append(status.UndefinedCollections, name)
+		}
+	}
+
+	if config != nil {
+		var values []string
+		for _, v := range append(config.Include, config.Exclude...) {
+			// undefined collections are already reported
+			if !strings.HasPrefix(v, appstudioredhatcomv1alpha1.CollectionPrefix) {
+				values = append(values, v)
+			}
+		}
+		status.UnmatchedRules = dedupe(cat.Unmatched(values))
+	}
+
+	status.RuleDataErrors = validateRuleData(source, ruleData, sensitive, fetched)
+
+	return status, cat
+}
+
+func sourceName(i int, source appstudioredhatcomv1alpha1.Source) string {
+	if source.Name != "" {
+		return source.Name
+	}
+
+	return fmt.Sprintf("#%d", i+1)
+}
+
+func dedupe(values []string) []string {
+	seen := map[string]bool{}
+	var unique []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+
+	return unique
+}
+
+func collectionsCondition(generation int64, undefined []string) metav1.Condition {
+	if len(undefined) == 0 {
+		return metav1.Condition{
+			Type:               appstudioredhatcomv1alpha1.ConditionCollectionsResolved,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             "Resolved",
+			Message:            "All referenced collections are defined",
+		}
+	}
+
+	return metav1.Condition{
+		Type:               appstudioredhatcomv1alpha1.ConditionCollectionsResolved,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "UndefinedCollections",
+		Message:            fmt.Sprintf("Collections not defined: %s", strings.Join(dedupe(undefined), ", ")),
+	}
+}
+
+func sourcesReadyCondition(generation int64, notReady []string) metav1.Condition {
+	if len(notReady) == 0 {
+		return metav1.Condition{
+			Type:               appstudioredhatcomv1alpha1.ConditionSourcesReady,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             "Ready",
+			Message:            "All sources are ready",
+		}
+	}
+
+	return metav1.Condition{
+		Type:               appstudioredhatcomv1alpha1.ConditionSourcesReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "SourcesNotReady",
+		Message:            fmt.Sprintf("Sources not ready: %s", strings.Join(notReady, ", ")),
+	}
+}
+
+func rulesMatchedCondition(generation int64, unmatched []string) metav1.Condition {
+	if len(unmatched) == 0 {
+		return metav1.Condition{
+			Type:               appstudioredhatcomv1alpha1.ConditionRulesMatched,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             "Matched",
+			Message:            "All includes and excludes match known rules",
+		}
+	}
+
+	return metav1.Condition{
+		Type:               appstudioredhatcomv1alpha1.ConditionRulesMatched,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "UnmatchedRules",
+		Message:            fmt.Sprintf("Includes and excludes matching no known rule: %s", strings.Join(dedupe(unmatched), ", ")),
+	}
+}
+
+func ruleDataCondition(generation int64, invalid []string) metav1.Condition {
+	if len(invalid) == 0 {
+		return metav1.Condition{
+			Type:               appstudioredhatcomv1alpha1.ConditionRuleDataValid,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             "Valid",
+			Message:            "Rule data conforms to the rule data schemas",
+		}
+	}
+
+	return metav1.Condition{
+		Type:               appstudioredhatcomv1alpha1.ConditionRuleDataValid,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "InvalidRuleData",
+		Message:            fmt.Sprintf("Rule data violates the rule data schema in sources: %s", strings.Join(invalid, ", ")),
+	}
+}
+
+// maxConsistencyFindings limits the number of findings listed in the message
+// of the RulesConsistent condition
+const maxConsistencyFindings = 10
+
+func rulesConsistentCondition(generation int64, findings []lint.Finding) metav1.Condition {
+	if len(findings) == 0 {
+		return metav1.Condition{
+			Type:               appstudioredhatcomv1alpha1.ConditionRulesConsistent,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			Reason:             "Consistent",
+			Message:            "No conflicting or redundant includes and excludes",
+		}
+	}
+
+	listed := make([]string, 0, maxConsistencyFindings+1)
+	for i, f := range findings {
+		if i == maxConsistencyFindings {
+			listed = append(listed, fmt.Sprintf("and %d more", len(findings)-i))
+			break
+		}
+		listed = append(listed, f.String())
+	}
+
+	return metav1.Condition{
+		Type:               appstudioredhatcomv1alpha1.ConditionRulesConsistent,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "InconsistentRules",
+		Message:            fmt.Sprintf("Conflicting or redundant includes and excludes: %s", strings.Join(listed, "; ")),
+	}
+}
+
+// policiesInNamespace enqueues all policies in the namespace of the given
+// object, used to reconcile the policies when the resources they might refer
+// to change
+func (r *EnterpriseContractPolicyReconciler) policiesInNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	policies := appstudioredhatcomv1alpha1.EnterpriseContractPolicyList{}
+	if err := r.List(ctx, &policies, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list policies", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(policies.Items))
+	for _, p := range policies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+	}
+
+	return requests
+}
+
+// allPolicies enqueues all policies in the cluster, used to reconcile the
+// policies when cluster scoped resources affecting them change
+func (r *EnterpriseContractPolicyReconciler) allPolicies(ctx context.Context, _ client.Object) []reconcile.Request {
+	policies := appstudioredhatcomv1alpha1.EnterpriseContractPolicyList{}
+	if err := r.List(ctx, &policies); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list policies")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(policies.Items))
+	for _, p := range policies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+	}
+
+	return requests
+}
+
+// policiesReferencingRuleData enqueues the policies with sources referring to
+// the given ConfigMap or Secret for rule data. Secrets are given as metadata
+// only, hence isSecret.
+func (r *EnterpriseContractPolicyReconciler) policiesReferencingRuleData(ctx context.Context, obj client.Object, isSecret bool) []reconcile.Request {
+	policies := appstudioredhatcomv1alpha1.EnterpriseContractPolicyList{}
+	if err := r.List(ctx, &policies, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list policies", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, p := range policies.Items {
+		if referencesRuleData(p.Spec, obj.GetName(), isSecret) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+		}
+	}
+
+	return requests
+}
+
+func referencesRuleData(spec appstudioredhatcomv1alpha1.EnterpriseContractPolicySpec, name string, secret bool) bool {
+	for _, s := range spec.Sources {
+		for _, ref := range s.RuleDataFrom {
+			if secret && ref.SecretRef != nil && ref.SecretRef.Name == name {
+				return true
+			}
+			if !secret && ref.ConfigMapRef != nil && ref.ConfigMapRef.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// dataConfigMapIndex indexes policies by the namespace/name of the ConfigMaps
+// their sources refer to for data
+const dataConfigMapIndex = "spec.sources.data.configMap"
+
+// dataConfigMaps returns the namespace/name of the ConfigMaps the sources of
+// the policy refer to for data, for indexing with dataConfigMapIndex. Only the
+// ConfigMaps in the namespace of the policy are indexed, the policy cannot
+// refer to the others
+func dataConfigMaps(obj client.Object) []string {
+	policy, ok := obj.(*appstudioredhatcomv1alpha1.EnterpriseContractPolicy)
+	if !ok {
+		return nil
+	}
+
+	var refs []string
+	for _, s := range policy.Spec.Sources {
+		for _, url := range s.Data {
+			if name, err := appstudioredhatcomv1alpha1.ParseKubernetesURLIn(url, policy.Namespace); err == nil {
+				refs = append(refs, policy.Namespace+"/"+name)
+			}
+		}
+	}
+
+	return dedupe(refs)
+}
+
+// policiesReferencingConfigMap enqueues the policies with sources referring to
+// the given ConfigMap for rule data or for data
+func (r *EnterpriseContractPolicyReconciler) policiesReferencingConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := r.policiesReferencingRuleData(ctx, obj, false)
+
+	policies := appstudioredhatcomv1alpha1.EnterpriseContractPolicyList{}
+	if err := r.List(ctx, &policies, client.MatchingFields{dataConfigMapIndex: obj.GetNamespace() + "/" + obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list policies referring to ConfigMap", "namespace", obj.GetNamespace(), "name", obj.GetName())
+		return requests
+	}
+
+	for _, p := range policies.Items {
+		request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)}
+		if !slices.Contains(requests, request) {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests
+}
+
+// publicKeySecretIndex indexes policies by the namespace/name of the Secrets
+// holding the public keys their sources verify bundle signatures with
+const publicKeySecretIndex = "spec.sources.bundleSignature.publicKey"
+
+// publicKeySecrets returns the namespace/name of the Secrets holding the
+// public keys of the sources of the policy, for indexing with
+// publicKeySecretIndex. Only the Secrets in the namespace of the policy are
+// indexed, the policy cannot refer to the others
+func publicKeySecrets(obj client.Object) []string {
+	policy, ok := obj.(*appstudioredhatcomv1alpha1.EnterpriseContractPolicy)
+	if !ok {
+		return nil
+	}
+
+	var refs []string
+	for _, s := range policy.Spec.Sources {
+		if s.BundleSignature == nil {
+			continue
+		}
+		if name, err := appstudioredhatcomv1alpha1.ParseKubernetesURLIn(s.BundleSignature.PublicKey, policy.Namespace); err == nil {
+			refs = append(refs, policy.Namespace+"/"+name)
+		}
+	}
+
+	return dedupe(refs)
+}
+
+// policiesReferencingSecret enqueues the policies with sources referring to
+// the given Secret for rule data or for the public key of signed bundles
+func (r *EnterpriseContractPolicyReconciler) policiesReferencingSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := r.policiesReferencingRuleData(ctx, obj, true)
+
+	policies := appstudioredhatcomv1alpha1.EnterpriseContractPolicyList{}
+	if err := r.List(ctx, &policies, client.MatchingFields{publicKeySecretIndex: obj.GetNamespace() + "/" + obj.GetName()}); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list policies referring to Secret", "namespace", obj.GetNamespace(), "name", obj.GetName())
+		return requests
+	}
+
+	for _, p := range policies.Items {
+		request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)}
+		if !slices.Contains(requests, request) {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *EnterpriseContractPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Fetcher == nil {
+		r.Fetcher = fetch.NewFetcher()
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &appstudioredhatcomv1alpha1.EnterpriseContractPolicy{}, dataConfigMapIndex, dataConfigMaps); err != nil {
+		return fmt.Errorf("unable to index policies by data ConfigMaps: %w", err)
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &appstudioredhatcomv1alpha1.EnterpriseContractPolicy{}, publicKeySecretIndex, publicKeySecrets); err != nil {
+		return fmt.Errorf("unable to index policies by public key Secrets: %w", err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&appstudioredhatcomv1alpha1.EnterpriseContractPolicy{}).
+		Owns(&corev1.ConfigMap{}).
+		Watches(&appstudioredhatcomv1alpha1.RuleCollection{}, handler.EnqueueRequestsFromMapFunc(r.policiesInNamespace)).
+		Watches(&appstudioredhatcomv1alpha1.SourceRewrite{}, handler.EnqueueRequestsFromMapFunc(r.allPolicies)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.policiesReferencingConfigMap)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.policiesReferencingSecret), builder.OnlyMetadata).
 		Complete(r)
 }
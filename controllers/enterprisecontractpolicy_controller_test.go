@@ -0,0 +1,1009 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/lint"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/mirror"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify/verifytest"
+)
+
+// testFetcher fetches policy rules by copying the local directories the URLs
+// are mapped to, failing for any other URL
+type testFetcher map[string]string
+
+func (f testFetcher) Fetch(_ context.Context, url, dir string) (string, error) {
+	src, ok := f[url]
+	if !ok {
+		return "", fmt.Errorf("unable to fetch %q", url)
+	}
+
+	if err := os.CopyFS(dir, os.DirFS(src)); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func newReconciler(c client.Client) *EnterpriseContractPolicyReconciler {
+	return &EnterpriseContractPolicyReconciler{Client: c, Scheme: c.Scheme(), Fetcher: testFetcher{
+		"oci::registry.io/acme/policy:latest":    "testdata/policy",
+		"oci::registry.io/acme/schema:latest":    "testdata/ruledata/policy",
+		"oci::registry.io/acme/rule-data:latest": "testdata/ruledata/data",
+	}}
+}
+
+func reconcilePolicy(t *testing.T, c client.Client, policy *ecc.EnterpriseContractPolicy) ecc.EnterpriseContractPolicy {
+	t.Helper()
+
+	return reconcilePolicyWith(t, newReconciler(c), policy)
+}
+
+func reconcilePolicyWith(t *testing.T, r *EnterpriseContractPolicyReconciler, policy *ecc.EnterpriseContractPolicy) ecc.EnterpriseContractPolicy {
+	t.Helper()
+
+	c := r.Client
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policy)}); err != nil {
+		t.Fatalf("unexpected error reconciling: %v", err)
+	}
+
+	got := ecc.EnterpriseContractPolicy{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(policy), &got); err != nil {
+		t.Fatalf("unexpected error getting policy: %v", err)
+	}
+
+	return got
+}
+
+func TestReconcileExpandsCollections(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(
+			ecctesting.NewSource("first").
+				WithPolicy(ecctesting.ReleasePolicyURL).
+				WithInclude("@acme", "x").
+				WithExclude("@slsa3"),
+			ecctesting.NewSource("second").
+				WithPolicy(ecctesting.ReleasePolicyURL)).
+		Policy("acme", "policy")
+
+	c := ecctesting.NewFakeClient(
+		policy,
+		ecctesting.NewRuleCollection("acme", "acme", "a", "b"),
+		ecctesting.NewRuleCollection("other", "slsa3", "c"),
+	)
+
+	got := reconcilePolicy(t, c, policy)
+
+	for i := range got.Status.Sources {
+		got.Status.Sources[i].Conditions = nil
+	}
+	expected := []ecc.SourceStatus{
+		{
+			Name: "first",
+			Config: &ecc.SourceConfig{
+				Include: []string{"a", "b", "x"},
+				Exclude: []string{"@slsa3"},
+			},
+			UndefinedCollections: []string{"slsa3"},
+		},
+		{Name: "second"},
+	}
+	if !reflect.DeepEqual(expected, got.Status.Sources) {
+		t.Errorf("expected sources %v, got %v", expected, got.Status.Sources)
+	}
+
+	condition := meta.FindStatusCondition(got.Status.Conditions, ecc.ConditionCollectionsResolved)
+	if condition == nil {
+		t.Fatal("expected the CollectionsResolved condition to be set")
+	}
+	if condition.Status != metav1.ConditionFalse || condition.Reason != "UndefinedCollections" {
+		t.Errorf("unexpected condition: %v", condition)
+	}
+	if got.Status.ObservedGeneration != got.Generation {
+		t.Errorf("expected observed generation %d, got %d", got.Generation, got.Status.ObservedGeneration)
+	}
+
+	// once the collection is defined the policy is resolved
+	if err := c.Create(context.Background(), ecctesting.NewRuleCollection("acme", "slsa3", "c")); err != nil {
+		t.Fatalf("unexpected error creating collection: %v", err)
+	}
+
+	got = reconcilePolicy(t, c, policy)
+
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, ecc.ConditionCollectionsResolved) {
+		t.Errorf("expected the CollectionsResolved condition to be true, got %v", got.Status.Conditions)
+	}
+	if expected := []string{"c"}; !reflect.DeepEqual(expected, got.Status.Sources[0].Config.Exclude) {
+		t.Errorf("expected excludes %v, got %v", expected, got.Status.Sources[0].Config.Exclude)
+	}
+}
+
+// deprecatedFieldPolicies returns the number of policies using the deprecated
+// field in the namespace, as exported by the metric
+func deprecatedFieldPolicies(t *testing.T, namespace, field string) float64 {
+	t.Helper()
+
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range families {
+		if f.GetName() != "ecp_deprecated_field_policies" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["namespace"] == namespace && labels["field"] == field {
+				return m.GetGauge().GetValue()
+			}
+		}
+	}
+
+	return -1
+}
+
+func TestReconcileRecordsDeprecations(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithConfiguration(ecc.EnterpriseContractPolicyConfiguration{Include: []string{"a"}}).
+		Policy("deprecations", "policy")
+	c := ecctesting.NewFakeClient(policy)
+
+	reconcilePolicy(t, c, policy)
+	if got := deprecatedFieldPolicies(t, "deprecations", "configuration"); got != 1 {
+		t.Errorf("expected one policy using the configuration, got %v", got)
+	}
+
+	if err := c.Delete(context.Background(), policy); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newReconciler(c).Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policy)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := deprecatedFieldPolicies(t, "deprecations", "configuration"); got != 0 {
+		t.Errorf("expected no policies using the configuration once deleted, got %v", got)
+	}
+}
+
+func TestReconcileIsIdempotent(t *testing.T) {
+	policy := ecctesting.DefaultPolicySpec().Policy("acme", "policy")
+	c := ecctesting.NewFakeClient(policy)
+
+	first := reconcilePolicy(t, c, policy)
+	second := reconcilePolicy(t, c, policy)
+
+	if first.ResourceVersion != second.ResourceVersion {
+		t.Errorf("expected no update, resource version changed from %s to %s", first.ResourceVersion, second.ResourceVersion)
+	}
+}
+
+func TestReconcileRetriesSources(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("unavailable").WithPolicy("oci::registry.io/acme/unavailable:latest")).
+		Policy("acme", "policy")
+	c := ecctesting.NewFakeClient(policy)
+	r := newReconciler(c)
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != minRetryInterval {
+		t.Errorf("expected the source to be retried after %v, got %v", minRetryInterval, result.RequeueAfter)
+	}
+
+	// retried also when the status does not change
+	result, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policy)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != minRetryInterval {
+		t.Errorf("expected the source to be retried after %v, got %v", minRetryInterval, result.RequeueAfter)
+	}
+
+	ready := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("ready").WithPolicy("oci::registry.io/acme/policy:latest")).
+		Policy("acme", "ready")
+	if err := c.Create(context.Background(), ready); err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(ready)}); result.RequeueAfter != 0 {
+		t.Errorf("expected ready sources not to be retried, got %v", result.RequeueAfter)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	source := func(status metav1.ConditionStatus, since time.Duration) ecc.SourceStatus {
+		return ecc.SourceStatus{Conditions: []metav1.Condition{{
+			Type:               ecc.ConditionReady,
+			Status:             status,
+			LastTransitionTime: metav1.NewTime(now.Add(-since)),
+		}}}
+	}
+
+	cases := []struct {
+		name     string
+		sources  []ecc.SourceStatus
+		expected time.Duration
+	}{
+		{name: "ready", sources: []ecc.SourceStatus{source(metav1.ConditionTrue, time.Hour)}},
+		{name: "just failed", sources: []ecc.SourceStatus{source(metav1.ConditionFalse, 0)}, expected: minRetryInterval},
+		{name: "backing off", sources: []ecc.SourceStatus{source(metav1.ConditionFalse, time.Minute), source(metav1.ConditionFalse, 2*time.Minute)}, expected: 2 * time.Minute},
+		{name: "failing for long", sources: []ecc.SourceStatus{source(metav1.ConditionTrue, 0), source(metav1.ConditionFalse, 24*time.Hour)}, expected: maxRetryInterval},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := retryAfter(now, c.sources); got != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestReconcileCatalogue(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(
+			ecctesting.NewSource("fetched").
+				WithPolicy("oci::registry.io/acme/policy:latest").
+				WithInclude("@slsa3", "@acme", "test", "friday_policy").
+				WithExclude("attestation_type.known_attestation_type", "room_temperature", "@slsa1"),
+			ecctesting.NewSource("missing").
+				WithPolicy("oci::registry.io/acme/missing:latest").
+				WithInclude("@redhat", "anything")).
+		Policy("acme", "policy")
+
+	c := ecctesting.NewFakeClient(policy, ecctesting.NewRuleCollection("acme", "acme", "test.no_failed_tests", "acme.unknown"))
+
+	got := reconcilePolicy(t, c, policy)
+
+	fetched := got.Status.Sources[0]
+	if expected := []string{"acme.unknown", "friday_policy", "room_temperature"}; !reflect.DeepEqual(expected, fetched.UnmatchedRules) {
+		t.Errorf("expected unmatched rules %v, got %v", expected, fetched.UnmatchedRules)
+	}
+	if expected := []string{"slsa1"}; !reflect.DeepEqual(expected, fetched.UndefinedCollections) {
+		t.Errorf("expected undefined collections %v, got %v", expected, fetched.UndefinedCollections)
+	}
+	if !meta.IsStatusConditionTrue(fetched.Conditions, ecc.ConditionReady) {
+		t.Errorf("expected the fetched source to be ready, got %v", fetched.Conditions)
+	}
+
+	missing := got.Status.Sources[1]
+	if missing.UnmatchedRules != nil {
+		t.Errorf("expected no unmatched rules for a source that could not be fetched, got %v", missing.UnmatchedRules)
+	}
+	if expected := []string{"redhat"}; !reflect.DeepEqual(expected, missing.UndefinedCollections) {
+		t.Errorf("expected undefined collections %v, got %v", expected, missing.UndefinedCollections)
+	}
+	if condition := meta.FindStatusCondition(missing.Conditions, ecc.ConditionReady); condition == nil || condition.Reason != "FetchFailed" {
+		t.Errorf("expected the missing source not to be ready, got %v", condition)
+	}
+
+	for conditionType, reason := range map[string]string{
+		ecc.ConditionCollectionsResolved: "UndefinedCollections",
+		ecc.ConditionSourcesReady:        "SourcesNotReady",
+		ecc.ConditionRulesMatched:        "UnmatchedRules",
+		ecc.ConditionCataloguePublished:  "Published",
+	} {
+		if condition := meta.FindStatusCondition(got.Status.Conditions, conditionType); condition == nil || condition.Reason != reason {
+			t.Errorf("expected %s condition with reason %s, got %v", conditionType, reason, condition)
+		}
+	}
+
+	if got.Status.RuleCatalogue != "policy-rule-catalogue" {
+		t.Errorf("unexpected rule catalogue name %q", got.Status.RuleCatalogue)
+	}
+
+	cm := corev1.ConfigMap{}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "acme", Name: got.Status.RuleCatalogue}, &cm); err != nil {
+		t.Fatalf("unexpected error getting the rule catalogue: %v", err)
+	}
+	if !metav1.IsControlledBy(&cm, &got) {
+		t.Error("expected the rule catalogue to be owned by the policy")
+	}
+
+	var catalogues []struct {
+		Name     string   `json:"name"`
+		Packages []string `json:"packages"`
+	}
+	if err := json.Unmarshal([]byte(cm.Data[CatalogueKey]), &catalogues); err != nil {
+		t.Fatalf("unexpected error parsing the rule catalogue: %v", err)
+	}
+	if len(catalogues) != 1 || catalogues[0].Name != "fetched" || !reflect.DeepEqual([]string{"attestation_type", "test"}, catalogues[0].Packages) {
+		t.Errorf("unexpected rule catalogue: %v", catalogues)
+	}
+}
+
+func TestReconcileRuleData(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(
+			ecctesting.NewSource("valid").
+				WithPolicy("oci::registry.io/acme/schema:latest").
+				WithRuleData(map[string]any{"max_age_days": 30}),
+			ecctesting.NewSource("invalid").
+				WithPolicy("oci::registry.io/acme/schema:latest").
+				WithData("oci::registry.io/acme/rule-data:latest").
+				WithRuleData(map[string]any{"allowed_registry_prefixes": "registry.io/"}),
+			ecctesting.NewSource("no schema").
+				WithPolicy("oci::registry.io/acme/policy:latest").
+				WithData("oci::registry.io/acme/rule-data:latest")).
+		Policy("acme", "policy")
+
+	c := ecctesting.NewFakeClient(policy)
+
+	got := reconcilePolicy(t, c, policy)
+
+	if errs := got.Status.Sources[0].RuleDataErrors; errs != nil {
+		t.Errorf("expected no rule data errors for valid rule data, got %v", errs)
+	}
+	if errs := got.Status.Sources[2].RuleDataErrors; errs != nil {
+		t.Errorf("expected no rule data errors without a schema, got %v", errs)
+	}
+
+	expected := []ecc.RuleDataError{
+		{Document: "ruleData", Pointer: "/allowed_registry_prefixes", Message: "expected array, but got string"},
+		{Data: "oci::registry.io/acme/rule-data:latest", Document: "rule_data.yml", Pointer: "/rule_data/max_age_days", Message: "must be >= 1 but found 0"},
+	}
+	if !reflect.DeepEqual(expected, got.Status.Sources[1].RuleDataErrors) {
+		t.Errorf("expected rule data errors %v, got %v", expected, got.Status.Sources[1].RuleDataErrors)
+	}
+
+	condition := meta.FindStatusCondition(got.Status.Conditions, ecc.ConditionRuleDataValid)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Message != "Rule data violates the rule data schema in sources: invalid (2 violations)" {
+		t.Errorf("unexpected RuleDataValid condition: %v", condition)
+	}
+}
+
+func TestReconcileRulesConsistent(t *testing.T) {
+	consistent := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("a").WithPolicy("oci::registry.io/acme/policy:latest").WithInclude("test").WithExclude("attestation_type")).
+		Policy("acme", "consistent")
+	inconsistent := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("a").WithPolicy("oci::registry.io/acme/policy:latest").WithInclude("test").WithExclude("test")).
+		Policy("acme", "inconsistent")
+
+	c := ecctesting.NewFakeClient(consistent, inconsistent)
+
+	got := reconcilePolicy(t, c, consistent)
+	if condition := meta.FindStatusCondition(got.Status.Conditions, ecc.ConditionRulesConsistent); condition == nil || condition.Status != metav1.ConditionTrue {
+		t.Errorf("expected the rules to be consistent, got %v", condition)
+	}
+
+	got = reconcilePolicy(t, c, inconsistent)
+	condition := meta.FindStatusCondition(got.Status.Conditions, ecc.ConditionRulesConsistent)
+	expected := `Conflicting or redundant includes and excludes: spec.sources[0].config.exclude[0]: "test" is also included by spec.sources[0].config.include[0], the include takes precedence`
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "InconsistentRules" || condition.Message != expected {
+		t.Errorf("unexpected RulesConsistent condition: %v", condition)
+	}
+}
+
+func TestRulesConsistentConditionLimit(t *testing.T) {
+	findings := make([]lint.Finding, maxConsistencyFindings+3)
+	for i := range findings {
+		findings[i] = lint.Finding{Kind: lint.KindDuplicate, Field: fmt.Sprintf("f%d", i), Message: "m"}
+	}
+
+	condition := rulesConsistentCondition(1, findings)
+	if !strings.HasSuffix(condition.Message, "f9: m; and 3 more") || strings.Contains(condition.Message, "f10") {
+		t.Errorf("expected the findings to be limited, got %q", condition.Message)
+	}
+}
+
+func TestReconcileRuleDataFrom(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(
+			ecctesting.NewSource("referenced").
+				WithPolicy("oci::registry.io/acme/schema:latest").
+				WithRuleDataFromConfigMap("rule-data", "rule_data.yaml", false).
+				WithRuleDataFromSecret("credentials", "", false).
+				WithRuleDataFromConfigMap("missing", "", true).
+				WithRuleData(map[string]any{"max_age_days": 7}),
+			ecctesting.NewSource("unresolved").
+				WithPolicy("oci::registry.io/acme/schema:latest").
+				WithRuleDataFromConfigMap("missing", "", false)).
+		Policy("acme", "policy")
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "rule-data"},
+		Data:       map[string]string{"rule_data.yaml": "max_age_days: 30\nallowed_registry_prefixes: [registry.io/]"},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "credentials"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+	c := ecctesting.NewFakeClient(policy, cm, secret)
+
+	got := reconcilePolicy(t, c, policy)
+
+	referenced := got.Status.Sources[0]
+	if !meta.IsStatusConditionTrue(referenced.Conditions, ecc.ConditionReady) {
+		t.Errorf("expected the referenced source to be ready, got %v", referenced.Conditions)
+	}
+	if expected := `{"allowed_registry_prefixes":["registry.io/"],"max_age_days":7}`; referenced.RuleData == nil || string(referenced.RuleData.Raw) != expected {
+		t.Errorf("expected rule data %s without the values from the Secret, got %v", expected, referenced.RuleData)
+	}
+	if referenced.RuleDataErrors != nil {
+		t.Errorf("expected no rule data errors, got %v", referenced.RuleDataErrors)
+	}
+	hash := referenced.RuleDataHash
+	if hash == "" {
+		t.Error("expected the rule data hash to be set")
+	}
+
+	unresolved := got.Status.Sources[1]
+	if condition := meta.FindStatusCondition(unresolved.Conditions, ecc.ConditionReady); condition == nil || condition.Reason != "RuleDataUnresolved" {
+		t.Errorf("expected the unresolved source not to be ready, got %v", condition)
+	}
+	if unresolved.RuleData != nil || unresolved.RuleDataHash != "" {
+		t.Errorf("expected no rule data for the unresolved source, got %v, %q", unresolved.RuleData, unresolved.RuleDataHash)
+	}
+
+	// changing the Secret changes the hash but not the disclosed rule data
+	secret.Data["token"] = []byte("changed")
+	if err := c.Update(context.Background(), secret); err != nil {
+		t.Fatalf("unexpected error updating the Secret: %v", err)
+	}
+
+	got = reconcilePolicy(t, c, policy)
+
+	if got.Status.Sources[0].RuleDataHash == hash {
+		t.Error("expected the rule data hash to change with the content of the Secret")
+	}
+	if !reflect.DeepEqual(referenced.RuleData, got.Status.Sources[0].RuleData) {
+		t.Errorf("expected the rule data to stay %s, got %s", referenced.RuleData.Raw, got.Status.Sources[0].RuleData.Raw)
+	}
+
+	// rule data from the ConfigMap is validated
+	cm.Data["rule_data.yaml"] = "allowed_registry_prefixes: registry.io/"
+	if err := c.Update(context.Background(), cm); err != nil {
+		t.Fatalf("unexpected error updating the ConfigMap: %v", err)
+	}
+
+	got = reconcilePolicy(t, c, policy)
+
+	expected := []ecc.RuleDataError{
+		{Document: "ruleData", Pointer: "/allowed_registry_prefixes", Message: "expected array, but got string"},
+	}
+	if !reflect.DeepEqual(expected, got.Status.Sources[0].RuleDataErrors) {
+		t.Errorf("expected rule data errors %v, got %v", expected, got.Status.Sources[0].RuleDataErrors)
+	}
+}
+
+func TestReconcileCatalogueConflict(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().Policy("acme", "policy")
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "policy-rule-catalogue"},
+		Data:       map[string]string{"config": "kept"},
+	}
+	c := ecctesting.NewFakeClient(policy, cm)
+
+	got := reconcilePolicy(t, c, policy)
+
+	if condition := meta.FindStatusCondition(got.Status.Conditions, ecc.ConditionCataloguePublished); condition == nil || condition.Reason != "ConfigMapConflict" {
+		t.Errorf("expected the catalogue not to be published, got %v", condition)
+	}
+	if got.Status.RuleCatalogue != "" {
+		t.Errorf("expected no rule catalogue, got %q", got.Status.RuleCatalogue)
+	}
+
+	kept := corev1.ConfigMap{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(cm), &kept); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cm.Data, kept.Data) || len(kept.OwnerReferences) != 0 {
+		t.Errorf("expected the ConfigMap to be left as is, got %v", kept)
+	}
+}
+
+func TestCatalogueName(t *testing.T) {
+	long := strings.Repeat("a", 240) + "." + strings.Repeat("b", 12)
+	other := strings.Repeat("a", 240) + "." + strings.Repeat("c", 12)
+
+	name := catalogueName(ecctesting.NewPolicySpec().Policy("acme", long))
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) != 0 {
+		t.Errorf("expected a valid name, got %q: %v", name, errs)
+	}
+	if name == catalogueName(ecctesting.NewPolicySpec().Policy("acme", other)) {
+		t.Errorf("expected the names of different policies to differ, got %q", name)
+	}
+	if name := catalogueName(ecctesting.NewPolicySpec().Policy("acme", "policy")); name != "policy-rule-catalogue" {
+		t.Errorf("expected the short name to be suffixed, got %q", name)
+	}
+}
+
+func TestReconcileRuleDataKeepsSecrets(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("secret").
+			WithPolicy("oci::registry.io/acme/schema:latest").
+			WithRuleDataFromSecret("credentials", "", false)).
+		Policy("acme", "policy")
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "credentials"},
+		Data:       map[string][]byte{"max_age_days": []byte("-31337")},
+	}
+	c := ecctesting.NewFakeClient(policy, secret)
+
+	got := reconcilePolicy(t, c, policy)
+
+	expected := []ecc.RuleDataError{
+		{Document: "ruleData", Pointer: "/max_age_days", Message: "value from a Secret does not match the rule data schema"},
+	}
+	if !reflect.DeepEqual(expected, got.Status.Sources[0].RuleDataErrors) {
+		t.Errorf("expected rule data errors %v, got %v", expected, got.Status.Sources[0].RuleDataErrors)
+	}
+
+	status, err := json.Marshal(got.Status)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(status), "31337") {
+		t.Errorf("expected the value from the Secret not to appear in the status, got %s", status)
+	}
+}
+
+func TestReconcileRuleDataFromAPIReader(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("secret").
+			WithPolicy("oci::registry.io/acme/schema:latest").
+			WithRuleDataFromSecret("credentials", "", false)).
+		Policy("acme", "policy")
+
+	// the Secret is not cached, only the API reader has it
+	c := ecctesting.NewFakeClient(policy)
+	r := newReconciler(c)
+	r.APIReader = ecctesting.NewFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "credentials"},
+		Data:       map[string][]byte{"max_age_days": []byte("7")},
+	})
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(policy)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := ecc.EnterpriseContractPolicy{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(policy), &got); err != nil {
+		t.Fatal(err)
+	}
+	if source := got.Status.Sources[0]; !meta.IsStatusConditionTrue(source.Conditions, ecc.ConditionReady) || source.RuleDataHash == "" {
+		t.Errorf("expected the rule data from the Secret to be resolved, got %v", source)
+	}
+}
+
+func TestReconcileConfigMapData(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(
+			ecctesting.NewSource("in-cluster").
+				WithPolicy("oci::registry.io/acme/schema:latest").
+				WithData("k8s://acme/policy-data"),
+			ecctesting.NewSource("missing").
+				WithPolicy("oci::registry.io/acme/schema:latest").
+				WithData("k8s://acme/missing"),
+			ecctesting.NewSource("other namespace").
+				WithPolicy("oci::registry.io/acme/schema:latest").
+				WithData("k8s://shared/policy-data")).
+		Policy("acme", "policy")
+
+	data := map[string]string{"rule_data.yml": "rule_data:\n  max_age_days: 0\n"}
+	c := ecctesting.NewFakeClient(policy,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "policy-data"}, Data: data},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "shared", Name: "policy-data"}, Data: data})
+
+	got := reconcilePolicy(t, c, policy)
+
+	inCluster := got.Status.Sources[0]
+	if !meta.IsStatusConditionTrue(inCluster.Conditions, ecc.ConditionReady) {
+		t.Errorf("expected the source with ConfigMap data to be ready, got %v", inCluster.Conditions)
+	}
+	expected := []ecc.RuleDataError{
+		{Data: "k8s://acme/policy-data", Document: "rule_data.yml", Pointer: "/rule_data/max_age_days", Message: "must be >= 1 but found 0"},
+	}
+	if !reflect.DeepEqual(expected, inCluster.RuleDataErrors) {
+		t.Errorf("expected rule data errors %v, got %v", expected, inCluster.RuleDataErrors)
+	}
+
+	if condition := meta.FindStatusCondition(got.Status.Sources[1].Conditions, ecc.ConditionReady); condition == nil || condition.Reason != "FetchFailed" {
+		t.Errorf("expected the source with a missing ConfigMap not to be ready, got %v", condition)
+	}
+
+	// ConfigMaps of other namespaces are not read
+	if condition := meta.FindStatusCondition(got.Status.Sources[2].Conditions, ecc.ConditionReady); condition == nil || condition.Reason != "FetchFailed" || !strings.Contains(condition.Message, "only resources in the same namespace") {
+		t.Errorf("expected the source with a ConfigMap in another namespace not to be ready, got %v", condition)
+	}
+	if len(got.Status.Sources[2].RuleDataErrors) != 0 {
+		t.Errorf("expected the data of the other namespace not to be read, got %v", got.Status.Sources[2].RuleDataErrors)
+	}
+}
+
+func TestReconcileMirror(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(
+			ecctesting.NewSource("mirrored").
+				WithPolicy("oci::registry.io/acme/policy:latest", "oci::registry.io/acme/schema:latest").
+				WithData("oci::registry.io/acme/rule-data:latest", "k8s://acme/policy-data")).
+		Policy("acme", "policy")
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "policy-data"},
+		Data:       map[string]string{"data.yml": "{}"},
+	}
+	c := ecctesting.NewFakeClient(policy, cm)
+
+	cache, err := mirror.NewCache(t.TempDir(), "https://mirror.svc")
+	if err != nil {
+		t.Fatalf("unexpected error creating the mirror: %v", err)
+	}
+	r := newReconciler(c)
+	r.Mirror = cache
+
+	got := reconcilePolicyWith(t, r, policy)
+
+	m := got.Status.Sources[0].Mirror
+	if m == nil || len(m.Policy) != 2 || len(m.Data) != 2 {
+		t.Fatalf("expected the policy rules and data to be mirrored, got %v", m)
+	}
+	for _, url := range append(m.Policy, m.Data[0]) {
+		if !strings.HasPrefix(url, "https://mirror.svc"+mirror.PathPrefix) {
+			t.Errorf("unexpected mirror URL %q", url)
+		}
+	}
+	if m.Policy[0] == m.Policy[1] {
+		t.Errorf("expected different content at different URLs, got %v", m.Policy)
+	}
+	if m.Data[1] != "k8s://acme/policy-data" {
+		t.Errorf("expected ConfigMap data not to be mirrored, got %q", m.Data[1])
+	}
+
+	// content the fetch did not create is not mirrored
+	r.Fetcher = outsideFetcher{}
+	if got := reconcilePolicyWith(t, r, policy); got.Status.Sources[0].Mirror != nil || meta.IsStatusConditionTrue(got.Status.Sources[0].Conditions, ecc.ConditionReady) {
+		t.Errorf("expected content outside of the fetched directory not to be mirrored, got %v", got.Status.Sources[0])
+	}
+
+	// without a mirror no URLs are published
+	if got := reconcilePolicy(t, c, policy); got.Status.Sources[0].Mirror != nil {
+		t.Errorf("expected no mirror URLs, got %v", got.Status.Sources[0].Mirror)
+	}
+}
+
+// outsideFetcher returns a directory other than the one fetched to
+type outsideFetcher struct{}
+
+func (outsideFetcher) Fetch(_ context.Context, _, _ string) (string, error) {
+	return "testdata/policy", nil
+}
+
+func TestReconcileSourceRewrite(t *testing.T) {
+	testdata, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := ecctesting.NewPolicySpec().
+		WithRekorUrl("https://rekor.sigstore.dev").
+		WithSources(
+			ecctesting.NewSource("rewritten").
+				WithPolicy("git::https://git.acme.io/ruledata/policy").
+				WithData("git::https://git.acme.io/ruledata/data", "k8s://acme/policy-data"),
+			ecctesting.NewSource("local").
+				WithPolicy("file::"+filepath.Join(testdata, "policy"))).
+		Policy("acme", "policy")
+
+	c := ecctesting.NewFakeClient(
+		policy,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "policy-data"}},
+		ecctesting.NewSourceRewrite("git", ecc.PrefixRewrite{Source: "git::https://git.acme.io/", Mirror: "file::" + testdata + "/"}),
+		ecctesting.NewSourceRewrite("rekor", ecc.PrefixRewrite{Source: "https://rekor.sigstore.dev", Mirror: "https://rekor.acme.internal"}),
+	)
+	r := EnterpriseContractPolicyReconciler{Client: c, Scheme: c.Scheme(), Fetcher: fetch.NewLocalFetcher()}
+
+	got := reconcilePolicyWith(t, &r, policy)
+
+	if got.Status.RekorUrl != "https://rekor.acme.internal" {
+		t.Errorf("expected the rewritten Rekor URL, got %q", got.Status.RekorUrl)
+	}
+
+	rewritten := got.Status.Sources[0]
+	if !meta.IsStatusConditionTrue(rewritten.Conditions, ecc.ConditionReady) {
+		t.Errorf("expected the source to be fetched from the rewritten URLs, got %v", rewritten.Conditions)
+	}
+	if expected := []string{"file::" + testdata + "/ruledata/policy"}; !reflect.DeepEqual(expected, rewritten.Policy) {
+		t.Errorf("expected policy URLs %v, got %v", expected, rewritten.Policy)
+	}
+	if expected := []string{"file::" + testdata + "/ruledata/data", "k8s://acme/policy-data"}; !reflect.DeepEqual(expected, rewritten.Data) {
+		t.Errorf("expected data URLs %v, got %v", expected, rewritten.Data)
+	}
+	// errors refer to the data as given in the policy
+	if len(rewritten.RuleDataErrors) != 1 || rewritten.RuleDataErrors[0].Data != "git::https://git.acme.io/ruledata/data" {
+		t.Errorf("unexpected rule data errors %v", rewritten.RuleDataErrors)
+	}
+
+	local := got.Status.Sources[1]
+	if local.Policy != nil || local.Data != nil {
+		t.Errorf("expected no effective URLs for a source without rewritten URLs, got %v and %v", local.Policy, local.Data)
+	}
+	if !meta.IsStatusConditionTrue(local.Conditions, ecc.ConditionReady) {
+		t.Errorf("expected the local source to be ready, got %v", local.Conditions)
+	}
+}
+
+func TestReconcileBundleSignature(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	key, pub := verifytest.GenerateKey(t)
+
+	files := map[string]string{}
+	for _, name := range []string{"test.rego", "attestation_type.rego"} {
+		content, err := os.ReadFile(filepath.Join("testdata", "policy", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = string(content)
+	}
+
+	signed := verifytest.PushBundle(t, host+"/acme/policy:latest", files)
+	verifytest.Sign(t, signed, key)
+	verifytest.PushBundle(t, host+"/acme/unsigned:latest", files)
+
+	policy := ecctesting.NewPolicySpec().
+		WithSources(
+			ecctesting.NewSource("pem").
+				WithPolicy("oci::"+host+"/acme/policy:latest").
+				WithBundleSignature(ecc.BundleSignature{PublicKey: string(pub)}),
+			ecctesting.NewSource("secret").
+				WithPolicy("oci::"+host+"/acme/policy:latest").
+				WithData("k8s://acme/policy-data").
+				WithBundleSignature(ecc.BundleSignature{PublicKey: "k8s://acme/policy-key"}),
+			ecctesting.NewSource("unsigned").
+				WithPolicy("oci::"+host+"/acme/unsigned:latest").
+				WithBundleSignature(ecc.BundleSignature{PublicKey: string(pub)}),
+			ecctesting.NewSource("missing key").
+				WithPolicy("oci::"+host+"/acme/policy:latest").
+				WithBundleSignature(ecc.BundleSignature{PublicKey: "k8s://acme/missing"}),
+			ecctesting.NewSource("other namespace").
+				WithPolicy("oci::"+host+"/acme/policy:latest").
+				WithBundleSignature(ecc.BundleSignature{PublicKey: "k8s://keys/policy-key"})).
+		Policy("acme", "policy")
+
+	c := ecctesting.NewFakeClient(
+		policy,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "policy-data"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "policy-key"}, Data: map[string][]byte{PublicKeySecretKey: pub}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "keys", Name: "policy-key"}, Data: map[string][]byte{PublicKeySecretKey: pub}},
+	)
+	r := EnterpriseContractPolicyReconciler{Client: c, Scheme: c.Scheme(), Fetcher: fetch.NewLocalFetcher()}
+
+	got := reconcilePolicyWith(t, &r, policy)
+
+	for _, s := range got.Status.Sources[:2] {
+		if !meta.IsStatusConditionTrue(s.Conditions, ecc.ConditionReady) {
+			t.Errorf("expected source %q to be ready, got %v", s.Name, s.Conditions)
+		}
+		if expected := []string{"oci::" + signed.String()}; !reflect.DeepEqual(expected, s.VerifiedBundles) {
+			t.Errorf("expected verified bundles %v of source %q, got %v", expected, s.Name, s.VerifiedBundles)
+		}
+	}
+
+	for _, s := range got.Status.Sources[2:] {
+		ready := meta.FindStatusCondition(s.Conditions, ecc.ConditionReady)
+		if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != "VerificationFailed" {
+			t.Errorf("expected source %q to fail verification, got %v", s.Name, ready)
+		}
+		if s.VerifiedBundles != nil {
+			t.Errorf("expected no verified bundles of source %q, got %v", s.Name, s.VerifiedBundles)
+		}
+	}
+}
+
+func TestReconcileCommitSignature(t *testing.T) {
+	sign, key := verifytest.GPGKey(t, "Release Engineering", "release@example.com")
+	otherSign, _ := verifytest.GPGKey(t, "Other", "other@example.com")
+
+	files := map[string]string{}
+	for _, name := range []string{"test.rego", "attestation_type.rego"} {
+		content, err := os.ReadFile(filepath.Join("testdata", "policy", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[filepath.Join("policy", name)] = string(content)
+	}
+
+	signed, commit := verifytest.GitRepository(t, files, sign)
+	other, _ := verifytest.GitRepository(t, files, otherSign)
+	unsigned, _ := verifytest.GitRepository(t, files, nil)
+
+	policy := ecctesting.NewPolicySpec().
+		WithSources(
+			ecctesting.NewSource("signed").
+				WithPolicy("git::"+signed+"//policy").
+				WithData("k8s://acme/policy-data").
+				WithCommitSignature(key),
+			ecctesting.NewSource("other").
+				WithPolicy("git::"+other+"//policy").
+				WithCommitSignature(key),
+			ecctesting.NewSource("unsigned").
+				WithPolicy("git::"+unsigned+"//policy").
+				WithCommitSignature(key)).
+		Policy("acme", "policy")
+
+	c := ecctesting.NewFakeClient(
+		policy,
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "policy-data"}},
+	)
+	r := EnterpriseContractPolicyReconciler{Client: c, Scheme: c.Scheme(), Fetcher: fetch.NewLocalFetcher()}
+
+	got := reconcilePolicyWith(t, &r, policy)
+
+	s := got.Status.Sources[0]
+	if !meta.IsStatusConditionTrue(s.Conditions, ecc.ConditionReady) {
+		t.Errorf("expected the signed source to be ready, got %v", s.Conditions)
+	}
+	if len(s.VerifiedCommits) != 1 {
+		t.Fatalf("expected one verified commit, got %v", s.VerifiedCommits)
+	}
+	if v := s.VerifiedCommits[0]; v.Url != "git::"+signed+"//policy" || v.Commit != commit || v.Signer != "Release Engineering <release@example.com>" || v.Fingerprint == "" {
+		t.Errorf("unexpected verified commit %v", v)
+	}
+
+	for _, s := range got.Status.Sources[1:] {
+		ready := meta.FindStatusCondition(s.Conditions, ecc.ConditionReady)
+		if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != "CommitVerificationFailed" {
+			t.Errorf("expected source %q to fail verification, got %v", s.Name, ready)
+		}
+		if s.VerifiedCommits != nil {
+			t.Errorf("expected no verified commits of source %q, got %v", s.Name, s.VerifiedCommits)
+		}
+	}
+}
+
+func TestPoliciesReferencingSecret(t *testing.T) {
+	c := ecctesting.NewFakeClientBuilder().
+		WithIndex(&ecc.EnterpriseContractPolicy{}, publicKeySecretIndex, publicKeySecrets).
+		WithObjects(
+			ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").WithRuleDataFromSecret("key", "", false)).Policy("acme", "a"),
+			ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("b").WithBundleSignature(ecc.BundleSignature{PublicKey: "k8s://acme/key"})).Policy("other", "b"),
+			ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("c").WithBundleSignature(ecc.BundleSignature{PublicKey: "k8s://other/key"})).Policy("acme", "c"),
+			ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("d").WithBundleSignature(ecc.BundleSignature{Identity: &ecc.Identity{Subject: "acme"}})).Policy("acme", "d"),
+			ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("e").WithBundleSignature(ecc.BundleSignature{PublicKey: "k8s://acme/key"})).Policy("acme", "e"),
+		).
+		Build()
+	r := EnterpriseContractPolicyReconciler{Client: c}
+
+	requests := r.policiesReferencingSecret(context.Background(), &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "key"}})
+
+	// b refers to a Secret of another namespace, which it cannot read
+	expected := []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "a"}},
+		{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "e"}},
+	}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Errorf("expected %v, got %v", expected, requests)
+	}
+}
+
+func TestAllPolicies(t *testing.T) {
+	c := ecctesting.NewFakeClient(
+		ecctesting.MinimalPolicySpec().Policy("acme", "a"),
+		ecctesting.MinimalPolicySpec().Policy("other", "b"),
+	)
+	r := EnterpriseContractPolicyReconciler{Client: c}
+
+	requests := r.allPolicies(context.Background(), ecctesting.NewSourceRewrite("rewrite"))
+
+	expected := []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "a"}},
+		{NamespacedName: client.ObjectKey{Namespace: "other", Name: "b"}},
+	}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Errorf("expected %v, got %v", expected, requests)
+	}
+}
+
+func TestReconcileMissingPolicy(t *testing.T) {
+	r := EnterpriseContractPolicyReconciler{Client: ecctesting.NewFakeClient()}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "missing"}}); err != nil {
+		t.Errorf("unexpected error reconciling a missing policy: %v", err)
+	}
+}
+
+func TestPoliciesInNamespace(t *testing.T) {
+	c := ecctesting.NewFakeClient(
+		ecctesting.MinimalPolicySpec().Policy("acme", "a"),
+		ecctesting.MinimalPolicySpec().Policy("acme", "b"),
+		ecctesting.MinimalPolicySpec().Policy("other", "c"),
+	)
+	r := EnterpriseContractPolicyReconciler{Client: c}
+
+	requests := r.policiesInNamespace(context.Background(), ecctesting.NewRuleCollection("acme", "acme", "x"))
+
+	expected := []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "a"}},
+		{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "b"}},
+	}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Errorf("expected %v, got %v", expected, requests)
+	}
+}
+
+func TestPoliciesReferencingRuleData(t *testing.T) {
+	c := ecctesting.NewFakeClient(
+		ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").WithRuleDataFromConfigMap("rule-data", "", false)).Policy("acme", "a"),
+		ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("b").WithRuleDataFromSecret("rule-data", "", false)).Policy("acme", "b"),
+		ecctesting.MinimalPolicySpec().Policy("acme", "c"),
+		ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("d").WithRuleDataFromConfigMap("rule-data", "", false)).Policy("other", "d"),
+	)
+	r := EnterpriseContractPolicyReconciler{Client: c}
+
+	requests := r.policiesReferencingRuleData(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "rule-data"}}, false)
+	if expected := []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "a"}}}; !reflect.DeepEqual(expected, requests) {
+		t.Errorf("expected %v for the ConfigMap, got %v", expected, requests)
+	}
+
+	requests = r.policiesReferencingRuleData(context.Background(), &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "rule-data"}}, true)
+	if expected := []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "b"}}}; !reflect.DeepEqual(expected, requests) {
+		t.Errorf("expected %v for the Secret, got %v", expected, requests)
+	}
+}
+
+func TestPoliciesReferencingConfigMap(t *testing.T) {
+	c := ecctesting.NewFakeClientBuilder().
+		WithIndex(&ecc.EnterpriseContractPolicy{}, dataConfigMapIndex, dataConfigMaps).
+		WithObjects(
+			ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").WithRuleDataFromConfigMap("data", "", false)).Policy("acme", "a"),
+			ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("b").WithData("k8s://acme/data")).Policy("other", "b"),
+			ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("c").WithData("k8s://acme/data").WithRuleDataFromConfigMap("data", "", false)).Policy("acme", "c"),
+			ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("d").WithData("k8s://other/data")).Policy("acme", "d"),
+		).
+		Build()
+	r := EnterpriseContractPolicyReconciler{Client: c}
+
+	requests := r.policiesReferencingConfigMap(context.Background(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "data"}})
+
+	// b refers to a ConfigMap of another namespace, which it cannot read
+	expected := []reconcile.Request{
+		{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "a"}},
+		{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "c"}},
+	}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Errorf("expected %v, got %v", expected, requests)
+	}
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/sigstore/sigstore/pkg/signature"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appstudioredhatcomv1alpha1 "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/artifact"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/effective"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+)
+
+const (
+	// SigningKeySecretKey is the key of the private key in the Secrets
+	// snapshots are signed with
+	SigningKeySecretKey = "cosign.key"
+	// SigningKeyPasswordSecretKey is the key of the password of encrypted
+	// private keys in the Secrets snapshots are signed with
+	SigningKeyPasswordSecretKey = "cosign.password"
+)
+
+// PolicySnapshotReconciler captures the policies PolicySnapshot objects refer
+// to, once
+type PolicySnapshotReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Pin pins the URLs of the sources captured, fetch.Pin when nil
+	Pin func(ctx context.Context, url string) (string, error)
+	// APIReader, when set, reads the Secrets referred to by the policies and
+	// snapshots directly from the API server, e.g. mgr.GetAPIReader(), as only
+	// their metadata is cached
+	APIReader client.Reader
+}
+
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=policysnapshots,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=policysnapshots/status,verbs=get;update;patch
+
+// Reconcile captures the policy of a snapshot not yet captured, recording the
+// flattened policy in the snapshot status. Captured snapshots are left as is.
+func (r *PolicySnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	snapshot := appstudioredhatcomv1alpha1.PolicySnapshot{}
+	if err := r.Get(ctx, req.NamespacedName, &snapshot); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if snapshot.Status.CapturedAt != nil {
+		return ctrl.Result{}, nil
+	}
+
+	policy := appstudioredhatcomv1alpha1.EnterpriseContractPolicy{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: snapshot.Namespace, Name: snapshot.Spec.Policy}, &policy)
+	if err != nil {
+		err = fmt.Errorf("unable to get policy %q: %w", snapshot.Spec.Policy, err)
+	}
+
+	var captured *appstudioredhatcomv1alpha1.EnterpriseContractPolicySpec
+	var hashes []string
+	if err == nil {
+		pin := r.Pin
+		if pin == nil {
+			pin = fetch.Pin
+		}
+		captured, hashes, err = Flatten(ctx, withSecrets(r.Client, r.APIReader), &policy, pin)
+	}
+
+	reason := "CaptureFailed"
+	var artifact string
+	if err == nil && snapshot.Spec.Export != nil {
+		if artifact, err = r.export(ctx, &snapshot, captured); err != nil {
+			reason = "ExportFailed"
+		}
+	}
+
+	if err != nil {
+		log.FromContext(ctx).Info("unable to capture the policy", "policy", snapshot.Spec.Policy, "error", err.Error())
+		meta.SetStatusCondition(&snapshot.Status.Conditions, metav1.Condition{
+			Type:               appstudioredhatcomv1alpha1.ConditionCaptured,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: snapshot.Generation,
+			Reason:             reason,
+			Message:            err.Error(),
+		})
+		if updateErr := r.Status().Update(ctx, &snapshot); updateErr != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to update the snapshot status: %w", updateErr)
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	now := metav1.Now()
+	snapshot.Status.CapturedAt = &now
+	snapshot.Status.PolicyGeneration = policy.Generation
+	snapshot.Status.Policy = captured
+	snapshot.Status.RuleDataHash = hashes
+	snapshot.Status.Artifact = artifact
+	meta.SetStatusCondition(&snapshot.Status.Conditions, metav1.Condition{
+		Type:               appstudioredhatcomv1alpha1.ConditionCaptured,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: snapshot.Generation,
+		Reason:             "Captured",
+		Message:            fmt.Sprintf("Captured generation %d of policy %q", policy.Generation, policy.Name),
+	})
+
+	if err := r.Status().Update(ctx, &snapshot); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to update the snapshot status: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("captured policy", "policy", policy.Name, "generation", policy.Generation)
+
+	return ctrl.Result{}, nil
+}
+
+// Flatten returns the flattened spec of the policy, as captured by snapshots,
+// and the hashes of the effective rule data of its sources. The URLs of the
+// sources are pinned with the given function, e.g. fetch.Pin.
+func Flatten(ctx context.Context, c client.Reader, policy *appstudioredhatcomv1alpha1.EnterpriseContractPolicy, pin func(ctx context.Context, url string) (string, error)) (*appstudioredhatcomv1alpha1.EnterpriseContractPolicySpec, []string, error) {
+	collections := appstudioredhatcomv1alpha1.RuleCollectionList{}
+	if err := c.List(ctx, &collections, client.InNamespace(policy.Namespace)); err != nil {
+		return nil, nil, fmt.Errorf("unable to list rule collections: %w", err)
+	}
+	defined := effective.CollectionsFrom(collections.Items)
+
+	sourceRewrites := appstudioredhatcomv1alpha1.SourceRewriteList{}
+	if err := c.List(ctx, &sourceRewrites); err != nil {
+		return nil, nil, fmt.Errorf("unable to list source rewrites: %w", err)
+	}
+	// the signatures are verified against the same Rekor instance as when
+	// the policy is reconciled
+	rekorUrl, _ := effective.RewritesFrom(sourceRewrites.Items).Rewrite(policy.Spec.RekorUrl)
+
+	spec := policy.Spec.DeepCopy()
+
+	// the policy runners read public keys held in Secrets of other namespaces
+	// with their own credentials, those are kept as references: the manager
+	// only reads the Secrets of the namespace of the policy
+	var err error
+	if spec.PublicKey != "" && !otherNamespace(spec.PublicKey, policy.Namespace) {
+		var key []byte
+		if key, err = publicKey(ctx, c, spec.PublicKey, policy.Namespace); err != nil {
+			return nil, nil, err
+		}
+		spec.PublicKey = string(key)
+	}
+
+	hashes := make([]string, len(spec.Sources))
+	hashed := false
+	for i := range spec.Sources {
+		source := &spec.Sources[i]
+		name := sourceName(i, *source)
+
+		// collections not defined as RuleCollections are left to be resolved
+		// from the policy rules
+		if config, _ := defined.SourceConfig(source.Config); config != nil {
+			source.Config = config
+		}
+
+		resolved, err := resolveRuleData(ctx, c, policy.Namespace, *source)
+		if err != nil {
+			return nil, nil, fmt.Errorf("source %s: %w", name, err)
+		}
+		if resolved != nil {
+			// rule data from Secrets is not disclosed, the references to the
+			// Secrets are kept for the policy runners to resolve
+			source.RuleData = resolved.disclosed
+			source.RuleDataFrom = secretRefs(source.RuleDataFrom)
+			hashes[i] = resolved.hash
+			hashed = true
+		}
+
+		if source.BundleSignature != nil {
+			// signed bundles are pinned to the verified digests
+			if *source, _, err = verifyBundles(ctx, c, *source, policy.Namespace, rekorUrl); err != nil {
+				return nil, nil, fmt.Errorf("source %s: %w", name, err)
+			}
+
+			if key := source.BundleSignature.PublicKey; key != "" {
+				pub, err := publicKey(ctx, c, key, policy.Namespace)
+				if err != nil {
+					return nil, nil, fmt.Errorf("source %s: %w", name, err)
+				}
+				source.BundleSignature.PublicKey = string(pub)
+			}
+		}
+
+		if source.Policy, err = pinAll(ctx, pin, source.Policy); err != nil {
+			return nil, nil, fmt.Errorf("source %s: %w", name, err)
+		}
+		if source.Data, err = pinAll(ctx, pin, source.Data); err != nil {
+			return nil, nil, fmt.Errorf("source %s: %w", name, err)
+		}
+	}
+
+	if !hashed {
+		hashes = nil
+	}
+
+	return spec, hashes, nil
+}
+
+// secretRefs returns the references to Secrets among the rule data
+// references. Merged in order before the disclosed rule data these resolve to
+// the same effective rule data as all the references: the keys left out of
+// the disclosed rule data take their values from the last Secret holding
+// them.
+func secretRefs(refs []appstudioredhatcomv1alpha1.RuleDataReference) []appstudioredhatcomv1alpha1.RuleDataReference {
+	var secrets []appstudioredhatcomv1alpha1.RuleDataReference
+	for _, ref := range refs {
+		if ref.SecretRef != nil {
+			secrets = append(secrets, ref)
+		}
+	}
+
+	return secrets
+}
+
+// export pushes the captured policy as an OCI artifact, signed with the
+// signing key if set, returning the digest reference to the artifact
+func (r *PolicySnapshotReconciler) export(ctx context.Context, snapshot *appstudioredhatcomv1alpha1.PolicySnapshot, captured *appstudioredhatcomv1alpha1.EnterpriseContractPolicySpec) (string, error) {
+	ref := snapshot.Spec.Export.Repository
+	if _, err := name.NewRepository(ref); err == nil {
+		ref += ":" + snapshot.Name
+	}
+
+	// the repository is the tenant's, only the credentials of the snapshot
+	// namespace are used
+	opts := artifact.Options{Keychain: artifact.Anonymous}
+	if secret := snapshot.Spec.Export.PushSecret; secret != "" {
+		var err error
+		if opts.Keychain, err = pushCredentials(ctx, withSecrets(r.Client, r.APIReader), secret, snapshot.Namespace); err != nil {
+			return "", err
+		}
+	}
+	if key := snapshot.Spec.Export.SigningKey; key != "" {
+		var err error
+		if opts.Signer, err = signingKey(ctx, withSecrets(r.Client, r.APIReader), key, snapshot.Namespace); err != nil {
+			return "", err
+		}
+	}
+
+	digest, err := artifact.Push(ctx, ref, captured, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return digest.String(), nil
+}
+
+// signingKey loads the private key from the Secret the k8s:// URL refers to,
+// which must be in the namespace of the snapshot: signing with the keys of
+// other namespaces would let anyone creating snapshots forge their artifacts
+func signingKey(ctx context.Context, c client.Reader, key, namespace string) (signature.Signer, error) {
+	name, err := appstudioredhatcomv1alpha1.ParseKubernetesURLIn(key, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key: %w", err)
+	}
+
+	secret := corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret); err != nil {
+		return nil, fmt.Errorf("unable to get the signing key Secret %s/%s: %w", namespace, name, err)
+	}
+
+	private, ok := secret.Data[SigningKeySecretKey]
+	if !ok {
+		return nil, fmt.Errorf("the signing key Secret %s/%s has no %q key", namespace, name, SigningKeySecretKey)
+	}
+
+	signer, err := artifact.LoadSigner(private, secret.Data[SigningKeyPasswordSecretKey])
+	if err != nil {
+		return nil, fmt.Errorf("the signing key Secret %s/%s: %w", namespace, name, err)
+	}
+
+	return signer, nil
+}
+
+// pushCredentials loads the registry credentials from the
+// kubernetes.io/dockerconfigjson Secret the k8s:// URL refers to, which must
+// be in the namespace of the snapshot
+func pushCredentials(ctx context.Context, c client.Reader, url, namespace string) (authn.Keychain, error) {
+	name, err := appstudioredhatcomv1alpha1.ParseKubernetesURLIn(url, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("invalid push Secret: %w", err)
+	}
+
+	secret := corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret); err != nil {
+		return nil, fmt.Errorf("unable to get the push Secret %s/%s: %w", namespace, name, err)
+	}
+
+	config, ok := secret.Data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return nil, fmt.Errorf("the push Secret %s/%s has no %q key", namespace, name, corev1.DockerConfigJsonKey)
+	}
+
+	keychain, err := artifact.NewKeychain(config)
+	if err != nil {
+		return nil, fmt.Errorf("the push Secret %s/%s: %w", namespace, name, err)
+	}
+
+	return keychain, nil
+}
+
+// otherNamespace reports whether the k8s://namespace/name URL refers to a
+// resource outside of the given namespace
+func otherNamespace(url, namespace string) bool {
+	ns, _, err := appstudioredhatcomv1alpha1.ParseKubernetesURL(url)
+
+	return err == nil && ns != namespace
+}
+
+// pinAll pins the go-getter style URLs to the content they refer to, URLs of
+// ConfigMaps are kept as is
+func pinAll(ctx context.Context, pin func(ctx context.Context, url string) (string, error), urls []string) ([]string, error) {
+	pinned := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if appstudioredhatcomv1alpha1.IsKubernetesURL(url) {
+			pinned = append(pinned, url)
+			continue
+		}
+
+		p, err := pin(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		pinned = append(pinned, p)
+	}
+
+	return pinned, nil
+}
+
+// snapshotsOfPolicy enqueues the snapshots of the given policy not yet
+// captured
+func (r *PolicySnapshotReconciler) snapshotsOfPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	snapshots := appstudioredhatcomv1alpha1.PolicySnapshotList{}
+	if err := r.List(ctx, &snapshots, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list snapshots", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, s := range snapshots.Items {
+		if s.Spec.Policy == obj.GetName() && s.Status.CapturedAt == nil {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&s)})
+		}
+	}
+
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PolicySnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appstudioredhatcomv1alpha1.PolicySnapshot{}).
+		Watches(&appstudioredhatcomv1alpha1.EnterpriseContractPolicy{}, handler.EnqueueRequestsFromMapFunc(r.snapshotsOfPolicy)).
+		Complete(r)
+}
@@ -0,0 +1,376 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/artifact"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify/verifytest"
+)
+
+func reconcileSnapshot(t *testing.T, r *PolicySnapshotReconciler, snapshot *ecc.PolicySnapshot) (ecc.PolicySnapshot, error) {
+	t.Helper()
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(snapshot)})
+
+	got := ecc.PolicySnapshot{}
+	if getErr := r.Get(context.Background(), client.ObjectKeyFromObject(snapshot), &got); getErr != nil {
+		t.Fatalf("unexpected error getting snapshot: %v", getErr)
+	}
+
+	return got, err
+}
+
+func TestReconcileSnapshot(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	key, pub := verifytest.GenerateKey(t)
+
+	repo, commit := verifytest.GitRepository(t, map[string]string{"policy/release.rego": "package release"}, nil)
+	data := verifytest.PushBundle(t, host+"/acme/data:latest", map[string]string{"data.yml": "rule_data: {}"})
+	signed := verifytest.PushBundle(t, host+"/acme/policy:latest", map[string]string{"release.rego": "package release"})
+	verifytest.Sign(t, signed, key)
+
+	policy := ecctesting.NewPolicySpec().
+		WithPublicKey("k8s://acme/signing-key").
+		WithSources(
+			ecctesting.NewSource("git").
+				WithPolicy("git::"+repo+"//policy").
+				WithData("oci::"+host+"/acme/data:latest", "k8s://acme/policy-data").
+				WithInclude("@acme", "x").
+				WithRuleDataFromConfigMap("rule-data", "rule_data.yml", false).
+				WithRuleDataFromSecret("credentials", "", false),
+			ecctesting.NewSource("signed").
+				WithPolicy("oci::"+host+"/acme/policy:latest").
+				WithBundleSignature(ecc.BundleSignature{PublicKey: "k8s://acme/policy-key"})).
+		Policy("acme", "policy")
+	policy.Generation = 3
+
+	snapshot := ecctesting.NewPolicySnapshot("acme", "release-1", "policy")
+
+	c := ecctesting.NewFakeClient(
+		snapshot,
+		ecctesting.NewRuleCollection("acme", "acme", "a", "b"),
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "rule-data"}, Data: map[string]string{"rule_data.yml": "max_age_days: 30"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "credentials"}, Data: map[string][]byte{"token": []byte("s3cr3t")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "signing-key"}, Data: map[string][]byte{PublicKeySecretKey: []byte("signing key")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "policy-key"}, Data: map[string][]byte{PublicKeySecretKey: pub}},
+	)
+	r := PolicySnapshotReconciler{Client: c, Scheme: c.Scheme(), Pin: fetch.PinLocal}
+
+	// the policy does not exist yet
+	got, err := reconcileSnapshot(t, &r, snapshot)
+	if err == nil {
+		t.Error("expected an error capturing a missing policy")
+	}
+	if condition := meta.FindStatusCondition(got.Status.Conditions, ecc.ConditionCaptured); condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "CaptureFailed" {
+		t.Errorf("expected the snapshot not to be captured, got %v", condition)
+	}
+	if got.Status.CapturedAt != nil || got.Status.Policy != nil {
+		t.Errorf("expected nothing to be captured, got %v", got.Status)
+	}
+
+	if err := c.Create(context.Background(), policy); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = reconcileSnapshot(t, &r, snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, ecc.ConditionCaptured) {
+		t.Errorf("expected the snapshot to be captured, got %v", got.Status.Conditions)
+	}
+	if got.Status.CapturedAt == nil {
+		t.Error("expected the capture time to be set")
+	}
+	if got.Status.PolicyGeneration != policy.Generation {
+		t.Errorf("expected generation %d to be captured, got %d", policy.Generation, got.Status.PolicyGeneration)
+	}
+	if len(got.Status.RuleDataHash) != 2 || got.Status.RuleDataHash[0] == "" || got.Status.RuleDataHash[1] != "" {
+		t.Errorf("expected the rule data hash of the first source, got %v", got.Status.RuleDataHash)
+	}
+
+	expected := ecc.EnterpriseContractPolicySpec{
+		PublicKey: "signing key",
+		Sources: []ecc.Source{
+			{
+				Name:     "git",
+				Policy:   []string{"git::" + repo + "//policy?ref=" + commit},
+				Data:     []string{"oci::" + data.String(), "k8s://acme/policy-data"},
+				Config:   &ecc.SourceConfig{Include: []string{"a", "b", "x"}},
+				RuleData: got.Status.Policy.Sources[0].RuleData,
+				// rule data from Secrets is resolved by the policy runners
+				RuleDataFrom: []ecc.RuleDataReference{{SecretRef: &ecc.RuleDataKeySelector{Name: "credentials"}}},
+			},
+			{
+				Name:            "signed",
+				Policy:          []string{"oci::" + signed.String()},
+				BundleSignature: &ecc.BundleSignature{PublicKey: string(pub)},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, *got.Status.Policy) {
+		t.Errorf("expected captured policy %v, got %v", expected, *got.Status.Policy)
+	}
+	if ruleData := got.Status.Policy.Sources[0].RuleData; ruleData == nil || string(ruleData.Raw) != `{"max_age_days":30}` {
+		t.Errorf("expected the rule data from the ConfigMap without values from Secrets, got %v", ruleData)
+	}
+
+	// captured snapshots no longer follow the policy
+	policy.Spec.Sources = policy.Spec.Sources[:1]
+	if err := c.Update(context.Background(), policy); err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := reconcileSnapshot(t, &r, snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got.Status, again.Status) {
+		t.Errorf("expected the captured snapshot to stay %v, got %v", got.Status, again.Status)
+	}
+}
+
+func TestFlattenRuleDataFromSecrets(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("rule data").
+			WithRuleDataFromSecret("defaults", "", false).
+			WithRuleDataFromConfigMap("rule-data", "", false).
+			WithRuleDataFromSecret("credentials", "", false).
+			WithRuleData(map[string]any{"max_age_days": 7})).
+		Policy("acme", "policy")
+
+	c := ecctesting.NewFakeClient(
+		policy,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "defaults"}, Data: map[string][]byte{"token": []byte("default"), "registry": []byte("registry.io")}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "rule-data"}, Data: map[string]string{"registry": "quay.io", "max_age_days": "30"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "credentials"}, Data: map[string][]byte{"token": []byte("s3cr3t")}},
+	)
+
+	spec, _, err := Flatten(context.Background(), c, policy, fetch.PinLocal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ruleData := spec.Sources[0].RuleData; ruleData == nil || string(ruleData.Raw) != `{"max_age_days":7,"registry":"quay.io"}` {
+		t.Errorf("expected the rule data without values from Secrets, got %v", ruleData)
+	}
+
+	// the captured source evaluates with the same rule data as the policy
+	expected, err := resolveRuleData(context.Background(), c, "acme", policy.Spec.Sources[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := resolveRuleData(context.Background(), c, "acme", spec.Sources[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(expected.effective.Raw) != string(got.effective.Raw) {
+		t.Errorf("expected the captured rule data to resolve to %s, got %s", expected.effective.Raw, got.effective.Raw)
+	}
+}
+
+func TestFlattenKeysOfOtherNamespaces(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithPublicKey("k8s://keys/signing-key").
+		WithSources(ecctesting.NewSource("signed").
+			WithPolicy("oci::registry.io/acme/policy:latest").
+			WithBundleSignature(ecc.BundleSignature{PublicKey: "k8s://keys/policy-key"})).
+		Policy("acme", "policy")
+
+	c := ecctesting.NewFakeClient(
+		policy,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "keys", Name: "signing-key"}, Data: map[string][]byte{PublicKeySecretKey: []byte("signing key")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "keys", Name: "policy-key"}, Data: map[string][]byte{PublicKeySecretKey: []byte("policy key")}},
+	)
+
+	// the key of the policy is left to the policy runners to read, the key of
+	// signed bundles is verified by the manager, which cannot read it
+	_, _, err := Flatten(context.Background(), c, policy, fetch.PinLocal)
+	if err == nil || !strings.Contains(err.Error(), `"k8s://keys/policy-key" is not in namespace "acme"`) {
+		t.Errorf("expected an error reading the key of another namespace, got %v", err)
+	}
+
+	policy.Spec.Sources = nil
+	spec, _, err := Flatten(context.Background(), c, policy, fetch.PinLocal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.PublicKey != "k8s://keys/signing-key" {
+		t.Errorf("expected the public key to be kept as a reference, got %q", spec.PublicKey)
+	}
+}
+
+func TestReconcileSnapshotUnverifiedBundle(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	_, pub := verifytest.GenerateKey(t)
+	verifytest.PushBundle(t, host+"/acme/policy:latest", map[string]string{"release.rego": "package release"})
+
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("unsigned").
+			WithPolicy("oci::"+host+"/acme/policy:latest").
+			WithBundleSignature(ecc.BundleSignature{PublicKey: string(pub)})).
+		Policy("acme", "policy")
+	snapshot := ecctesting.NewPolicySnapshot("acme", "release-1", "policy")
+
+	c := ecctesting.NewFakeClient(policy, snapshot)
+	r := PolicySnapshotReconciler{Client: c, Scheme: c.Scheme(), Pin: fetch.PinLocal}
+
+	got, err := reconcileSnapshot(t, &r, snapshot)
+	if err == nil {
+		t.Error("expected an error capturing a policy with an unsigned bundle")
+	}
+	if got.Status.Policy != nil || meta.IsStatusConditionTrue(got.Status.Conditions, ecc.ConditionCaptured) {
+		t.Errorf("expected nothing to be captured, got %v", got.Status)
+	}
+}
+
+func TestReconcileSnapshotExport(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	key, pub := verifytest.GenerateKey(t)
+	private, err := cryptoutils.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("local").WithPolicy("/policy")).
+		Policy("acme", "policy")
+	snapshot := ecctesting.NewPolicySnapshot("acme", "release-1", "policy")
+	snapshot.Spec.Export = &ecc.PolicyExport{Repository: host + "/acme/policy", SigningKey: "k8s://acme/export-key"}
+
+	c := ecctesting.NewFakeClient(policy, snapshot)
+	r := PolicySnapshotReconciler{Client: c, Scheme: c.Scheme(), Pin: fetch.PinLocal}
+
+	// the signing key does not exist yet
+	got, err := reconcileSnapshot(t, &r, snapshot)
+	if err == nil {
+		t.Error("expected an error exporting without the signing key")
+	}
+	if condition := meta.FindStatusCondition(got.Status.Conditions, ecc.ConditionCaptured); condition == nil || condition.Reason != "ExportFailed" {
+		t.Errorf("expected the export to fail, got %v", condition)
+	}
+	if got.Status.CapturedAt != nil {
+		t.Errorf("expected nothing to be captured, got %v", got.Status)
+	}
+
+	if err := c.Create(context.Background(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "export-key"}, Data: map[string][]byte{SigningKeySecretKey: private}}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = reconcileSnapshot(t, &r, snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, ecc.ConditionCaptured) {
+		t.Errorf("expected the snapshot to be captured, got %v", got.Status.Conditions)
+	}
+
+	verified, err := verify.Bundle(context.Background(), "oci::"+host+"/acme/policy:release-1", verify.Options{PublicKey: pub})
+	if err != nil {
+		t.Fatalf("unable to verify the exported policy: %v", err)
+	}
+	if expected := "oci::" + got.Status.Artifact; verified != expected {
+		t.Errorf("expected the exported artifact %q, got %q", expected, verified)
+	}
+
+	exported, _, err := artifact.Pull(context.Background(), got.Status.Artifact, artifact.Options{})
+	if err != nil {
+		t.Fatalf("unable to pull the exported policy: %v", err)
+	}
+	if !reflect.DeepEqual(got.Status.Policy, exported) {
+		t.Errorf("expected the exported policy to be %v, got %v", got.Status.Policy, exported)
+	}
+}
+
+func TestReconcileSnapshotExportPushSecret(t *testing.T) {
+	host := verifytest.NewAuthRegistry(t, "acme", "s3cr3t")
+
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("local").WithPolicy("/policy")).
+		Policy("acme", "policy")
+	snapshot := ecctesting.NewPolicySnapshot("acme", "release-1", "policy")
+	snapshot.Spec.Export = &ecc.PolicyExport{Repository: host + "/acme/policy", PushSecret: "k8s://acme/push-credentials"}
+
+	c := ecctesting.NewFakeClient(policy, snapshot)
+	r := PolicySnapshotReconciler{Client: c, Scheme: c.Scheme(), Pin: fetch.PinLocal}
+
+	// the push Secret does not exist yet
+	got, err := reconcileSnapshot(t, &r, snapshot)
+	if err == nil {
+		t.Error("expected an error exporting without the push Secret")
+	}
+	if condition := meta.FindStatusCondition(got.Status.Conditions, ecc.ConditionCaptured); condition == nil || condition.Reason != "ExportFailed" {
+		t.Errorf("expected the export to fail, got %v", condition)
+	}
+
+	config := fmt.Sprintf(`{"auths": {%q: {"username": "acme", "password": "s3cr3t"}}}`, host)
+	if err := c.Create(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "acme", Name: "push-credentials"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(config)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = reconcileSnapshot(t, &r, snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, ecc.ConditionCaptured) || got.Status.Artifact == "" {
+		t.Errorf("expected the snapshot to be exported, got %v", got.Status)
+	}
+}
+
+func TestSnapshotsOfPolicy(t *testing.T) {
+	captured := ecctesting.NewPolicySnapshot("acme", "captured", "policy")
+	now := metav1.Now()
+	captured.Status.CapturedAt = &now
+
+	c := ecctesting.NewFakeClient(
+		ecctesting.NewPolicySnapshot("acme", "pending", "policy"),
+		captured,
+		ecctesting.NewPolicySnapshot("acme", "other", "other"),
+		ecctesting.NewPolicySnapshot("other", "pending", "policy"),
+	)
+	r := PolicySnapshotReconciler{Client: c}
+
+	requests := r.snapshotsOfPolicy(context.Background(), ecctesting.NewPolicySpec().Policy("acme", "policy"))
+
+	expected := []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: "acme", Name: "pending"}}}
+	if !reflect.DeepEqual(expected, requests) {
+		t.Errorf("expected %v, got %v", expected, requests)
+	}
+}
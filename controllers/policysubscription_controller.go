@@ -0,0 +1,425 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	sigsyaml "sigs.k8s.io/yaml"
+
+	appstudioredhatcomv1alpha1 "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+)
+
+const (
+	// AppliedSpecHashAnnotation holds the hash of the spec of a subscribed
+	// policy as last applied from the source, telling apart changes made in
+	// the cluster
+	AppliedSpecHashAnnotation = "appstudio.redhat.com/applied-spec-hash"
+	// defaultSyncInterval is the interval between syncs of subscriptions
+	// without one
+	defaultSyncInterval = 5 * time.Minute
+	// minSyncInterval is the shortest interval between syncs, shorter
+	// intervals would have the controller refetch the source continuously
+	minSyncInterval = time.Minute
+)
+
+// PolicySubscriptionReconciler keeps the policies of PolicySubscription
+// objects in sync with their sources
+type PolicySubscriptionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	// Fetcher fetches the sources of the subscriptions
+	Fetcher fetch.Fetcher
+	// Pin pins the sources of the subscriptions, fetch.Pin when nil
+	Pin func(ctx context.Context, url string) (string, error)
+}
+
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=policysubscriptions,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=policysubscriptions/status,verbs=get;update;patch
+
+// invalidManifestsError is returned for sources with manifests that are not
+// valid policies, these are not retried before the next sync
+type invalidManifestsError struct {
+	err error
+}
+
+func (e invalidManifestsError) Error() string {
+	return e.err.Error()
+}
+
+func (e invalidManifestsError) Unwrap() error {
+	return e.err
+}
+
+// Reconcile fetches the source of the subscription at the revision it
+// currently resolves to and creates, updates and, when pruning, deletes the
+// policies of the subscription to match the manifests in the source
+func (r *PolicySubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	subscription := appstudioredhatcomv1alpha1.PolicySubscription{}
+	if err := r.Get(ctx, req.NamespacedName, &subscription); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	interval := subscription.Spec.Interval.Duration
+	if interval <= 0 {
+		interval = defaultSyncInterval
+	}
+	interval = max(interval, minSyncInterval)
+
+	pin := r.Pin
+	if pin == nil {
+		pin = fetch.Pin
+	}
+
+	revision, err := pin(ctx, subscription.Spec.Source)
+	reason := "FetchFailed"
+
+	var policies []appstudioredhatcomv1alpha1.EnterpriseContractPolicy
+	if err == nil {
+		subscription.Status.LastAttemptedRevision = revision
+		policies, err = r.fetchPolicies(ctx, revision, subscription.Namespace)
+		if errors.As(err, &invalidManifestsError{}) {
+			reason = "InvalidManifests"
+		}
+	}
+
+	var applied, drifted []string
+	if err == nil {
+		if applied, drifted, err = r.apply(ctx, &subscription, policies); err != nil {
+			reason = "ApplyFailed"
+		}
+	}
+
+	if err != nil {
+		log.FromContext(ctx).Info("unable to sync the policies", "source", subscription.Spec.Source, "error", err.Error())
+		meta.SetStatusCondition(&subscription.Status.Conditions, metav1.Condition{
+			Type:               appstudioredhatcomv1alpha1.ConditionSynced,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: subscription.Generation,
+			Reason:             reason,
+			Message:            err.Error(),
+		})
+		if updateErr := r.Status().Update(ctx, &subscription); updateErr != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to update the subscription status: %w", updateErr)
+		}
+
+		if reason == "InvalidManifests" {
+			return ctrl.Result{RequeueAfter: interval}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	now := metav1.Now()
+	subscription.Status.LastAppliedRevision = revision
+	subscription.Status.LastSyncTime = &now
+	subscription.Status.Policies = applied
+	subscription.Status.Drifted = drifted
+	meta.SetStatusCondition(&subscription.Status.Conditions, metav1.Condition{
+		Type:               appstudioredhatcomv1alpha1.ConditionSynced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: subscription.Generation,
+		Reason:             "Synced",
+		Message:            fmt.Sprintf("Synced %d policies from %s", len(applied), revision),
+	})
+
+	if err := r.Status().Update(ctx, &subscription); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to update the subscription status: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("synced policies", "revision", revision, "policies", applied, "drifted", drifted)
+
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// fetchPolicies fetches the source and reads the policy manifests in it
+func (r *PolicySubscriptionReconciler) fetchPolicies(ctx context.Context, url, namespace string) ([]appstudioredhatcomv1alpha1.EnterpriseContractPolicy, error) {
+	fetcher := r.Fetcher
+	if fetcher == nil {
+		fetcher = fetch.NewFetcher()
+	}
+
+	tmp, err := os.MkdirTemp("", "ecpsub-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	dir, err := fetcher.Fetch(ctx, url, filepath.Join(tmp, "source"))
+	if err != nil {
+		return nil, err
+	}
+
+	return readPolicies(dir, namespace)
+}
+
+// readPolicies reads the EnterpriseContractPolicy manifests in the YAML and
+// JSON files in the directory, validating their specs against the schema.
+// Manifests of other kinds are ignored.
+func readPolicies(dir, namespace string) ([]appstudioredhatcomv1alpha1.EnterpriseContractPolicy, error) {
+	var policies []appstudioredhatcomv1alpha1.EnterpriseContractPolicy
+	seen := map[string]string{}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// symbolic links could point outside of the source, they are not
+		// followed, nor are special files read
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		rel, _ := filepath.Rel(dir, path)
+		found, err := readManifests(path, rel, namespace)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range found {
+			if other, ok := seen[p.Name]; ok {
+				return invalidManifestsError{fmt.Errorf("policy %q is defined in both %s and %s", p.Name, other, rel)}
+			}
+			seen[p.Name] = rel
+			policies = append(policies, p)
+		}
+
+		return nil
+	})
+
+	return policies, err
+}
+
+// readManifests reads the EnterpriseContractPolicy manifests in the file
+func readManifests(path, name, namespace string) ([]appstudioredhatcomv1alpha1.EnterpriseContractPolicy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s: %w", name, err)
+	}
+	defer f.Close()
+
+	gvk := appstudioredhatcomv1alpha1.GroupVersion.WithKind("EnterpriseContractPolicy")
+	reader := yaml.NewYAMLReader(bufio.NewReader(f))
+
+	var policies []appstudioredhatcomv1alpha1.EnterpriseContractPolicy
+	for i := 1; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, invalidManifestsError{fmt.Errorf("unable to read %s: %w", name, err)}
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		invalid := func(format string, args ...any) error {
+			return invalidManifestsError{fmt.Errorf("document %d of %s: %s", i, name, fmt.Sprintf(format, args...))}
+		}
+
+		var manifest map[string]any
+		if err := sigsyaml.Unmarshal(doc, &manifest); err != nil {
+			return nil, invalid("%v", err)
+		}
+		if manifest == nil || manifest["apiVersion"] != gvk.GroupVersion().String() || manifest["kind"] != gvk.Kind {
+			continue
+		}
+
+		spec, ok := manifest["spec"]
+		if !ok {
+			spec = map[string]any{}
+		}
+		// round trip through JSON for the schema to see JSON types
+		raw, err := json.Marshal(spec)
+		if err != nil {
+			return nil, invalid("%v", err)
+		}
+		var generic any
+		if err := json.Unmarshal(raw, &generic); err != nil {
+			return nil, invalid("%v", err)
+		}
+		if err := appstudioredhatcomv1alpha1.ValidateSpec(generic); err != nil {
+			return nil, invalid("the spec is not valid: %v", err)
+		}
+
+		policy := appstudioredhatcomv1alpha1.EnterpriseContractPolicy{}
+		if err := sigsyaml.UnmarshalStrict(doc, &policy); err != nil {
+			return nil, invalid("%v", err)
+		}
+		if policy.Name == "" {
+			return nil, invalid("the policy has no name")
+		}
+		if policy.Namespace != "" && policy.Namespace != namespace {
+			return nil, invalid("policy %q is in namespace %q instead of the namespace of the subscription", policy.Name, policy.Namespace)
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// specHash returns the SHA-256 hash of the JSON encoded policy spec
+func specHash(spec appstudioredhatcomv1alpha1.EnterpriseContractPolicySpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode the policy spec: %w", err)
+	}
+
+	h := sha256.Sum256(data)
+
+	return hex.EncodeToString(h[:]), nil
+}
+
+// apply creates or updates the policies of the subscription, returning the
+// names of the applied policies and of the policies which drifted from the
+// source. With pruning, the policies of the subscription no longer in the
+// source are deleted.
+func (r *PolicySubscriptionReconciler) apply(ctx context.Context, subscription *appstudioredhatcomv1alpha1.PolicySubscription, policies []appstudioredhatcomv1alpha1.EnterpriseContractPolicy) ([]string, []string, error) {
+	var applied, drifted []string
+	desired := map[string]bool{}
+
+	for _, p := range policies {
+		hash, err := specHash(p.Spec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		policy := appstudioredhatcomv1alpha1.EnterpriseContractPolicy{}
+		err = r.Get(ctx, client.ObjectKey{Namespace: subscription.Namespace, Name: p.Name}, &policy)
+		switch {
+		case apierrors.IsNotFound(err):
+			policy = appstudioredhatcomv1alpha1.EnterpriseContractPolicy{}
+			policy.Namespace = subscription.Namespace
+			policy.Name = p.Name
+		case err != nil:
+			return nil, nil, fmt.Errorf("unable to get policy %q: %w", p.Name, err)
+		case !metav1.IsControlledBy(&policy, subscription):
+			return nil, nil, fmt.Errorf("policy %q exists and is not owned by the subscription", p.Name)
+		default:
+			current, err := specHash(policy.Spec)
+			if err != nil {
+				return nil, nil, err
+			}
+			if current != policy.Annotations[AppliedSpecHashAnnotation] {
+				drifted = append(drifted, p.Name)
+			}
+		}
+
+		for k, v := range p.Labels {
+			metav1.SetMetaDataLabel(&policy.ObjectMeta, k, v)
+		}
+		for k, v := range p.Annotations {
+			metav1.SetMetaDataAnnotation(&policy.ObjectMeta, k, v)
+		}
+		metav1.SetMetaDataAnnotation(&policy.ObjectMeta, AppliedSpecHashAnnotation, hash)
+		policy.Spec = p.Spec
+		if err := controllerutil.SetControllerReference(subscription, &policy, r.Scheme); err != nil {
+			return nil, nil, err
+		}
+
+		if policy.ResourceVersion == "" {
+			err = r.Create(ctx, &policy)
+		} else {
+			err = r.Update(ctx, &policy)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to apply policy %q: %w", p.Name, err)
+		}
+
+		applied = append(applied, p.Name)
+		desired[p.Name] = true
+	}
+
+	if subscription.Spec.Prune {
+		existing := appstudioredhatcomv1alpha1.EnterpriseContractPolicyList{}
+		if err := r.List(ctx, &existing, client.InNamespace(subscription.Namespace)); err != nil {
+			return nil, nil, fmt.Errorf("unable to list policies: %w", err)
+		}
+
+		for i := range existing.Items {
+			policy := &existing.Items[i]
+			if desired[policy.Name] || !metav1.IsControlledBy(policy, subscription) {
+				continue
+			}
+			if err := r.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+				return nil, nil, fmt.Errorf("unable to prune policy %q: %w", policy.Name, err)
+			}
+		}
+	}
+
+	sort.Strings(applied)
+	sort.Strings(drifted)
+
+	return applied, drifted, nil
+}
+
+// specChanged filters out the updates of the status: each sync records its
+// lastSyncTime, which would otherwise trigger the next sync right away instead
+// of after the interval. Changes made to the spec of the owned policies still
+// trigger a sync, restoring them
+var specChanged = predicate.GenerationChangedPredicate{}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PolicySubscriptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Fetcher == nil {
+		r.Fetcher = fetch.NewFetcher()
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appstudioredhatcomv1alpha1.PolicySubscription{}, builder.WithPredicates(specChanged)).
+		Owns(&appstudioredhatcomv1alpha1.EnterpriseContractPolicy{}, builder.WithPredicates(specChanged)).
+		Complete(r)
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify/verifytest"
+)
+
+const subscribedPolicies = `apiVersion: appstudio.redhat.com/v1alpha1
+kind: EnterpriseContractPolicy
+metadata:
+  name: default
+  labels:
+    tier: prod
+spec:
+  sources:
+    - name: Default
+      policy:
+        - oci::quay.io/acme/policy:latest
+---
+apiVersion: appstudio.redhat.com/v1alpha1
+kind: EnterpriseContractPolicy
+metadata:
+  name: minimal
+spec:
+  description: Minimal
+`
+
+func reconcileSubscription(t *testing.T, r *PolicySubscriptionReconciler, subscription *ecc.PolicySubscription) (ecc.PolicySubscription, ctrl.Result, error) {
+	t.Helper()
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(subscription)})
+
+	got := ecc.PolicySubscription{}
+	if getErr := r.Get(context.Background(), client.ObjectKeyFromObject(subscription), &got); getErr != nil {
+		t.Fatalf("unexpected error getting subscription: %v", getErr)
+	}
+
+	return got, result, err
+}
+
+func TestReconcileSubscription(t *testing.T) {
+	ctx := context.Background()
+	repo, commit := verifytest.GitRepository(t, map[string]string{
+		"prod/policies.yaml":      subscribedPolicies,
+		"prod/kustomization.yaml": "resources:\n  - policies.yaml\n",
+		"dev/policy.yaml":         "apiVersion: appstudio.redhat.com/v1alpha1\nkind: EnterpriseContractPolicy\nmetadata:\n  name: dev\n",
+	}, nil)
+
+	subscription := ecctesting.NewPolicySubscription("acme", "prod", "git::"+repo+"//prod?ref=main")
+	subscription.Spec.Interval = metav1.Duration{Duration: time.Minute}
+	subscription.Spec.Prune = true
+
+	c := ecctesting.NewFakeClient(subscription)
+	r := PolicySubscriptionReconciler{Client: c, Scheme: c.Scheme(), Fetcher: fetch.NewLocalFetcher(), Pin: fetch.PinLocal}
+
+	got, result, err := reconcileSubscription(t, &r, subscription)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != time.Minute {
+		t.Errorf("expected to sync again after a minute, got %v", result.RequeueAfter)
+	}
+
+	revision := "git::" + repo + "//prod?ref=" + commit
+	if got.Status.LastAppliedRevision != revision || got.Status.LastAttemptedRevision != revision {
+		t.Errorf("expected revision %q to be applied, got %v", revision, got.Status)
+	}
+	if !reflect.DeepEqual([]string{"default", "minimal"}, got.Status.Policies) {
+		t.Errorf("expected the default and minimal policies, got %v", got.Status.Policies)
+	}
+	if len(got.Status.Drifted) != 0 {
+		t.Errorf("expected no drift, got %v", got.Status.Drifted)
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, ecc.ConditionSynced) {
+		t.Errorf("expected the subscription to be synced, got %v", got.Status.Conditions)
+	}
+
+	policy := ecc.EnterpriseContractPolicy{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "acme", Name: "default"}, &policy); err != nil {
+		t.Fatal(err)
+	}
+	if !metav1.IsControlledBy(&policy, &got) {
+		t.Errorf("expected the policy to be owned by the subscription, got %v", policy.OwnerReferences)
+	}
+	if policy.Labels["tier"] != "prod" {
+		t.Errorf("expected the labels of the manifest, got %v", policy.Labels)
+	}
+	if len(policy.Spec.Sources) != 1 || policy.Spec.Sources[0].Policy[0] != "oci::quay.io/acme/policy:latest" {
+		t.Errorf("expected the spec of the manifest, got %v", policy.Spec)
+	}
+
+	// changes made in the cluster are reported and restored
+	policy.Spec.Sources = nil
+	if err := c.Update(ctx, &policy); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _, err = reconcileSubscription(t, &r, subscription)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual([]string{"default"}, got.Status.Drifted) {
+		t.Errorf("expected the default policy to drift, got %v", got.Status.Drifted)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "acme", Name: "default"}, &policy); err != nil {
+		t.Fatal(err)
+	}
+	if len(policy.Spec.Sources) != 1 {
+		t.Errorf("expected the policy to be restored, got %v", policy.Spec)
+	}
+
+	// policies no longer in the source are pruned
+	got.Spec.Source = "git::" + repo + "//dev?ref=main"
+	if err := c.Update(ctx, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _, err = reconcileSubscription(t, &r, subscription)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual([]string{"dev"}, got.Status.Policies) || len(got.Status.Drifted) != 0 {
+		t.Errorf("expected the dev policy without drift, got %v", got.Status)
+	}
+
+	policies := ecc.EnterpriseContractPolicyList{}
+	if err := c.List(ctx, &policies, client.InNamespace("acme")); err != nil {
+		t.Fatal(err)
+	}
+	if len(policies.Items) != 1 || policies.Items[0].Name != "dev" {
+		t.Errorf("expected only the dev policy to remain, got %v", policies.Items)
+	}
+}
+
+func TestSubscriptionStatusUpdateIgnored(t *testing.T) {
+	repo, _ := verifytest.GitRepository(t, map[string]string{"policies.yaml": subscribedPolicies}, nil)
+
+	subscription := ecctesting.NewPolicySubscription("acme", "prod", "git::"+repo+"?ref=main")
+	subscription.Generation = 1
+
+	c := ecctesting.NewFakeClient(subscription)
+	r := PolicySubscriptionReconciler{Client: c, Scheme: c.Scheme(), Fetcher: fetch.NewLocalFetcher(), Pin: fetch.PinLocal}
+
+	got, _, err := reconcileSubscription(t, &r, subscription)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status.LastSyncTime == nil {
+		t.Fatalf("expected the sync to be recorded, got %v", got.Status)
+	}
+
+	// the status update of the sync does not trigger another sync
+	if specChanged.Update(event.UpdateEvent{ObjectOld: subscription, ObjectNew: &got}) {
+		t.Error("expected the status update not to trigger a reconcile")
+	}
+
+	changed := got.DeepCopy()
+	changed.Spec.Prune = true
+	changed.Generation++
+	if !specChanged.Update(event.UpdateEvent{ObjectOld: &got, ObjectNew: changed}) {
+		t.Error("expected the spec update to trigger a reconcile")
+	}
+
+	policy := ecc.EnterpriseContractPolicy{}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "acme", Name: "default"}, &policy); err != nil {
+		t.Fatal(err)
+	}
+	updated := policy.DeepCopy()
+	updated.Status.Conditions = []metav1.Condition{{Type: ecc.ConditionReady, Status: metav1.ConditionTrue}}
+	if specChanged.Update(event.UpdateEvent{ObjectOld: &policy, ObjectNew: updated}) {
+		t.Error("expected the status update of an owned policy not to trigger a reconcile")
+	}
+	updated.Generation++
+	if !specChanged.Update(event.UpdateEvent{ObjectOld: &policy, ObjectNew: updated}) {
+		t.Error("expected the spec update of an owned policy to trigger a reconcile")
+	}
+}
+
+func TestReconcileSubscriptionOCI(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	digest := verifytest.PushBundle(t, host+"/acme/policies:latest", map[string]string{"policies.yaml": subscribedPolicies})
+
+	subscription := ecctesting.NewPolicySubscription("acme", "prod", "oci::"+host+"/acme/policies:latest")
+	c := ecctesting.NewFakeClient(subscription)
+	r := PolicySubscriptionReconciler{Client: c, Scheme: c.Scheme(), Fetcher: fetch.NewLocalFetcher(), Pin: fetch.PinLocal}
+
+	got, result, err := reconcileSubscription(t, &r, subscription)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != defaultSyncInterval {
+		t.Errorf("expected to sync again after the default interval, got %v", result.RequeueAfter)
+	}
+	if expected := "oci::" + digest.String(); got.Status.LastAppliedRevision != expected {
+		t.Errorf("expected revision %q to be applied, got %q", expected, got.Status.LastAppliedRevision)
+	}
+	if !reflect.DeepEqual([]string{"default", "minimal"}, got.Status.Policies) {
+		t.Errorf("expected the default and minimal policies, got %v", got.Status.Policies)
+	}
+}
+
+func TestReconcileSubscriptionMinInterval(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	verifytest.PushBundle(t, host+"/acme/policies:latest", map[string]string{"policies.yaml": subscribedPolicies})
+
+	subscription := ecctesting.NewPolicySubscription("acme", "prod", "oci::"+host+"/acme/policies:latest")
+	subscription.Spec.Interval = metav1.Duration{Duration: time.Second}
+	c := ecctesting.NewFakeClient(subscription)
+	r := PolicySubscriptionReconciler{Client: c, Scheme: c.Scheme(), Fetcher: fetch.NewLocalFetcher(), Pin: fetch.PinLocal}
+
+	_, result, err := reconcileSubscription(t, &r, subscription)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != minSyncInterval {
+		t.Errorf("expected to sync again after the minimum interval, got %v", result.RequeueAfter)
+	}
+}
+
+func TestReadPoliciesSkipsSymlinks(t *testing.T) {
+	outside := filepath.Join(t.TempDir(), "secret.yaml")
+	if err := os.WriteFile(outside, []byte("apiVersion: appstudio.redhat.com/v1alpha1\nkind: EnterpriseContractPolicy\nmetadata:\n  name: outside\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "policies.yaml"), []byte(subscribedPolicies), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(dir, "linked.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	policies, err := readPolicies(dir, "acme")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, p := range policies {
+		names = append(names, p.Name)
+	}
+	if expected := []string{"default", "minimal"}; !reflect.DeepEqual(expected, names) {
+		t.Errorf("expected only the policies of the regular file %v, got %v", expected, names)
+	}
+}
+
+func TestReconcileSubscriptionFailures(t *testing.T) {
+	cases := []struct {
+		name     string
+		files    map[string]string
+		existing *ecc.EnterpriseContractPolicy
+		reason   string
+		message  string
+		err      bool
+	}{
+		{
+			name:    "invalid spec",
+			files:   map[string]string{"policy.yaml": "apiVersion: appstudio.redhat.com/v1alpha1\nkind: EnterpriseContractPolicy\nmetadata:\n  name: policy\nspec:\n  sourcez: []\n"},
+			reason:  "InvalidManifests",
+			message: "document 1 of policy.yaml: the spec is not valid",
+		},
+		{
+			name:    "other namespace",
+			files:   map[string]string{"policy.yaml": "apiVersion: appstudio.redhat.com/v1alpha1\nkind: EnterpriseContractPolicy\nmetadata:\n  name: policy\n  namespace: other\n"},
+			reason:  "InvalidManifests",
+			message: `policy "policy" is in namespace "other"`,
+		},
+		{
+			name:    "duplicate",
+			files:   map[string]string{"a.yaml": subscribedPolicies, "b.yaml": subscribedPolicies},
+			reason:  "InvalidManifests",
+			message: `policy "default" is defined in both a.yaml and b.yaml`,
+		},
+		{
+			name:     "not owned",
+			files:    map[string]string{"policies.yaml": subscribedPolicies},
+			existing: ecctesting.NewPolicySpec().Policy("acme", "default"),
+			reason:   "ApplyFailed",
+			message:  `policy "default" exists and is not owned by the subscription`,
+			err:      true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo, _ := verifytest.GitRepository(t, c.files, nil)
+			subscription := ecctesting.NewPolicySubscription("acme", "prod", "git::"+repo)
+
+			objs := []client.Object{subscription}
+			if c.existing != nil {
+				objs = append(objs, c.existing)
+			}
+			cl := ecctesting.NewFakeClient(objs...)
+			r := PolicySubscriptionReconciler{Client: cl, Scheme: cl.Scheme(), Fetcher: fetch.NewLocalFetcher(), Pin: fetch.PinLocal}
+
+			got, result, err := reconcileSubscription(t, &r, subscription)
+			if c.err && err == nil {
+				t.Error("expected an error")
+			}
+			if !c.err {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if result.RequeueAfter != defaultSyncInterval {
+					t.Errorf("expected to sync again after the default interval, got %v", result.RequeueAfter)
+				}
+			}
+
+			condition := meta.FindStatusCondition(got.Status.Conditions, ecc.ConditionSynced)
+			if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != c.reason || !strings.Contains(condition.Message, c.message) {
+				t.Errorf("expected the sync to fail with %s: %q, got %v", c.reason, c.message, condition)
+			}
+			if got.Status.LastAppliedRevision != "" || got.Status.LastAttemptedRevision == "" {
+				t.Errorf("expected only the attempted revision, got %v", got.Status)
+			}
+
+			if c.existing == nil {
+				err := cl.Get(context.Background(), client.ObjectKey{Namespace: "acme", Name: "policy"}, &ecc.EnterpriseContractPolicy{})
+				if !apierrors.IsNotFound(err) {
+					t.Errorf("expected no policy to be created, got %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,541 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	appstudioredhatcomv1alpha1 "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/ruledata"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/catalogue"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/effective"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/mirror"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify"
+)
+
+// CatalogueKey is the key in the rule catalogue ConfigMap holding the
+// catalogues of the policy sources in JSON
+const CatalogueKey = "catalogue.json"
+
+// PublicKeySecretKey is the key of the PEM encoded public key in Secrets
+// referred to for verifying the signatures of OCI bundles
+const PublicKeySecretKey = "cosign.pub"
+
+// maxRuleDataErrors limits the number of rule data errors recorded in the
+// status of a source
+const maxRuleDataErrors = 20
+
+// fetchedSource is the content of a policy source fetched to a temporary
+// directory
+type fetchedSource struct {
+	tmp string
+	// policy holds the directories of the policy rules
+	policy []string
+	// data holds the directories of the data, in the order of the data
+	// source URLs
+	data []string
+}
+
+// fetchSource fetches the policy rules and the data of the source of a policy
+// in the given namespace, the returned content must be closed once no longer
+// needed
+func (r *EnterpriseContractPolicyReconciler) fetchSource(ctx context.Context, source appstudioredhatcomv1alpha1.Source, namespace string) (*fetchedSource, error) {
+	fetcher := r.Fetcher
+	if fetcher == nil {
+		fetcher = fetch.NewFetcher()
+	}
+
+	tmp, err := os.MkdirTemp("", "ecp-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create a temporary directory: %w", err)
+	}
+
+	fetched := fetchedSource{tmp: tmp}
+	fetchAll := func(kind string, urls []string, fetch func(context.Context, string, string) (string, error)) ([]string, error) {
+		dirs := make([]string, 0, len(urls))
+		for i, url := range urls {
+			dir, err := fetch(ctx, url, filepath.Join(tmp, kind, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			dirs = append(dirs, dir)
+		}
+
+		return dirs, nil
+	}
+
+	if fetched.policy, err = fetchAll("policy", source.Policy, fetcher.Fetch); err != nil {
+		fetched.Close()
+		return nil, err
+	}
+
+	fetchData := func(ctx context.Context, url, dir string) (string, error) {
+		if appstudioredhatcomv1alpha1.IsKubernetesURL(url) {
+			return r.fetchConfigMap(ctx, url, namespace, dir)
+		}
+		return fetcher.Fetch(ctx, url, dir)
+	}
+
+	if fetched.data, err = fetchAll("data", source.Data, fetchData); err != nil {
+		fetched.Close()
+		return nil, err
+	}
+
+	return &fetched, nil
+}
+
+// fetchConfigMap writes each key of the ConfigMap the k8s://namespace/name URL
+// refers to as a file in the given directory. The ConfigMap must be in the
+// namespace of the policy, the manager would otherwise disclose the data of
+// any namespace to the authors of policies
+func (r *EnterpriseContractPolicyReconciler) fetchConfigMap(ctx context.Context, url, namespace, dir string) (string, error) {
+	name, err := appstudioredhatcomv1alpha1.ParseKubernetesURLIn(url, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	cm := corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &cm); err != nil {
+		return "", fmt.Errorf("unable to get ConfigMap %s/%s: %w", namespace, name, err)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("unable to create directory for ConfigMap %s/%s: %w", namespace, name, err)
+	}
+
+	for k, v := range configMapData(&cm) {
+		if err := os.WriteFile(filepath.Join(dir, filepath.Base(k)), v, 0o600); err != nil {
+			return "", fmt.Errorf("unable to write key %q of ConfigMap %s/%s: %w", k, namespace, name, err)
+		}
+	}
+
+	return dir, nil
+}
+
+// configMapData returns the data and the binary data of the ConfigMap
+func configMapData(cm *corev1.ConfigMap) map[string][]byte {
+	data := make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+	for k, v := range cm.BinaryData {
+		data[k] = v
+	}
+	for k, v := range cm.Data {
+		data[k] = []byte(v)
+	}
+
+	return data
+}
+
+// Close removes the fetched content
+func (f *fetchedSource) Close() {
+	os.RemoveAll(f.tmp)
+}
+
+// mirrorSource stores the fetched policy rules and data in the mirror,
+// returning the URLs they are served at. Only content fetched to the temporary
+// directory of the source is mirrored: sources on the local file system are
+// refused and data held in ConfigMaps is not mirrored, the policy runners read
+// it from the cluster.
+func mirrorSource(cache *mirror.Cache, source appstudioredhatcomv1alpha1.Source, fetched *fetchedSource) (*appstudioredhatcomv1alpha1.SourceMirror, error) {
+	m := appstudioredhatcomv1alpha1.SourceMirror{}
+	for i, dir := range fetched.policy {
+		if appstudioredhatcomv1alpha1.IsKubernetesURL(source.Policy[i]) || fetch.IsLocal(source.Policy[i]) {
+			return nil, fmt.Errorf("unable to mirror %s: only remote sources are mirrored", source.Policy[i])
+		}
+
+		url, err := cache.Store(fetched.tmp, dir)
+		if err != nil {
+			return nil, fmt.Errorf("unable to mirror %s: %w", source.Policy[i], err)
+		}
+		m.Policy = append(m.Policy, url)
+	}
+
+	for i, dir := range fetched.data {
+		if appstudioredhatcomv1alpha1.IsKubernetesURL(source.Data[i]) {
+			m.Data = append(m.Data, source.Data[i])
+			continue
+		}
+		if fetch.IsLocal(source.Data[i]) {
+			return nil, fmt.Errorf("unable to mirror %s: only remote sources are mirrored", source.Data[i])
+		}
+
+		url, err := cache.Store(fetched.tmp, dir)
+		if err != nil {
+			return nil, fmt.Errorf("unable to mirror %s: %w", source.Data[i], err)
+		}
+		m.Data = append(m.Data, url)
+	}
+
+	return &m, nil
+}
+
+// verifyBundles verifies the signatures of the OCI bundles of the source of a
+// policy in the given namespace, returning the source with the URLs of the
+// bundles pinned to the verified digests and the pinned URLs
+func verifyBundles(ctx context.Context, c client.Reader, source appstudioredhatcomv1alpha1.Source, namespace, rekorUrl string) (appstudioredhatcomv1alpha1.Source, []string, error) {
+	opts := verify.Options{Identity: source.BundleSignature.Identity, RekorURL: rekorUrl}
+	if key := source.BundleSignature.PublicKey; key != "" {
+		var err error
+		if opts.PublicKey, err = publicKey(ctx, c, key, namespace); err != nil {
+			return source, nil, err
+		}
+	}
+
+	var verified []string
+	pin := func(urls []string) ([]string, error) {
+		pinned := make([]string, 0, len(urls))
+		for _, url := range urls {
+			if !verify.IsBundle(url) {
+				pinned = append(pinned, url)
+				continue
+			}
+
+			p, err := verify.Bundle(ctx, url, opts)
+			if err != nil {
+				return nil, err
+			}
+			pinned = append(pinned, p)
+			verified = append(verified, p)
+		}
+
+		return pinned, nil
+	}
+
+	pinned := source
+	var err error
+	if pinned.Policy, err = pin(source.Policy); err != nil {
+		return source, nil, err
+	}
+	if pinned.Data, err = pin(source.Data); err != nil {
+		return source, nil, err
+	}
+
+	return pinned, verified, nil
+}
+
+// verifyCommits verifies the signatures of the commits the git repositories of
+// the source were fetched at
+func verifyCommits(ctx context.Context, source appstudioredhatcomv1alpha1.Source, fetched *fetchedSource) ([]appstudioredhatcomv1alpha1.VerifiedCommit, error) {
+	urls := append(append([]string(nil), source.Policy...), source.Data...)
+	dirs := append(append([]string(nil), fetched.policy...), fetched.data...)
+
+	var verified []appstudioredhatcomv1alpha1.VerifiedCommit
+	for i, url := range urls {
+		if !verify.IsGit(url) {
+			continue
+		}
+
+		signer, err := verify.Commit(ctx, dirs[i], source.CommitSignature.AllowedKeys)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", url, err)
+		}
+
+		verified = append(verified, appstudioredhatcomv1alpha1.VerifiedCommit{
+			Url:         url,
+			Commit:      signer.Commit,
+			Signer:      signer.Identity,
+			Fingerprint: signer.Fingerprint,
+		})
+	}
+
+	return verified, nil
+}
+
+// secretReader reads Secrets with the secrets reader and any other object with
+// the embedded reader. The manager only caches the metadata of Secrets, so the
+// Secrets holding keys and rule data are read from the API server instead.
+type secretReader struct {
+	client.Reader
+	secrets client.Reader
+}
+
+func (s secretReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, ok := obj.(*corev1.Secret); ok {
+		return s.secrets.Get(ctx, key, obj, opts...)
+	}
+
+	return s.Reader.Get(ctx, key, obj, opts...)
+}
+
+// withSecrets returns a reader reading Secrets with the given secrets reader,
+// the reader as is if the secrets reader is nil
+func withSecrets(c, secrets client.Reader) client.Reader {
+	if secrets == nil {
+		return c
+	}
+
+	return secretReader{Reader: c, secrets: secrets}
+}
+
+// publicKey returns the PEM encoded public key, reading it from the Secret
+// the key refers to when given as a k8s://namespace/name URL. The Secret must
+// be in the given namespace, the namespace of the policy
+func publicKey(ctx context.Context, c client.Reader, key, namespace string) ([]byte, error) {
+	if !appstudioredhatcomv1alpha1.IsKubernetesURL(key) {
+		return []byte(key), nil
+	}
+
+	name, err := appstudioredhatcomv1alpha1.ParseKubernetesURLIn(key, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &secret); err != nil {
+		return nil, fmt.Errorf("unable to get the public key Secret %s/%s: %w", namespace, name, err)
+	}
+
+	pub, ok := secret.Data[PublicKeySecretKey]
+	if !ok {
+		return nil, fmt.Errorf("the public key Secret %s/%s has no %q key", namespace, name, PublicKeySecretKey)
+	}
+
+	return pub, nil
+}
+
+// resolvedRuleData is the effective rule data of a source with ruleDataFrom
+// references
+type resolvedRuleData struct {
+	// effective is the effective rule data, including values from Secrets
+	effective *extv1.JSON
+	// disclosed is the effective rule data without the values from Secrets
+	disclosed *extv1.JSON
+	// sensitive are the keys of the effective rule data with values from
+	// Secrets
+	sensitive []string
+	hash      string
+}
+
+// resolveRuleData merges the rule data of the ruleDataFrom references of the
+// source with the inline rule data, returns nil if the source has no
+// references
+func resolveRuleData(ctx context.Context, c client.Reader, namespace string, source appstudioredhatcomv1alpha1.Source) (*resolvedRuleData, error) {
+	if len(source.RuleDataFrom) == 0 {
+		return nil, nil
+	}
+
+	ruleData := make([]effective.RuleData, 0, len(source.RuleDataFrom)+1)
+	for _, ref := range source.RuleDataFrom {
+		var obj client.Object
+		var selector *appstudioredhatcomv1alpha1.RuleDataKeySelector
+		var data func() map[string][]byte
+		switch {
+		case ref.ConfigMapRef != nil:
+			cm := corev1.ConfigMap{}
+			obj, selector = &cm, ref.ConfigMapRef
+			data = func() map[string][]byte { return configMapData(&cm) }
+		case ref.SecretRef != nil:
+			secret := corev1.Secret{}
+			obj, selector = &secret, ref.SecretRef
+			data = func() map[string][]byte { return secret.Data }
+		default:
+			return nil, fmt.Errorf("rule data reference sets neither configMapRef nor secretRef")
+		}
+
+		kind := "ConfigMap"
+		if ref.SecretRef != nil {
+			kind = "Secret"
+		}
+
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: selector.Name}, obj); err != nil {
+			if apierrors.IsNotFound(err) && selector.Optional {
+				continue
+			}
+			return nil, fmt.Errorf("unable to get rule data from %s %q: %w", kind, selector.Name, err)
+		}
+
+		d := data()
+		if _, ok := d[selector.Key]; selector.Key != "" && !ok && selector.Optional {
+			continue
+		}
+
+		values, err := effective.ParseRuleData(d, selector.Key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse rule data from %s %q: %w", kind, selector.Name, err)
+		}
+
+		rd := effective.RuleData{Values: values}
+		if ref.SecretRef != nil {
+			rd.Sensitive, rd.Revision = true, fmt.Sprintf("%s/%s", obj.GetUID(), obj.GetResourceVersion())
+		}
+		ruleData = append(ruleData, rd)
+	}
+
+	if source.RuleData != nil {
+		values := map[string]any{}
+		if err := json.Unmarshal(source.RuleData.Raw, &values); err != nil {
+			return nil, fmt.Errorf("unable to parse the inline rule data: %w", err)
+		}
+		ruleData = append(ruleData, effective.RuleData{Values: values})
+	}
+
+	merged, sensitive := effective.MergeRuleData(ruleData...)
+
+	resolved := resolvedRuleData{sensitive: sensitive}
+	var err error
+	if resolved.effective, err = effective.RuleDataJSON(merged); err != nil {
+		return nil, err
+	}
+	if resolved.disclosed, err = effective.RuleDataJSON(merged, sensitive...); err != nil {
+		return nil, err
+	}
+	if resolved.hash, err = effective.HashRuleData(ruleData...); err != nil {
+		return nil, err
+	}
+
+	return &resolved, nil
+}
+
+// validateRuleData validates the effective rule data and the rule data in the
+// data of the source against the rule data schema in the policy rules of the
+// source, if there is one. The messages of errors in the values of the
+// sensitive keys are redacted, the messages may quote the values.
+func validateRuleData(source appstudioredhatcomv1alpha1.Source, ruleData *extv1.JSON, sensitive []string, fetched *fetchedSource) []appstudioredhatcomv1alpha1.RuleDataError {
+	schema, err := ruledata.FindSchema(fetched.policy...)
+	if err != nil {
+		return []appstudioredhatcomv1alpha1.RuleDataError{{Document: ruledata.SchemaFileName, Message: err.Error()}}
+	}
+	if schema == nil {
+		return nil
+	}
+
+	v, err := ruledata.NewValidator(schema)
+	if err != nil {
+		return []appstudioredhatcomv1alpha1.RuleDataError{{Document: ruledata.SchemaFileName, Message: err.Error()}}
+	}
+
+	errs, err := v.ValidateInline(ruleData)
+	if err != nil {
+		errs = append(errs, appstudioredhatcomv1alpha1.RuleDataError{Document: ruledata.InlineDocument, Message: err.Error()})
+	}
+	for i := range errs {
+		if isSensitive(errs[i].Pointer, sensitive) {
+			errs[i].Message = "value from a Secret does not match the rule data schema"
+		}
+	}
+
+	for i, dir := range fetched.data {
+		dataErrs, err := v.ValidateData(dir)
+		if err != nil {
+			dataErrs = append(dataErrs, appstudioredhatcomv1alpha1.RuleDataError{Message: err.Error()})
+		}
+		for _, e := range dataErrs {
+			e.Data = source.Data[i]
+			errs = append(errs, e)
+		}
+	}
+
+	return errs
+}
+
+// isSensitive returns true if the JSON pointer points within the value of one
+// of the sensitive top level keys
+func isSensitive(pointer string, sensitive []string) bool {
+	for _, k := range sensitive {
+		p := "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+		if pointer == p || strings.HasPrefix(pointer, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sourceCatalogue is the catalogue of the rules of a policy source as
+// published in the rule catalogue ConfigMap
+type sourceCatalogue struct {
+	Name string `json:"name,omitempty"`
+	*catalogue.Catalogue
+}
+
+// catalogueSuffix ends the names of the rule catalogue ConfigMaps
+const catalogueSuffix = "-rule-catalogue"
+
+// catalogueName returns the name of the ConfigMap holding the rule catalogue
+// of the policy. The names of policies too long to be suffixed are truncated
+// and kept apart by the hash of the full name.
+func catalogueName(policy *appstudioredhatcomv1alpha1.EnterpriseContractPolicy) string {
+	name := policy.Name + catalogueSuffix
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(policy.Name)))[:8]
+	prefix := policy.Name[:validation.DNS1123SubdomainMaxLength-len(catalogueSuffix)-len(hash)-1]
+
+	return strings.TrimRight(prefix, ".-") + "-" + hash + catalogueSuffix
+}
+
+// reconcileCatalogue publishes the catalogues of the policy sources in a
+// ConfigMap controlled by the policy, returning the name of the ConfigMap and
+// the CataloguePublished condition. A ConfigMap of the same name not
+// controlled by the policy is left as is.
+func (r *EnterpriseContractPolicyReconciler) reconcileCatalogue(ctx context.Context, policy *appstudioredhatcomv1alpha1.EnterpriseContractPolicy, catalogues []sourceCatalogue) (string, metav1.Condition, error) {
+	data, err := json.Marshal(catalogues)
+	if err != nil {
+		return "", metav1.Condition{}, fmt.Errorf("unable to marshal the rule catalogue: %w", err)
+	}
+
+	cm := corev1.ConfigMap{}
+	cm.Namespace = policy.Namespace
+	cm.Name = catalogueName(policy)
+
+	err = r.Get(ctx, client.ObjectKeyFromObject(&cm), &cm)
+	switch {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return "", metav1.Condition{}, fmt.Errorf("unable to get the rule catalogue: %w", err)
+	case !metav1.IsControlledBy(&cm, policy):
+		return "", metav1.Condition{
+			Type:               appstudioredhatcomv1alpha1.ConditionCataloguePublished,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: policy.Generation,
+			Reason:             "ConfigMapConflict",
+			Message:            fmt.Sprintf("ConfigMap %q exists and is not controlled by the policy", cm.Name),
+		}, nil
+	}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, &cm, func() error {
+		cm.Data = map[string]string{CatalogueKey: string(data)}
+		return controllerutil.SetControllerReference(policy, &cm, r.Scheme)
+	}); err != nil {
+		return "", metav1.Condition{}, fmt.Errorf("unable to update the rule catalogue: %w", err)
+	}
+
+	return cm.Name, metav1.Condition{
+		Type:               appstudioredhatcomv1alpha1.ConditionCataloguePublished,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: policy.Generation,
+		Reason:             "Published",
+		Message:            fmt.Sprintf("Rule catalogue published in ConfigMap %q", cm.Name),
+	}, nil
+}
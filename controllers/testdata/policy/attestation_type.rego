@@ -0,0 +1,15 @@
+package release.attestation_type
+
+import rego.v1
+
+# METADATA
+# title: Known attestation type found
+# custom:
+#   short_name: known_attestation_type
+#   collections:
+#   - minimal
+#   - slsa3
+deny contains result if {
+	false
+	result := {}
+}
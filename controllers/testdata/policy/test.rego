@@ -0,0 +1,14 @@
+package release.test
+
+import rego.v1
+
+# METADATA
+# title: No tests failed
+# custom:
+#   short_name: no_failed_tests
+#   collections:
+#   - redhat
+deny contains result if {
+	false
+	result := {}
+}
@@ -6,12 +6,49 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
+	"text/template"
 
 	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/yaml"
 )
 
+var (
+	examplesDir = path.Join("docs", "modules", "ROOT", "examples")
+	pagesDir    = path.Join("docs", "modules", "ROOT", "pages")
+	crdPath     = path.Join("api", "config", "appstudio.redhat.com_enterprisecontractpolicies.yaml")
+)
+
+// example is a policy documented in the examples page
+type example struct {
+	// name is used as the name of the Kubernetes resource and as the prefix of
+	// the generated file names
+	name        string
+	title       string
+	description string
+	spec        ecc.EnterpriseContractPolicySpec
+}
+
+func (e example) jsonFile() string {
+	return e.name + "-spec-example.json"
+}
+
+func (e example) k8sFile() string {
+	return e.name + "-k8s-example.yaml"
+}
+
+func (e example) consoleFile() string {
+	return e.name + "-console-example.yaml"
+}
+
 func simplePolicy() *ecc.EnterpriseContractPolicySpec {
 	return &ecc.EnterpriseContractPolicySpec{
 		Description: "ACME & co policy",
@@ -33,50 +70,301 @@ func simplePolicy() *ecc.EnterpriseContractPolicySpec {
 	}
 }
 
-func generateJSONSpecExample() (err error) {
-	var out bytes.Buffer
-	encoder := json.NewEncoder(&out)
-	encoder.SetEscapeHTML(false)
-	encoder.SetIndent("", "  ")
+func extendedPolicy() ecc.EnterpriseContractPolicySpec {
+	return ecctesting.DefaultPolicySpec().
+		WithName("ACME").
+		WithDescription("The default policy extended with the rules specific to ACME").
+		WithSources(ecctesting.NewSource("ACME Policies").
+			WithPolicy("oci::registry.io/acme/enterprise-rules:latest").
+			WithRuleData(map[string]any{
+				"allowed_registry_prefixes": []string{"registry.io/acme/"},
+			}).
+			WithInclude("@acme")).
+		Build()
+}
+
+func catalogue() []example {
+	return []example{
+		{
+			name:        "simple",
+			title:       "Simple policy",
+			description: "Policy rules and data from a git repository, with some of the rules excluded.",
+			spec:        *simplePolicy(),
+		},
+		{
+			name:        "keyless",
+			title:       "Keyless verification",
+			description: "Verifies the image signatures made using keyless signing from a GitHub Actions workflow, instead of a public key.",
+			spec:        ecctesting.KeylessPolicySpec().Build(),
+		},
+		{
+			name:        "volatile",
+			title:       "Time bound exclusions",
+			description: "Excludes rules for a limited time, either for a single image by its digest or for all images in a repository, with a link to the issue tracking the exclusion.",
+			spec:        ecctesting.VolatilePolicySpec().Build(),
+		},
+		{
+			name:        "multiple-sources",
+			title:       "Multiple sources with rule data",
+			description: "Combines the release policy with custom policy rules, each source with its own rule data.",
+			spec:        ecctesting.MultiSourcePolicySpec().Build(),
+		},
+		{
+			name:        "extended",
+			title:       "Extending the default policy",
+			description: "Builds on the sources of the default policy by adding another source with organization specific rules.",
+			spec:        extendedPolicy(),
+		},
+	}
+}
+
+// validator validates the examples against the JSON schema and the CRD
+type validator struct {
+	jsonSchema *jsonschema.Schema
+	crd        validation.SchemaValidator
+}
+
+func newValidator(root string) (*validator, error) {
+	js, err := jsonschema.CompileString("policy_spec.json", ecc.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile the JSON schema: %w", err)
+	}
+
+	crdBytes, err := os.ReadFile(path.Join(root, crdPath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the CRD: %w", err)
+	}
+
+	crd := extv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(crdBytes, &crd); err != nil {
+		return nil, fmt.Errorf("unable to parse the CRD: %w", err)
+	}
+
+	crdv := apiextensions.CustomResourceValidation{}
+	if err := extv1.Convert_v1_CustomResourceValidation_To_apiextensions_CustomResourceValidation(crd.Spec.Versions[0].Schema, &crdv, nil); err != nil {
+		return nil, fmt.Errorf("unable to convert the CRD validation: %w", err)
+	}
+
+	s, err := schema.NewStructural(crdv.OpenAPIV3Schema)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the structural schema: %w", err)
+	}
+
+	return &validator{
+		jsonSchema: js,
+		crd:        validation.NewSchemaValidatorFromOpenAPI(s.ToKubeOpenAPI()),
+	}, nil
+}
+
+func (v *validator) validate(e example) error {
+	spec, err := toMap(e.spec)
+	if err != nil {
+		return err
+	}
+
+	if err := v.jsonSchema.Validate(spec); err != nil {
+		return fmt.Errorf("example %q is not valid according to the JSON schema: %w", e.name, err)
+	}
+
+	policy, err := toMap(k8sPolicy(e))
+	if err != nil {
+		return err
+	}
+
+	if result := v.crd.Validate(&unstructured.Unstructured{Object: policy}); !result.IsValid() {
+		return fmt.Errorf("example %q is not valid according to the CRD: %v", e.name, result.Errors)
+	}
 
-	if err = encoder.Encode(simplePolicy()); err == nil {
-		err = os.WriteFile(path.Join("docs", "modules", "ROOT", "examples", "spec-example.json"), out.Bytes(), 0644)
+	return nil
+}
+
+func toMap(v any) (map[string]any, error) {
+	j, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
 	}
 
-	return
+	m := map[string]any{}
+	err = json.Unmarshal(j, &m)
+
+	return m, err
 }
 
-func generateK8SYAMLExample() (err error) {
-	policy := ecc.EnterpriseContractPolicy{
+func k8sPolicy(e example) ecc.EnterpriseContractPolicy {
+	return ecc.EnterpriseContractPolicy{
 		TypeMeta: v1.TypeMeta{
 			Kind:       "EnterpriseContractPolicy",
 			APIVersion: ecc.GroupVersion.Identifier(),
 		},
 		ObjectMeta: v1.ObjectMeta{
-			Name:      "ec-policy",
+			Name:      e.name,
 			Namespace: "acme",
 		},
-		Spec: *simplePolicy(),
+		Spec: e.spec,
+	}
+}
+
+func jsonSpecExample(e example) ([]byte, error) {
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(e.spec)
+
+	return out.Bytes(), err
+}
+
+func k8sYAMLExample(e example) ([]byte, error) {
+	return yaml.Marshal(k8sPolicy(e))
+}
+
+// consoleYAMLSample is the OpenShift ConsoleYAMLSample resource, defined here
+// to avoid depending on the OpenShift API
+type consoleYAMLSample struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Metadata   map[string]string `json:"metadata"`
+	Spec       struct {
+		TargetResource v1.TypeMeta `json:"targetResource"`
+		Title          string      `json:"title"`
+		Description    string      `json:"description"`
+		YAML           string      `json:"yaml"`
+	} `json:"spec"`
+}
+
+func consoleYAMLExample(e example) ([]byte, error) {
+	policy := k8sPolicy(e)
+
+	// the sample is created in the namespace chosen in the console, and
+	// should contain only the fields the user is expected to fill in
+	p, err := toMap(policy)
+	if err != nil {
+		return nil, err
+	}
+	delete(p, "status")
+	p["metadata"] = map[string]any{"name": policy.Name}
+
+	y, err := yaml.Marshal(p)
+	if err != nil {
+		return nil, err
 	}
 
-	var out []byte
-	if out, err = yaml.Marshal(policy); err == nil {
-		err = os.WriteFile(path.Join("docs", "modules", "ROOT", "examples", "k8s-example.yaml"), out, 0644)
+	sample := consoleYAMLSample{
+		APIVersion: "console.openshift.io/v1",
+		Kind:       "ConsoleYAMLSample",
+		Metadata:   map[string]string{"name": "ecp-" + e.name},
 	}
+	sample.Spec.TargetResource = policy.TypeMeta
+	sample.Spec.Title = e.title
+	sample.Spec.Description = e.description
+	sample.Spec.YAML = string(y)
 
-	return
+	return yaml.Marshal(sample)
 }
 
-func main() {
-	generators := []func() error{
-		generateJSONSpecExample,
-		generateK8SYAMLExample,
+var examplesPage = template.Must(template.New("examples").Parse(`= Examples
+
+////
+Generated by docs/examples.go, do not edit.
+////
+
+The following examples are validated against the JSON schema and the
+Kubernetes Custom Resource Definition of the Enterprise Contract Policy.
+{{ range . }}
+== {{ .Title }}
+
+{{ .Description }}
+
+.{{ .JSONFile }}
+[source,json]
+----
+include::example${{ .JSONFile }}[]
+----
+
+.{{ .K8sFile }}
+[source,yaml]
+----
+include::example${{ .K8sFile }}[]
+----
+
+.{{ .ConsoleFile }}
+[source,yaml]
+----
+include::example${{ .ConsoleFile }}[]
+----
+{{ end }}`))
+
+func generateExamplesPage(root string, examples []example) error {
+	type entry struct {
+		Title, Description, JSONFile, K8sFile, ConsoleFile string
+	}
+
+	entries := make([]entry, 0, len(examples))
+	for _, e := range examples {
+		entries = append(entries, entry{
+			Title:       e.title,
+			Description: e.description,
+			JSONFile:    e.jsonFile(),
+			K8sFile:     e.k8sFile(),
+			ConsoleFile: e.consoleFile(),
+		})
+	}
+
+	var out strings.Builder
+	if err := examplesPage.Execute(&out, entries); err != nil {
+		return err
 	}
 
-	for _, g := range generators {
-		if err := g(); err != nil {
-			fmt.Fprintf(os.Stderr, "Unable to generate example: %v", err)
-			os.Exit(1)
+	return os.WriteFile(path.Join(root, pagesDir, "examples.adoc"), []byte(out.String()), 0644)
+}
+
+// generate validates all examples and writes them to the documentation
+func generate(root string) error {
+	v, err := newValidator(root)
+	if err != nil {
+		return err
+	}
+
+	examples := catalogue()
+	for _, e := range examples {
+		if err := v.validate(e); err != nil {
+			return err
 		}
 	}
+
+	if err := os.MkdirAll(path.Join(root, examplesDir), 0755); err != nil {
+		return err
+	}
+
+	generators := []struct {
+		file     func(example) string
+		generate func(example) ([]byte, error)
+	}{
+		{example.jsonFile, jsonSpecExample},
+		{example.k8sFile, k8sYAMLExample},
+		{example.consoleFile, consoleYAMLExample},
+	}
+
+	for _, e := range examples {
+		for _, g := range generators {
+			out, err := g.generate(e)
+			if err != nil {
+				return fmt.Errorf("unable to generate %s: %w", g.file(e), err)
+			}
+
+			if err := os.WriteFile(path.Join(root, examplesDir, g.file(e)), out, 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	return generateExamplesPage(root, examples)
+}
+
+func main() {
+	if err := generate("."); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to generate example: %v", err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+func TestCatalogueIsValid(t *testing.T) {
+	v, err := newValidator("..")
+	if err != nil {
+		t.Fatalf("unexpected error creating validator: %v", err)
+	}
+
+	for _, e := range catalogue() {
+		t.Run(e.name, func(t *testing.T) {
+			if err := v.validate(e); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestInvalidExamples(t *testing.T) {
+	v, err := newValidator("..")
+	if err != nil {
+		t.Fatalf("unexpected error creating validator: %v", err)
+	}
+
+	badDate := *simplePolicy()
+	badDate.Sources[0].VolatileConfig = &ecc.VolatileSourceConfig{
+		Exclude: []ecc.VolatileCriteria{{Value: "rule", EffectiveOn: "tomorrow"}},
+	}
+
+	badDigest := *simplePolicy()
+	badDigest.Sources[0].VolatileConfig = &ecc.VolatileSourceConfig{
+		Exclude: []ecc.VolatileCriteria{{Value: "rule", ImageDigest: "not-a-digest"}},
+	}
+
+	cases := map[string]ecc.EnterpriseContractPolicySpec{
+		"invalid date":   badDate,
+		"invalid digest": badDigest,
+	}
+
+	for name, spec := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := v.validate(example{name: "invalid", spec: spec}); err == nil {
+				t.Error("expected the example to be invalid")
+			}
+		})
+	}
+}
+
+func TestConsoleYAMLExample(t *testing.T) {
+	e := catalogue()[0]
+	out, err := consoleYAMLExample(e)
+	if err != nil {
+		t.Fatalf("unexpected error generating sample: %v", err)
+	}
+
+	sample := consoleYAMLSample{}
+	if err := yaml.Unmarshal(out, &sample); err != nil {
+		t.Fatalf("unexpected error parsing sample: %v", err)
+	}
+
+	if sample.Spec.TargetResource.Kind != "EnterpriseContractPolicy" {
+		t.Errorf("unexpected target resource: %v", sample.Spec.TargetResource)
+	}
+
+	policy := ecc.EnterpriseContractPolicy{}
+	if err := yaml.UnmarshalStrict([]byte(sample.Spec.YAML), &policy); err != nil {
+		t.Fatalf("unexpected error parsing the sample policy: %v", err)
+	}
+
+	if policy.Namespace != "" {
+		t.Errorf("expected no namespace in the sample, got %q", policy.Namespace)
+	}
+
+	if policy.Spec.Description != e.spec.Description {
+		t.Errorf("expected description %q, got %q", e.spec.Description, policy.Spec.Description)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(path.Join(root, pagesDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(path.Join(root, path.Dir(crdPath)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	crd, err := os.ReadFile(path.Join("..", crdPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path.Join(root, crdPath), crd, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := generate(root); err != nil {
+		t.Fatalf("unexpected error generating examples: %v", err)
+	}
+
+	page, err := os.ReadFile(path.Join(root, pagesDir, "examples.adoc"))
+	if err != nil {
+		t.Fatalf("unexpected error reading the examples page: %v", err)
+	}
+
+	for _, e := range catalogue() {
+		for _, f := range []string{e.jsonFile(), e.k8sFile(), e.consoleFile()} {
+			if _, err := os.Stat(path.Join(root, examplesDir, f)); err != nil {
+				t.Errorf("expected %s to be generated: %v", f, err)
+			}
+			if !strings.Contains(string(page), "include::example$"+f+"[]") {
+				t.Errorf("expected the examples page to include %s", f)
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+#!/usr/bin/env bash
+# Copyright 2025 Red Hat, Inc.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+#
+# SPDX-License-Identifier: Apache-2.0
+
+# Generates the typed clientset, listers and informers in the client module
+# from the types in the api module.
+# Usage:
+#   update-codegen.sh
+
+set -o errexit
+set -o pipefail
+set -o nounset
+
+ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)"
+MODULE=github.com/enterprise-contract/enterprise-contract-controller
+APIS="${MODULE}/api/v1alpha1"
+CLIENT="${MODULE}/client"
+HEADER="${ROOT}/hack/boilerplate.go.txt"
+
+CODE_GENERATOR="go run -modfile ${ROOT}/tools/go.mod k8s.io/code-generator/cmd"
+CLIENT_GEN="${CLIENT_GEN:-${CODE_GENERATOR}/client-gen}"
+LISTER_GEN="${LISTER_GEN:-${CODE_GENERATOR}/lister-gen}"
+INFORMER_GEN="${INFORMER_GEN:-${CODE_GENERATOR}/informer-gen}"
+
+# client-gen treats a package in a directory named "api" as the legacy core
+# group, so the generators are pointed to a temporary copy of the API types in
+# a directory named after the group instead, imports of the copy are rewritten
+# to the actual package path once generated
+ALIAS="${MODULE}/api/appstudio/v1alpha1"
+
+OUTPUT="$(mktemp -d)"
+trap 'rm -rf "${OUTPUT}" "${ROOT}/api/appstudio"' EXIT
+
+# the generators load the API types from the api module
+cd "${ROOT}/api"
+mkdir appstudio && cp -r v1alpha1 appstudio/
+
+${CLIENT_GEN} \
+  --go-header-file "${HEADER}" \
+  --output-base "${OUTPUT}" \
+  --input-base "${MODULE}/api" \
+  --input appstudio/v1alpha1 \
+  --clientset-name versioned \
+  --output-package "${CLIENT}/clientset"
+
+${LISTER_GEN} \
+  --go-header-file "${HEADER}" \
+  --output-base "${OUTPUT}" \
+  --input-dirs "${ALIAS}" \
+  --output-package "${CLIENT}/listers"
+
+${INFORMER_GEN} \
+  --go-header-file "${HEADER}" \
+  --output-base "${OUTPUT}" \
+  --input-dirs "${ALIAS}" \
+  --versioned-clientset-package "${CLIENT}/clientset/versioned" \
+  --listers-package "${CLIENT}/listers" \
+  --output-package "${CLIENT}/informers"
+
+find "${OUTPUT}" -name '*.go' -exec sed -i "s|${ALIAS}|${APIS}|g" {} +
+
+for dir in clientset listers informers; do
+  rm -rf "${ROOT}/client/${dir}"
+  cp -r "${OUTPUT}/${CLIENT}/${dir}" "${ROOT}/client/${dir}"
+done
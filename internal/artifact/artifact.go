@@ -0,0 +1,264 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package artifact packages flattened policies as OCI artifacts, so that
+// policies can be distributed between clusters and referred to by digest.
+//
+// The artifact is an OCI image manifest with the ConfigMediaType config and a
+// single PolicyMediaType layer holding the JSON encoded
+// EnterpriseContractPolicySpec. Artifacts are signed as done by cosign sign,
+// the signatures verify as the signatures of policy bundles do.
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	ocimutate "github.com/sigstore/cosign/v2/pkg/oci/mutate"
+	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	ocistatic "github.com/sigstore/cosign/v2/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+const (
+	// ConfigMediaType is the media type of the config of policy artifacts,
+	// identifying the artifact type
+	ConfigMediaType types.MediaType = "application/vnd.enterprisecontract.policy.config.v1+json"
+	// PolicyMediaType is the media type of the layer holding the policy
+	PolicyMediaType types.MediaType = "application/vnd.enterprisecontract.policy.spec.v1+json"
+	// titleAnnotation names the file the layer is extracted to
+	titleAnnotation = "org.opencontainers.image.title"
+	// policyFile is the name of the file the policy layer is extracted to
+	policyFile = "policy.json"
+	// maxPolicySize is the size limit of the policy layer, well above the
+	// size of any policy stored in the cluster
+	maxPolicySize = 4 << 20
+)
+
+// Options of pushing and pulling policy artifacts
+type Options struct {
+	// Signer signs the pushed artifact, the artifact is not signed when nil
+	Signer signature.Signer
+	// Keychain provides the credentials for accessing the registry,
+	// authn.DefaultKeychain when nil
+	Keychain authn.Keychain
+	// RemoteOptions are the options for accessing the registry
+	RemoteOptions []remote.Option
+}
+
+func (o Options) remoteOptions(ctx context.Context) []remote.Option {
+	keychain := o.Keychain
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+
+	return append([]remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(keychain)}, o.RemoteOptions...)
+}
+
+// Push pushes the policy as an OCI artifact to the given reference, signing
+// it when a signer is set, and returns the digest of the pushed artifact
+func Push(ctx context.Context, ref string, spec *ecc.EnterpriseContractPolicySpec, opts Options) (name.Digest, error) {
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("unable to parse reference %q: %w", ref, err)
+	}
+
+	content, err := json.Marshal(spec)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("unable to encode the policy: %w", err)
+	}
+
+	img := mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), ConfigMediaType)
+	img, err = mutate.Append(img, mutate.Addendum{
+		Layer:       static.NewLayer(content, PolicyMediaType),
+		Annotations: map[string]string{titleAnnotation: policyFile},
+	})
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("unable to create the artifact: %w", err)
+	}
+
+	remoteOpts := opts.remoteOptions(ctx)
+	if err := remote.Write(r, img, remoteOpts...); err != nil {
+		return name.Digest{}, fmt.Errorf("unable to push %q: %w", ref, err)
+	}
+
+	d, err := img.Digest()
+	if err != nil {
+		return name.Digest{}, err
+	}
+	digest := r.Context().Digest(d.String())
+
+	if opts.Signer != nil {
+		if err := sign(digest, opts.Signer, remoteOpts); err != nil {
+			return name.Digest{}, err
+		}
+	}
+
+	return digest, nil
+}
+
+// sign signs the artifact as done by cosign sign --key and pushes the
+// signature
+func sign(digest name.Digest, signer signature.Signer, remoteOpts []remote.Option) error {
+	p, err := payload.Cosign{Image: digest}.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("unable to create the signature payload: %w", err)
+	}
+
+	sig, err := signer.SignMessage(bytes.NewReader(p))
+	if err != nil {
+		return fmt.Errorf("unable to sign %s: %w", digest, err)
+	}
+
+	ociSig, err := ocistatic.NewSignature(p, base64.StdEncoding.EncodeToString(sig))
+	if err != nil {
+		return fmt.Errorf("unable to create the signature: %w", err)
+	}
+
+	ociOpts := ociremote.WithRemoteOptions(remoteOpts...)
+	se, err := ociremote.SignedImage(digest, ociOpts)
+	if err != nil {
+		return fmt.Errorf("unable to get %s: %w", digest, err)
+	}
+
+	if se, err = ocimutate.AttachSignatureToImage(se, ociSig); err != nil {
+		return fmt.Errorf("unable to attach the signature: %w", err)
+	}
+
+	if err := ociremote.WriteSignatures(digest.Repository, se, ociOpts); err != nil {
+		return fmt.Errorf("unable to push the signature of %s: %w", digest, err)
+	}
+
+	return nil
+}
+
+// Pull pulls the policy artifact with the given reference, returning the
+// policy and the digest of the artifact. The signature is not verified, see
+// verify.Bundle.
+func Pull(ctx context.Context, ref string, opts Options) (*ecc.EnterpriseContractPolicySpec, name.Digest, error) {
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, name.Digest{}, fmt.Errorf("unable to parse reference %q: %w", ref, err)
+	}
+
+	img, err := remote.Image(r, opts.remoteOptions(ctx)...)
+	if err != nil {
+		return nil, name.Digest{}, fmt.Errorf("unable to pull %q: %w", ref, err)
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, name.Digest{}, fmt.Errorf("unable to get the manifest of %q: %w", ref, err)
+	}
+	if manifest.Config.MediaType != ConfigMediaType {
+		return nil, name.Digest{}, fmt.Errorf("%q is not a policy artifact, its config media type is %q", ref, manifest.Config.MediaType)
+	}
+
+	var layer *v1.Descriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].MediaType == PolicyMediaType {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return nil, name.Digest{}, fmt.Errorf("%q has no %s layer", ref, PolicyMediaType)
+	}
+	if layer.Size > maxPolicySize {
+		return nil, name.Digest{}, fmt.Errorf("the policy layer of %q is %d bytes, over the limit of %d bytes", ref, layer.Size, maxPolicySize)
+	}
+
+	d, err := img.Digest()
+	if err != nil {
+		return nil, name.Digest{}, err
+	}
+
+	l, err := remote.Layer(r.Context().Digest(layer.Digest.String()), opts.remoteOptions(ctx)...)
+	if err != nil {
+		return nil, name.Digest{}, fmt.Errorf("unable to get the policy layer of %q: %w", ref, err)
+	}
+	rc, err := l.Compressed()
+	if err != nil {
+		return nil, name.Digest{}, fmt.Errorf("unable to read the policy layer of %q: %w", ref, err)
+	}
+	defer rc.Close()
+
+	// the size in the manifest is not trusted, the registry could serve more
+	content, err := io.ReadAll(io.LimitReader(rc, maxPolicySize+1))
+	if err != nil {
+		return nil, name.Digest{}, fmt.Errorf("unable to read the policy layer of %q: %w", ref, err)
+	}
+	if len(content) > maxPolicySize {
+		return nil, name.Digest{}, fmt.Errorf("the policy layer of %q is over the limit of %d bytes", ref, maxPolicySize)
+	}
+
+	spec := ecc.EnterpriseContractPolicySpec{}
+	if err := json.Unmarshal(content, &spec); err != nil {
+		return nil, name.Digest{}, fmt.Errorf("unable to decode the policy of %q: %w", ref, err)
+	}
+
+	return &spec, r.Context().Digest(d.String()), nil
+}
+
+// LoadSigner loads the PEM encoded private key, either encrypted as generated
+// by cosign generate-key-pair and decrypted with the password, or an
+// unencrypted PKCS #8, PKCS #1 or EC private key
+func LoadSigner(key, password []byte) (signature.Signer, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, fmt.Errorf("unable to parse the private key: no PEM block")
+	}
+
+	if block.Type == cosign.CosignPrivateKeyPemType || block.Type == cosign.SigstorePrivateKeyPemType {
+		signer, err := cosign.LoadPrivateKey(key, password)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the private key: %w", err)
+		}
+
+		return signer, nil
+	}
+
+	pk, err := cryptoutils.UnmarshalPEMToPrivateKey(key, cryptoutils.SkipPassword)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the private key: %w", err)
+	}
+
+	signer, err := signature.LoadSigner(pk, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the private key: %w", err)
+	}
+
+	return signer, nil
+}
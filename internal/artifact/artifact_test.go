@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify/verifytest"
+)
+
+func policy() *ecc.EnterpriseContractPolicySpec {
+	return &ecc.EnterpriseContractPolicySpec{
+		Description: "Flattened",
+		Sources: []ecc.Source{
+			{
+				Name:     "Default",
+				Policy:   []string{"oci::quay.io/acme/policy@sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+				RuleData: &extv1.JSON{Raw: []byte(`{"allowed_registries":["quay.io/acme"]}`)},
+				Config:   &ecc.SourceConfig{Include: []string{"@minimal"}},
+			},
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	host := verifytest.NewRegistry(t)
+
+	key, pub := verifytest.GenerateKey(t)
+	keyPEM, err := cryptoutils.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := LoadSigner(keyPEM, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest, err := Push(ctx, host+"/acme/policy:v1", policy(), Options{Signer: signer})
+	if err != nil {
+		t.Fatalf("unable to push: %v", err)
+	}
+
+	verified, err := verify.Bundle(ctx, "oci::"+host+"/acme/policy:v1", verify.Options{PublicKey: pub})
+	if err != nil {
+		t.Fatalf("unable to verify: %v", err)
+	}
+	if expected := "oci::" + digest.String(); verified != expected {
+		t.Errorf("expected the verified artifact to be %q, got %q", expected, verified)
+	}
+
+	got, pulled, err := Pull(ctx, digest.String(), Options{})
+	if err != nil {
+		t.Fatalf("unable to pull: %v", err)
+	}
+	if pulled != digest {
+		t.Errorf("expected to pull %s, got %s", digest, pulled)
+	}
+	if !reflect.DeepEqual(got, policy()) {
+		t.Errorf("expected the pulled policy to be %#v, got %#v", policy(), got)
+	}
+}
+
+func TestPushUnsigned(t *testing.T) {
+	ctx := context.Background()
+	host := verifytest.NewRegistry(t)
+	_, pub := verifytest.GenerateKey(t)
+
+	if _, err := Push(ctx, host+"/acme/policy:v1", policy(), Options{}); err != nil {
+		t.Fatalf("unable to push: %v", err)
+	}
+
+	if _, err := verify.Bundle(ctx, "oci::"+host+"/acme/policy:v1", verify.Options{PublicKey: pub}); err == nil || !strings.Contains(err.Error(), "no signatures found") {
+		t.Errorf("expected no signatures to be found, got %v", err)
+	}
+}
+
+func TestPullNotAPolicy(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	verifytest.PushBundle(t, host+"/acme/bundle:latest", map[string]string{"release.rego": "package release"})
+
+	_, _, err := Pull(context.Background(), host+"/acme/bundle:latest", Options{})
+	if err == nil || !strings.Contains(err.Error(), "is not a policy artifact") {
+		t.Errorf("expected the bundle not to be a policy artifact, got %v", err)
+	}
+}
+
+// sizedLayer is a layer with a size not matching its content
+type sizedLayer struct {
+	v1.Layer
+	size int64
+}
+
+func (l sizedLayer) Size() (int64, error) {
+	return l.size, nil
+}
+
+func TestPullTooLarge(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	content := append([]byte(`{"description": "`), bytes.Repeat([]byte("x"), maxPolicySize)...)
+	content = append(content, []byte(`"}`)...)
+
+	cases := []struct {
+		name string
+		size int64
+	}{
+		{name: "too-large", size: int64(len(content))},
+		// the size in the manifest is not trusted
+		{name: "understated", size: 100},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			img := mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), ConfigMediaType)
+			img, err := mutate.Append(img, mutate.Addendum{Layer: sizedLayer{Layer: static.NewLayer(content, PolicyMediaType), size: c.size}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ref, err := name.ParseReference(host + "/acme/" + c.name + ":latest")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := remote.Write(ref, img); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, _, err := Pull(context.Background(), ref.String(), Options{}); err == nil || !strings.Contains(err.Error(), "over the limit") {
+				t.Errorf("expected the policy layer to be over the limit, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadSigner(t *testing.T) {
+	password := []byte("s3cr3t")
+	keys, err := cosign.GenerateKeyPair(func(bool) ([]byte, error) { return password, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, _ := verifytest.GenerateKey(t)
+	plain, err := cryptoutils.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		key      []byte
+		password []byte
+		err      string
+	}{
+		{name: "encrypted", key: keys.PrivateBytes, password: password},
+		{name: "wrong password", key: keys.PrivateBytes, password: []byte("wrong"), err: "unable to load the private key"},
+		{name: "plain", key: plain},
+		{name: "not PEM", key: []byte("key"), err: "no PEM block"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			signer, err := LoadSigner(c.key, c.password)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Errorf("expected error containing %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if signer == nil {
+				t.Error("expected a signer")
+			}
+		})
+	}
+}
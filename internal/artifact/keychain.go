@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifact
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// Anonymous is the keychain without any credentials
+var Anonymous authn.Keychain = authn.NewMultiKeychain()
+
+// dockerConfig is the content of kubernetes.io/dockerconfigjson Secrets
+type dockerConfig struct {
+	Auths map[string]dockerAuth `json:"auths"`
+}
+
+type dockerAuth struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// dockerConfigKeychain resolves the credentials of the registries by host
+type dockerConfigKeychain map[string]authn.AuthConfig
+
+// NewKeychain returns the keychain with the credentials of the Docker config
+// JSON, as held by kubernetes.io/dockerconfigjson Secrets. Only the auths are
+// used, credential helpers are not run. Registries without credentials are
+// accessed anonymously.
+func NewKeychain(dockerConfigJSON []byte) (authn.Keychain, error) {
+	config := dockerConfig{}
+	if err := json.Unmarshal(dockerConfigJSON, &config); err != nil {
+		return nil, fmt.Errorf("unable to parse the Docker config: %w", err)
+	}
+
+	keychain := dockerConfigKeychain{}
+	for registry, auth := range config.Auths {
+		cfg := authn.AuthConfig{Username: auth.Username, Password: auth.Password}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode the auth of %q: %w", registry, err)
+			}
+			var ok bool
+			if cfg.Username, cfg.Password, ok = strings.Cut(string(decoded), ":"); !ok {
+				return nil, fmt.Errorf("the auth of %q is not username:password", registry)
+			}
+		}
+
+		keychain[registryHost(registry)] = cfg
+	}
+
+	return keychain, nil
+}
+
+func (k dockerConfigKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	cfg, ok := k[registryHost(target.RegistryStr())]
+	if !ok {
+		return authn.Anonymous, nil
+	}
+
+	return authn.FromConfig(cfg), nil
+}
+
+// registryHost returns the host of the registry the key of the auths refers
+// to, given as a host or as a URL such as https://index.docker.io/v1/
+func registryHost(registry string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	host, _, _ = strings.Cut(host, "/")
+
+	if host == "docker.io" {
+		return name.DefaultRegistry
+	}
+
+	return host
+}
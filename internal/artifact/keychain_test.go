@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify/verifytest"
+)
+
+func TestNewKeychain(t *testing.T) {
+	keychain, err := NewKeychain([]byte(`{"auths": {
+		"quay.io": {"auth": "YWNtZTpzM2NyM3Q="},
+		"https://index.docker.io/v1/": {"username": "hub", "password": "p4ss"},
+		"registry.io:5000/acme": {"username": "acme", "password": "pw"}
+	}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		repository string
+		expected   authn.AuthConfig
+	}{
+		{repository: "quay.io/acme/policy", expected: authn.AuthConfig{Username: "acme", Password: "s3cr3t"}},
+		{repository: "acme/policy", expected: authn.AuthConfig{Username: "hub", Password: "p4ss"}},
+		{repository: "registry.io:5000/other/policy", expected: authn.AuthConfig{Username: "acme", Password: "pw"}},
+		{repository: "ghcr.io/acme/policy"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.repository, func(t *testing.T) {
+			repo, err := name.NewRepository(c.repository)
+			if err != nil {
+				t.Fatal(err)
+			}
+			auth, err := keychain.Resolve(repo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := auth.Authorization()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, *got)
+			}
+		})
+	}
+}
+
+func TestNewKeychainInvalid(t *testing.T) {
+	for _, config := range []string{`{`, `{"auths": {"quay.io": {"auth": "!"}}}`, `{"auths": {"quay.io": {"auth": "YWNtZQ=="}}}`} {
+		if _, err := NewKeychain([]byte(config)); err == nil {
+			t.Errorf("expected an error for %s", config)
+		}
+	}
+}
+
+func TestPushKeychain(t *testing.T) {
+	ctx := context.Background()
+	host := verifytest.NewAuthRegistry(t, "acme", "s3cr3t")
+
+	if _, err := Push(ctx, host+"/acme/policy:v1", policy(), Options{Keychain: Anonymous}); err == nil {
+		t.Error("expected an error pushing without credentials")
+	}
+
+	keychain, err := NewKeychain([]byte(fmt.Sprintf(`{"auths": {%q: {"username": "acme", "password": "s3cr3t"}}}`, host)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Push(ctx, host+"/acme/policy:v1", policy(), Options{Keychain: keychain}); err != nil {
+		t.Errorf("unable to push with the credentials: %v", err)
+	}
+}
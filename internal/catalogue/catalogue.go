@@ -0,0 +1,227 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package catalogue discovers the rules, packages and collections defined in
+// policy rules from the METADATA annotations of the Rego rules.
+package catalogue
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// Rule is a documented policy rule
+type Rule struct {
+	// Code of the rule, i.e. the package name and the short name of the rule
+	Code string `json:"code"`
+	// Title of the rule
+	Title string `json:"title,omitempty"`
+	// Collections the rule is part of
+	Collections []string `json:"collections,omitempty"`
+}
+
+// Catalogue lists the rules, and the packages and collections of those
+// rules, found in policy rules
+type Catalogue struct {
+	Packages    []string `json:"packages"`
+	Collections []string `json:"collections"`
+	Rules       []Rule   `json:"rules"`
+}
+
+// Load builds the catalogue of the Rego files in the given directories and
+// their subdirectories. Only rules with the short_name custom annotation are
+// considered, test files are skipped.
+func Load(dirs ...string) (*Catalogue, error) {
+	rules := map[string]Rule{}
+
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() || filepath.Ext(path) != ".rego" || strings.HasSuffix(path, "_test.rego") {
+				return nil
+			}
+
+			found, err := loadFile(path)
+			if err != nil {
+				return err
+			}
+			for _, r := range found {
+				rules[r.Code] = r
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newCatalogue(rules), nil
+}
+
+func loadFile(path string) ([]Rule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	module, err := ast.ParseModuleWithOpts(path, string(content), ast.ParserOptions{ProcessAnnotation: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	if module == nil {
+		return nil, nil
+	}
+
+	pkg := packageName(module.Package)
+
+	var rules []Rule
+	for _, rule := range module.Rules {
+		for _, a := range rule.Annotations {
+			if a.Scope != "rule" {
+				continue
+			}
+
+			shortName, ok := a.Custom["short_name"].(string)
+			if !ok || shortName == "" {
+				continue
+			}
+
+			rules = append(rules, Rule{
+				Code:        pkg + "." + shortName,
+				Title:       a.Title,
+				Collections: stringList(a.Custom["collections"]),
+			})
+		}
+	}
+
+	return rules, nil
+}
+
+// packageName returns the last segment of the package path, which is how
+// packages are referred to from includes and excludes
+func packageName(pkg *ast.Package) string {
+	last := pkg.Path[len(pkg.Path)-1].Value
+	if s, ok := last.(ast.String); ok {
+		return string(s)
+	}
+
+	return last.String()
+}
+
+func stringList(v any) []string {
+	values, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+
+	list := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			list = append(list, s)
+		}
+	}
+	sort.Strings(list)
+
+	return list
+}
+
+func newCatalogue(rules map[string]Rule) *Catalogue {
+	c := Catalogue{
+		Packages:    []string{},
+		Collections: []string{},
+		Rules:       make([]Rule, 0, len(rules)),
+	}
+
+	packages := map[string]bool{}
+	collections := map[string]bool{}
+	for _, r := range rules {
+		c.Rules = append(c.Rules, r)
+		pkg, _, _ := strings.Cut(r.Code, ".")
+		packages[pkg] = true
+		for _, col := range r.Collections {
+			collections[col] = true
+		}
+	}
+
+	for p := range packages {
+		c.Packages = append(c.Packages, p)
+	}
+	for col := range collections {
+		c.Collections = append(c.Collections, col)
+	}
+
+	sort.Strings(c.Packages)
+	sort.Strings(c.Collections)
+	sort.Slice(c.Rules, func(i, j int) bool { return c.Rules[i].Code < c.Rules[j].Code })
+
+	return &c
+}
+
+// HasCollection returns true if any of the rules is in the named collection
+func (c *Catalogue) HasCollection(name string) bool {
+	return contains(c.Collections, name)
+}
+
+// Matches returns true if the include or exclude value matches a rule,
+// package or collection in the catalogue. Values are in the form of "@"
+// prefixed collections, package names, package names with the "*" wildcard
+// or rule codes, optionally followed by ":" and a term.
+func (c *Catalogue) Matches(value string) bool {
+	if name, ok := strings.CutPrefix(value, ecc.CollectionPrefix); ok {
+		return c.HasCollection(name)
+	}
+
+	value, _, _ = strings.Cut(value, ":")
+
+	pkg, rule, ok := strings.Cut(value, ".")
+	if !ok || rule == "*" {
+		return contains(c.Packages, pkg)
+	}
+
+	i := sort.Search(len(c.Rules), func(i int) bool { return c.Rules[i].Code >= value })
+
+	return i < len(c.Rules) && c.Rules[i].Code == value
+}
+
+// Unmatched returns the values that match no rule, package or collection in
+// the catalogue
+func (c *Catalogue) Unmatched(values []string) []string {
+	var unmatched []string
+	for _, v := range values {
+		if !c.Matches(v) {
+			unmatched = append(unmatched, v)
+		}
+	}
+
+	return unmatched
+}
+
+func contains(sorted []string, value string) bool {
+	i := sort.SearchStrings(sorted, value)
+	return i < len(sorted) && sorted[i] == value
+}
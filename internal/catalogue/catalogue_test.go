@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package catalogue
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	c, err := Load("testdata/policy")
+	if err != nil {
+		t.Fatalf("unexpected error loading catalogue: %v", err)
+	}
+
+	expected := &Catalogue{
+		Packages:    []string{"attestation_type", "test"},
+		Collections: []string{"minimal", "policy_data", "redhat", "slsa3"},
+		Rules: []Rule{
+			{
+				Code:        "attestation_type.known_attestation_type",
+				Title:       "Known attestation type found",
+				Collections: []string{"minimal", "slsa3"},
+			},
+			{
+				Code:        "attestation_type.known_attestation_types_provided",
+				Title:       "Known attestation types provided",
+				Collections: []string{"policy_data"},
+			},
+			{
+				Code:        "test.no_failed_tests",
+				Title:       "No tests failed",
+				Collections: []string{"redhat"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, c) {
+		t.Errorf("expected %#v, got %#v", expected, c)
+	}
+}
+
+func TestLoadInvalidRego(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "invalid.rego"), []byte("package"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(dir); err == nil {
+		t.Error("expected an error loading invalid Rego")
+	}
+}
+
+func TestLoadEmpty(t *testing.T) {
+	c, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error loading catalogue: %v", err)
+	}
+
+	if len(c.Rules) != 0 || c.Matches("anything") {
+		t.Errorf("expected an empty catalogue, got %v", c)
+	}
+}
+
+func TestMatches(t *testing.T) {
+	c, err := Load("testdata/policy")
+	if err != nil {
+		t.Fatalf("unexpected error loading catalogue: %v", err)
+	}
+
+	cases := map[string]bool{
+		"@slsa3":             true,
+		"@minimal":           true,
+		"@slsa1":             false,
+		"attestation_type":   true,
+		"attestation_type.*": true,
+		"attestation_type.known_attestation_type":   true,
+		"attestation_type.known_attestation_type:x": true,
+		"test:task":            true,
+		"test.no_failed_tests": true,
+		"test.no_tests":        false,
+		"lib":                  false,
+		"friday_policy":        false,
+		"room_temperature":     false,
+		"attestation_type.known_attestation_types":        false,
+		"release.attestation_type.known_attestation_type": false,
+	}
+
+	for value, expected := range cases {
+		t.Run(value, func(t *testing.T) {
+			if got := c.Matches(value); got != expected {
+				t.Errorf("expected %v, got %v", expected, got)
+			}
+		})
+	}
+
+	unmatched := c.Unmatched([]string{"@slsa3", "friday_policy", "test", "room_temperature"})
+	if expected := []string{"friday_policy", "room_temperature"}; !reflect.DeepEqual(expected, unmatched) {
+		t.Errorf("expected unmatched %v, got %v", expected, unmatched)
+	}
+}
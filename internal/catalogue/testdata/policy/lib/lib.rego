@@ -0,0 +1,7 @@
+package lib
+
+import rego.v1
+
+rule_data(key) := data.rule_data[key]
+
+result_helper(chain, params) := {"msg": sprintf(chain.rule.custom.failure_msg, params)}
@@ -0,0 +1,42 @@
+#
+# METADATA
+# title: Attestation type
+# description: >-
+#   Sanity checks related to the format of the image build's attestation.
+#
+package release.attestation_type
+
+import rego.v1
+
+import data.lib
+
+# METADATA
+# title: Known attestation type found
+# description: >-
+#   Confirm the attestation found for the image has a known attestation type.
+# custom:
+#   short_name: known_attestation_type
+#   failure_msg: Unknown attestation type '%s'
+#   collections:
+#   - minimal
+#   - slsa3
+#
+deny contains result if {
+	some att in lib.pipelinerun_attestations
+	not att.statement._type in lib.rule_data("known_attestation_types")
+	result := lib.result_helper(rego.metadata.chain(), [att.statement._type])
+}
+
+# METADATA
+# title: Known attestation types provided
+# description: Confirm the `known_attestation_types` rule data was provided.
+# custom:
+#   short_name: known_attestation_types_provided
+#   failure_msg: Missing required known_attestation_types rule data
+#   collections:
+#   - policy_data
+#
+deny contains result if {
+	count(lib.rule_data("known_attestation_types")) == 0
+	result := lib.result_helper(rego.metadata.chain(), [])
+}
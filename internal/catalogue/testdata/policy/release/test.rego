@@ -0,0 +1,31 @@
+#
+# METADATA
+# title: Test
+# description: Checks the results of the tests run by the pipeline.
+#
+package policy.release.test
+
+import rego.v1
+
+import data.lib
+
+# METADATA
+# title: No tests failed
+# description: Produce a violation if any of the tests failed.
+# custom:
+#   short_name: no_failed_tests
+#   failure_msg: "The following tests failed: %s"
+#   collections:
+#   - redhat
+#
+deny contains result if {
+	some test in lib.results_named("TEST_OUTPUT")
+	test.value.result == "FAILURE"
+	result := lib.result_helper(rego.metadata.chain(), [test.name])
+}
+
+# not documented, so not part of the catalogue
+warn contains result if {
+	false
+	result := {}
+}
@@ -0,0 +1,12 @@
+package policy.release.test_test
+
+import rego.v1
+
+import data.policy.release.test
+
+# METADATA
+# custom:
+#   short_name: not_a_rule
+test_no_failures if {
+	count(test.deny) == 0 with data.lib.results_named as []
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deprecation finds the deprecated fields used in policies, warned of
+// on admission, and tracks the policies still using them in a metric.
+package deprecation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// Deprecated fields, as reported in the field label of the metric
+const (
+	Configuration = "configuration"
+	Collections   = "configuration.collections"
+	ImageRef      = "imageRef"
+)
+
+// fields are all the deprecated fields
+var fields = []string{Configuration, Collections, ImageRef}
+
+// Replacements of the deprecated fields
+var Replacements = map[string]string{
+	Configuration: "use the config of the policy sources instead",
+	Collections:   `list the collections in include with the "@" prefix instead, e.g. "@minimal"`,
+	ImageRef:      "use imageDigest instead",
+}
+
+// policiesUsing is the number of policies using each deprecated field
+var policiesUsing = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "ecp_deprecated_field_policies",
+	Help: "Number of EnterpriseContractPolicies using deprecated fields, by namespace and field.",
+}, []string{"namespace", "field"})
+
+func init() {
+	metrics.Registry.MustRegister(policiesUsing)
+}
+
+// Use is the use of a deprecated field in a policy
+type Use struct {
+	// Field is the deprecated field, one of Configuration, Collections and
+	// ImageRef
+	Field string
+	// Path is the path to the field in the policy
+	Path *field.Path
+}
+
+// Find returns the deprecated fields used in the policy
+func Find(spec ecc.EnterpriseContractPolicySpec) []Use {
+	var used []Use
+
+	if spec.Configuration != nil {
+		path := field.NewPath("spec", "configuration")
+		used = append(used, Use{Field: Configuration, Path: path})
+		if len(spec.Configuration.Collections) > 0 {
+			used = append(used, Use{Field: Collections, Path: path.Child("collections")})
+		}
+	}
+
+	for i, s := range spec.Sources {
+		if s.VolatileConfig == nil {
+			continue
+		}
+
+		path := field.NewPath("spec", "sources").Index(i).Child("volatileConfig")
+		for _, criteria := range []struct {
+			name     string
+			criteria []ecc.VolatileCriteria
+		}{{"include", s.VolatileConfig.Include}, {"exclude", s.VolatileConfig.Exclude}} {
+			for j, c := range criteria.criteria {
+				if c.ImageRef != "" {
+					used = append(used, Use{Field: ImageRef, Path: path.Child(criteria.name).Index(j).Child("imageRef")})
+				}
+			}
+		}
+	}
+
+	return used
+}
+
+// Record sets the number of policies using each deprecated field in the
+// namespace, given all the policies in the namespace
+func Record(namespace string, policies []ecc.EnterpriseContractPolicy) {
+	counts := make(map[string]int, len(fields))
+	for _, p := range policies {
+		seen := map[string]bool{}
+		for _, u := range Find(p.Spec) {
+			if !seen[u.Field] {
+				seen[u.Field] = true
+				counts[u.Field]++
+			}
+		}
+	}
+
+	for _, f := range fields {
+		policiesUsing.WithLabelValues(namespace, f).Set(float64(counts[f]))
+	}
+}
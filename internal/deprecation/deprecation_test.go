@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+)
+
+const imageDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func deprecatedPolicy(name string) ecc.EnterpriseContractPolicy {
+	policy := ecctesting.NewPolicySpec().
+		WithConfiguration(ecc.EnterpriseContractPolicyConfiguration{Include: []string{"a"}, Collections: []string{"minimal"}}).
+		WithSources(ecctesting.NewSource("a").
+			WithPolicy(ecctesting.ReleasePolicyURL).
+			WithVolatileExclude(ecctesting.NewVolatileCriteria("c"), ecctesting.NewVolatileCriteria("d"))).
+		Policy("acme", name)
+	policy.Spec.Sources[0].VolatileConfig.Exclude[0].ImageRef = imageDigest
+	policy.Spec.Sources[0].VolatileConfig.Exclude[1].ImageRef = imageDigest
+
+	return *policy
+}
+
+func TestFind(t *testing.T) {
+	var got []string
+	for _, u := range Find(deprecatedPolicy("policy").Spec) {
+		got = append(got, u.Field+" "+u.Path.String())
+	}
+
+	expected := []string{
+		"configuration spec.configuration",
+		"configuration.collections spec.configuration.collections",
+		"imageRef spec.sources[0].volatileConfig.exclude[0].imageRef",
+		"imageRef spec.sources[0].volatileConfig.exclude[1].imageRef",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], got[i])
+		}
+	}
+}
+
+func TestRecord(t *testing.T) {
+	policiesUsing.Reset()
+
+	migrated := ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").WithInclude("@minimal")).Policy("acme", "migrated")
+	Record("acme", []ecc.EnterpriseContractPolicy{deprecatedPolicy("a"), deprecatedPolicy("b"), *migrated})
+
+	// each policy counts once, however many times it uses a field
+	for field, count := range map[string]float64{Configuration: 2, Collections: 2, ImageRef: 2} {
+		if got := testutil.ToFloat64(policiesUsing.WithLabelValues("acme", field)); got != count {
+			t.Errorf("expected %v policies using %s, got %v", count, field, got)
+		}
+	}
+
+	// the count goes down as policies migrate or are deleted
+	Record("acme", []ecc.EnterpriseContractPolicy{*migrated})
+	for _, field := range []string{Configuration, Collections, ImageRef} {
+		if got := testutil.ToFloat64(policiesUsing.WithLabelValues("acme", field)); got != 0 {
+			t.Errorf("expected no policies using %s, got %v", field, got)
+		}
+	}
+}
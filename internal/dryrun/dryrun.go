@@ -0,0 +1,335 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dryrun serves the effective configuration of policies over HTTP,
+// answering which rules apply when an image is verified at a given time
+// without evaluating the policy.
+//
+// GET /effective?policy=<namespace>/<name>&image=<reference>&time=<RFC 3339>
+// returns the effective configuration as JSON. The image and time are
+// optional, without an image only the volatile configuration not bound to an
+// image applies, the time defaults to the current time. The component and
+// application the image belongs to, and further attributes as repeated
+// attribute=<key>=<value> parameters, select the volatile configuration scoped
+// to them.
+//
+// The effective configuration is served behind kube-rbac-proxy, which passes
+// the authenticated user in the X-Remote-User and X-Remote-Groups headers. The
+// user must be allowed to get the policy, checked with a SubjectAccessReview.
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/effective"
+)
+
+// Path is the path the effective configuration is served at
+const Path = "/effective"
+
+const (
+	// UserHeader is the header kube-rbac-proxy passes the authenticated user
+	// in
+	UserHeader = "X-Remote-User"
+	// GroupsHeader is the header kube-rbac-proxy passes the groups of the
+	// authenticated user in, separated by groupsSeparator
+	GroupsHeader    = "X-Remote-Groups"
+	groupsSeparator = "|"
+)
+
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// Response is the effective configuration of a policy
+type Response struct {
+	// Policy is the namespace/name of the policy
+	Policy string `json:"policy"`
+	// Generation of the policy the configuration is computed from
+	Generation int64 `json:"generation"`
+	// Image the configuration is computed for
+	Image *effective.Image `json:"image,omitempty"`
+	// Attributes the configuration is computed for
+	Attributes effective.Attributes `json:"attributes,omitempty"`
+	// Time the configuration is computed for
+	Time time.Time `json:"time"`
+	effective.Config
+}
+
+// Handler serves the effective configuration of the policies
+type Handler struct {
+	// Client reads the policies and creates the SubjectAccessReviews of the
+	// requests
+	Client client.Client
+	// now returns the current time, time.Now when nil
+	now func() time.Time
+}
+
+// ServeHTTP serves the effective configuration of the requested policy
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, errors.New(http.StatusText(http.StatusMethodNotAllowed)))
+		return
+	}
+
+	query := r.URL.Query()
+	namespace, name, err := parsePolicy(query.Get("policy"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := h.authorize(r, namespace, name); err != nil {
+		var forbidden forbiddenError
+		if errors.As(err, &forbidden) {
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		log.FromContext(r.Context()).Error(err, "unable to authorize the request", "policy", namespace+"/"+name)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	response := Response{Policy: namespace + "/" + name}
+
+	image := effective.Image{}
+	if ref := query.Get("image"); ref != "" {
+		if image, err = effective.ParseImage(ref); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		response.Image = &image
+	}
+
+	if response.Attributes, err = parseAttributes(query); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	response.Time = time.Now()
+	if h.now != nil {
+		response.Time = h.now()
+	}
+	if at := query.Get("time"); at != "" {
+		if response.Time, err = time.Parse(time.RFC3339, at); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid time %q, expected RFC 3339: %w", at, err))
+			return
+		}
+	}
+	response.Time = response.Time.UTC()
+
+	config, generation, err := h.evaluate(r.Context(), namespace, name, image, response.Attributes, response.Time)
+	if apierrors.IsNotFound(err) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("policy %s not found", response.Policy))
+		return
+	}
+	if err != nil {
+		log.FromContext(r.Context()).Error(err, "unable to compute the effective configuration", "policy", response.Policy)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	response.Config = config
+	response.Generation = generation
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.FromContext(r.Context()).Error(err, "unable to write the response")
+	}
+}
+
+// forbiddenError is the error of requests the user is not allowed to make
+type forbiddenError string
+
+func (e forbiddenError) Error() string {
+	return string(e)
+}
+
+// authorize checks that the user of the request, as passed by kube-rbac-proxy,
+// is allowed to get the policy: the effective configuration discloses the
+// spec of the policy, which is only readable in namespaces the user has access
+// to
+func (h *Handler) authorize(r *http.Request, namespace, name string) error {
+	user := r.Header.Get(UserHeader)
+	if user == "" {
+		return forbiddenError("the user is not known, the request is expected to be made through kube-rbac-proxy")
+	}
+
+	var groups []string
+	if g := r.Header.Get(GroupsHeader); g != "" {
+		groups = strings.Split(g, groupsSeparator)
+	}
+
+	review := authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user,
+			Groups: groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "get",
+				Group:     ecc.GroupVersion.Group,
+				Resource:  "enterprisecontractpolicies",
+				Name:      name,
+			},
+		},
+	}
+	if err := h.Client.Create(r.Context(), &review); err != nil {
+		return fmt.Errorf("unable to review the access of %q: %w", user, err)
+	}
+
+	if !review.Status.Allowed {
+		return forbiddenError(fmt.Sprintf("%q is not allowed to get policy %s/%s", user, namespace, name))
+	}
+
+	return nil
+}
+
+// evaluate computes the effective configuration of the policy with the
+// RuleCollections of its namespace and the SourceRewrites, as the policy
+// reconciler does
+func (h *Handler) evaluate(ctx context.Context, namespace, name string, image effective.Image, attributes effective.Attributes, at time.Time) (effective.Config, int64, error) {
+	policy := ecc.EnterpriseContractPolicy{}
+	if err := h.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &policy); err != nil {
+		return effective.Config{}, 0, err
+	}
+
+	collections := ecc.RuleCollectionList{}
+	if err := h.Client.List(ctx, &collections, client.InNamespace(namespace)); err != nil {
+		return effective.Config{}, 0, fmt.Errorf("unable to list rule collections: %w", err)
+	}
+
+	rewrites := ecc.SourceRewriteList{}
+	if err := h.Client.List(ctx, &rewrites); err != nil {
+		return effective.Config{}, 0, fmt.Errorf("unable to list source rewrites: %w", err)
+	}
+
+	config := effective.Evaluate(policy.Spec, effective.CollectionsFrom(collections.Items), effective.RewritesFrom(rewrites.Items), image, attributes, at)
+
+	return config, policy.Generation, nil
+}
+
+// parsePolicy returns the namespace and name of the policy referred to as
+// namespace/name or k8s://namespace/name
+func parsePolicy(ref string) (string, string, error) {
+	if ref == "" {
+		return "", "", errors.New("the policy parameter is required")
+	}
+
+	if !ecc.IsKubernetesURL(ref) {
+		ref = ecc.KubernetesURLPrefix + ref
+	}
+
+	return ecc.ParseKubernetesURL(ref)
+}
+
+// parseAttributes returns the attributes given by the component and
+// application parameters and the attribute=<key>=<value> parameters
+func parseAttributes(query url.Values) (effective.Attributes, error) {
+	var attributes effective.Attributes
+	set := func(key, value string) {
+		if attributes == nil {
+			attributes = effective.Attributes{}
+		}
+		attributes[key] = value
+	}
+
+	for _, a := range query["attribute"] {
+		key, value, ok := strings.Cut(a, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid attribute %q, expected <key>=<value>", a)
+		}
+		set(key, value)
+	}
+
+	for _, key := range []string{ecc.ComponentAttribute, ecc.ApplicationAttribute} {
+		if query.Has(key) {
+			set(key, query.Get(key))
+		}
+	}
+
+	return attributes, nil
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// Server serves the effective configuration over HTTP, run by the manager
+type Server struct {
+	Client client.Client
+	// Addr is the address to listen on
+	Addr string
+}
+
+var _ manager.Runnable = &Server{}
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// Start serves the effective configuration until the context is done
+func (s *Server) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("dry-run")
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, &Handler{Client: s.Client})
+
+	srv := http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	ln, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %w", s.Addr, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "unable to shut down the dry-run server")
+		}
+	}()
+
+	logger.Info("serving the effective configuration", "address", ln.Addr().String(), "path", Path)
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// NeedLeaderElection is false as the effective configuration is served by
+// every replica
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/effective"
+)
+
+// reviewAccess allows the acme-developers group to get the policies of the
+// acme namespace
+func reviewAccess(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+	review, ok := obj.(*authorizationv1.SubjectAccessReview)
+	if !ok {
+		return c.Create(ctx, obj, opts...)
+	}
+
+	attributes := review.Spec.ResourceAttributes
+	review.Status.Allowed = slices.Contains(review.Spec.Groups, "acme-developers") &&
+		attributes.Namespace == "acme" && attributes.Verb == "get" &&
+		attributes.Group == ecc.GroupVersion.Group && attributes.Resource == "enterprisecontractpolicies"
+
+	return nil
+}
+
+const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestEffective(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	policy := ecctesting.NewPolicySpec().
+		WithPublicKey("k8s://keys/signing-key").
+		WithSources(ecctesting.NewSource("release").
+			WithPolicy("oci::quay.io/acme/policy:latest").
+			WithInclude("@acme").
+			WithVolatileExclude(
+				ecctesting.NewVolatileCriteria("cve").EffectiveUntil(now.Add(24*time.Hour)).ForImageDigest(digest),
+				ecctesting.NewVolatileCriteria("tasks").EffectiveUntil(now.Add(time.Hour)),
+				ecctesting.NewVolatileCriteria("sbom").ForComponent("api").WithAttribute("team", "payments"),
+			)).
+		Policy("acme", "policy")
+	policy.Generation = 4
+
+	h := Handler{
+		Client: ecctesting.NewFakeClientBuilder().
+			WithObjects(
+				policy,
+				ecctesting.NewRuleCollection("acme", "acme", "a", "b"),
+				ecctesting.NewSourceRewrite("mirror", ecc.PrefixRewrite{Source: "oci::quay.io/", Mirror: "oci::registry.internal/"}),
+			).
+			WithInterceptorFuncs(interceptor.Funcs{Create: reviewAccess}).
+			Build(),
+		now: func() time.Time { return now },
+	}
+
+	get := func(t *testing.T, query url.Values) (*httptest.ResponseRecorder, Response) {
+		t.Helper()
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, Path+"?"+query.Encode(), nil)
+		req.Header.Set(UserHeader, "alice")
+		req.Header.Set(GroupsHeader, "system:authenticated|acme-developers")
+		h.ServeHTTP(rec, req)
+
+		response := Response{}
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("unable to decode the response %q: %v", rec.Body.String(), err)
+			}
+		}
+
+		return rec, response
+	}
+
+	t.Run("image by digest", func(t *testing.T) {
+		rec, got := get(t, url.Values{"policy": {"acme/policy"}, "image": {"quay.io/acme/app@" + digest}})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected OK, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		if got.Policy != "acme/policy" || got.Generation != 4 || !got.Time.Equal(now) || got.PublicKey != "k8s://keys/signing-key" {
+			t.Errorf("unexpected response %v", got)
+		}
+		if got.Image == nil || got.Image.Url != "quay.io/acme/app" || got.Image.Digest != digest {
+			t.Errorf("expected the parsed image, got %v", got.Image)
+		}
+		if len(got.Sources) != 1 {
+			t.Fatalf("expected one source, got %v", got.Sources)
+		}
+		source := got.Sources[0]
+		if !reflect.DeepEqual([]string{"oci::registry.internal/acme/policy:latest"}, source.Policy) {
+			t.Errorf("expected the rewritten policy URL, got %v", source.Policy)
+		}
+		if !reflect.DeepEqual([]string{"a", "b"}, source.Include) {
+			t.Errorf("expected the expanded collection, got %v", source.Include)
+		}
+		if !reflect.DeepEqual([]string{"cve", "tasks"}, source.Exclude) {
+			t.Errorf("expected both volatile excludes, got %v", source.Exclude)
+		}
+	})
+
+	t.Run("attributes", func(t *testing.T) {
+		rec, got := get(t, url.Values{"policy": {"acme/policy"}, "component": {"api"}, "attribute": {"team=payments", "region=eu"}})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected OK, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		expected := effective.Attributes{ecc.ComponentAttribute: "api", "team": "payments", "region": "eu"}
+		if !reflect.DeepEqual(expected, got.Attributes) {
+			t.Errorf("expected attributes %v, got %v", expected, got.Attributes)
+		}
+		if exclude := got.Sources[0].Exclude; !reflect.DeepEqual([]string{"tasks", "sbom"}, exclude) {
+			t.Errorf("expected the excludes scoped to the component, got %v", exclude)
+		}
+	})
+
+	t.Run("later without image", func(t *testing.T) {
+		rec, got := get(t, url.Values{"policy": {"k8s://acme/policy"}, "time": {"2025-06-01T14:00:00+01:00"}})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected OK, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if !got.Time.Equal(now.Add(time.Hour)) || got.Image != nil {
+			t.Errorf("expected the given time without an image, got %v %v", got.Time, got.Image)
+		}
+		if exclude := got.Sources[0].Exclude; len(exclude) != 0 {
+			t.Errorf("expected no volatile excludes, got %v", exclude)
+		}
+	})
+
+	errors := []struct {
+		name  string
+		query url.Values
+		code  int
+		err   string
+	}{
+		{name: "no policy", query: url.Values{}, code: http.StatusBadRequest, err: "the policy parameter is required"},
+		{name: "invalid policy", query: url.Values{"policy": {"policy"}}, code: http.StatusBadRequest, err: "is not of the form"},
+		{name: "invalid image", query: url.Values{"policy": {"acme/policy"}, "image": {"quay.io/Acme"}}, code: http.StatusBadRequest, err: "unable to parse image reference"},
+		{name: "invalid time", query: url.Values{"policy": {"acme/policy"}, "time": {"yesterday"}}, code: http.StatusBadRequest, err: "expected RFC 3339"},
+		{name: "invalid attribute", query: url.Values{"policy": {"acme/policy"}, "attribute": {"team"}}, code: http.StatusBadRequest, err: "invalid attribute"},
+		{name: "missing policy", query: url.Values{"policy": {"acme/other"}}, code: http.StatusNotFound, err: "policy acme/other not found"},
+		{name: "forbidden namespace", query: url.Values{"policy": {"other/policy"}}, code: http.StatusForbidden, err: `\"alice\" is not allowed to get policy other/policy`},
+	}
+
+	for _, c := range errors {
+		t.Run(c.name, func(t *testing.T) {
+			rec, _ := get(t, c.query)
+			if rec.Code != c.code {
+				t.Errorf("expected status %d, got %d", c.code, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), c.err) {
+				t.Errorf("expected error containing %q, got %s", c.err, rec.Body.String())
+			}
+		})
+	}
+
+	t.Run("unknown user", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path+"?policy=acme/policy", nil))
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "the user is not known") {
+			t.Errorf("expected the request without a user to be forbidden, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("method", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path, nil))
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("expected status %d, got %d", http.StatusMethodNotAllowed, rec.Code)
+		}
+	})
+}
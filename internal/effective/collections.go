@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package effective computes the effective configuration of a policy, i.e.
+// the configuration that is used when the policy is evaluated, after all
+// references in it are resolved.
+package effective
+
+import (
+	"sort"
+	"strings"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// Collections maps the name of a collection, without the "@" prefix, to the
+// rules in it
+type Collections map[string][]string
+
+// CollectionsFrom returns the collections defined by the given RuleCollection
+// resources
+func CollectionsFrom(collections []ecc.RuleCollection) Collections {
+	c := make(Collections, len(collections))
+	for _, rc := range collections {
+		c[rc.Name] = rc.Spec.Rules
+	}
+
+	return c
+}
+
+const (
+	// maxCollectionDepth limits how deeply collections can be nested
+	maxCollectionDepth = 16
+	// maxExpandedRules is the number of values past which no more collections
+	// are expanded
+	maxExpandedRules = 5000
+)
+
+// Expand replaces the references to defined collections in values with the
+// rules in those collections, recursively. References to collections that are
+// not defined are kept as is and returned, sorted, as undefined. So are the
+// references to collections nested more than maxCollectionDepth deep, and
+// those met once the values are expanded to maxExpandedRules. The order of
+// values is preserved and duplicates are removed.
+func (c Collections) Expand(values []string) (expanded []string, undefined []string) {
+	seen := map[string]bool{}
+	missing := map[string]bool{}
+	// each collection is expanded once: expanding it again, when nested in
+	// several collections or within itself, adds no rules not already seen
+	done := map[string]bool{}
+
+	keep := func(v string) {
+		if !seen[v] {
+			seen[v] = true
+			expanded = append(expanded, v)
+		}
+	}
+
+	var expand func(values []string, depth int)
+	expand = func(values []string, depth int) {
+		for _, v := range values {
+			name, isRef := strings.CutPrefix(v, ecc.CollectionPrefix)
+			rules, ok := c[name]
+			switch {
+			case !isRef:
+				keep(v)
+			case ok && done[name]:
+				continue
+			case ok && depth < maxCollectionDepth && len(expanded) < maxExpandedRules:
+				done[name] = true
+				expand(rules, depth+1)
+			default:
+				missing[name] = true
+				keep(v)
+			}
+		}
+	}
+	expand(values, 0)
+
+	for name := range missing {
+		undefined = append(undefined, name)
+	}
+	sort.Strings(undefined)
+
+	return
+}
+
+// SourceConfig returns the given source configuration with the references to
+// collections in includes and excludes expanded, along with the names of the
+// referenced collections that are not defined
+func (c Collections) SourceConfig(config *ecc.SourceConfig) (*ecc.SourceConfig, []string) {
+	if config == nil {
+		return nil, nil
+	}
+
+	include, undefinedIncludes := c.Expand(config.Include)
+	exclude, undefinedExcludes := c.Expand(config.Exclude)
+
+	return &ecc.SourceConfig{
+		Include: include,
+		Exclude: exclude,
+	}, union(undefinedIncludes, undefinedExcludes)
+}
+
+func union(a, b []string) []string {
+	set := map[string]bool{}
+	for _, v := range a {
+		set[v] = true
+	}
+	for _, v := range b {
+		set[v] = true
+	}
+
+	if len(set) == 0 {
+		return nil
+	}
+
+	u := make([]string, 0, len(set))
+	for v := range set {
+		u = append(u, v)
+	}
+	sort.Strings(u)
+
+	return u
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effective
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+)
+
+func TestExpand(t *testing.T) {
+	collections := CollectionsFrom([]ecc.RuleCollection{
+		*ecctesting.NewRuleCollection("acme", "acme", "a", "b"),
+		*ecctesting.NewRuleCollection("acme", "nested", "@acme", "c", "@slsa3"),
+		*ecctesting.NewRuleCollection("acme", "cycle", "d", "@cycle", "@loop"),
+		*ecctesting.NewRuleCollection("acme", "loop", "@cycle", "e"),
+	})
+
+	cases := []struct {
+		name      string
+		values    []string
+		expanded  []string
+		undefined []string
+	}{
+		{name: "nil"},
+		{
+			name:     "no references",
+			values:   []string{"a", "x"},
+			expanded: []string{"a", "x"},
+		},
+		{
+			name:     "defined collection",
+			values:   []string{"x", "@acme", "y"},
+			expanded: []string{"x", "a", "b", "y"},
+		},
+		{
+			name:      "undefined collection",
+			values:    []string{"@slsa3", "x"},
+			expanded:  []string{"@slsa3", "x"},
+			undefined: []string{"slsa3"},
+		},
+		{
+			name:      "nested collections",
+			values:    []string{"@nested"},
+			expanded:  []string{"a", "b", "c", "@slsa3"},
+			undefined: []string{"slsa3"},
+		},
+		{
+			name:     "duplicates",
+			values:   []string{"a", "@acme", "@acme"},
+			expanded: []string{"a", "b"},
+		},
+		{
+			name:     "cycles",
+			values:   []string{"@cycle"},
+			expanded: []string{"d", "e"},
+		},
+		{
+			name:      "sorted undefined",
+			values:    []string{"@z", "@y", "@z"},
+			expanded:  []string{"@z", "@y"},
+			undefined: []string{"y", "z"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			expanded, undefined := collections.Expand(c.values)
+			if !reflect.DeepEqual(c.expanded, expanded) {
+				t.Errorf("expected expanded %v, got %v", c.expanded, expanded)
+			}
+			if !reflect.DeepEqual(c.undefined, undefined) {
+				t.Errorf("expected undefined %v, got %v", c.undefined, undefined)
+			}
+		})
+	}
+}
+
+func TestExpandDiamonds(t *testing.T) {
+	// each level nests ten collections all nesting the next level, there
+	// are 10^levels paths to the leaf
+	const levels = 7
+	collections := Collections{fmt.Sprintf("level%d", levels): {"leaf"}}
+	for i := 0; i < levels; i++ {
+		level := fmt.Sprintf("level%d", i)
+		for j := 0; j < 10; j++ {
+			name := fmt.Sprintf("%s-%d", level, j)
+			collections[level] = append(collections[level], "@"+name)
+			collections[name] = []string{fmt.Sprintf("@level%d", i+1), name}
+		}
+	}
+
+	// the leaf is reached first, then the rules of the deepest level
+	expected := []string{"leaf"}
+	for i := levels - 1; i >= 0; i-- {
+		for j := 0; j < 10; j++ {
+			expected = append(expected, fmt.Sprintf("level%d-%d", i, j))
+		}
+	}
+
+	expanded, undefined := collections.Expand([]string{"@level0"})
+	if !reflect.DeepEqual(expected, expanded) {
+		t.Errorf("expected expanded %v, got %v", expected, expanded)
+	}
+	if undefined != nil {
+		t.Errorf("expected no undefined collections, got %v", undefined)
+	}
+}
+
+func TestExpandLimits(t *testing.T) {
+	collections := Collections{}
+	for i := 0; i < 2*maxCollectionDepth; i++ {
+		collections[fmt.Sprintf("nested%d", i)] = []string{fmt.Sprintf("@nested%d", i+1)}
+	}
+
+	tooDeep := fmt.Sprintf("nested%d", maxCollectionDepth)
+	expanded, undefined := collections.Expand([]string{"@nested0"})
+	if expected := []string{"@" + tooDeep}; !reflect.DeepEqual(expected, expanded) {
+		t.Errorf("expected expanded %v, got %v", expected, expanded)
+	}
+	if expected := []string{tooDeep}; !reflect.DeepEqual(expected, undefined) {
+		t.Errorf("expected undefined %v, got %v", expected, undefined)
+	}
+
+	for i := 0; i < maxExpandedRules; i++ {
+		collections["large"] = append(collections["large"], fmt.Sprintf("rule%d", i))
+	}
+	collections["small"] = []string{"small"}
+
+	expanded, undefined = collections.Expand([]string{"@large", "@small", "last"})
+	if len(expanded) != maxExpandedRules+2 || expanded[maxExpandedRules] != "@small" || expanded[maxExpandedRules+1] != "last" {
+		t.Errorf("expected the rules of the large collection followed by @small and last, got %d values ending in %v", len(expanded), expanded[len(expanded)-2:])
+	}
+	if expected := []string{"small"}; !reflect.DeepEqual(expected, undefined) {
+		t.Errorf("expected undefined %v, got %v", expected, undefined)
+	}
+}
+
+func TestSourceConfig(t *testing.T) {
+	collections := Collections{"acme": {"a", "b"}}
+
+	config, undefined := collections.SourceConfig(nil)
+	if config != nil || undefined != nil {
+		t.Errorf("expected nil config, got %v, %v", config, undefined)
+	}
+
+	config, undefined = collections.SourceConfig(&ecc.SourceConfig{
+		Include: []string{"@acme", "@slsa3"},
+		Exclude: []string{"@slsa3", "@redhat", "c"},
+	})
+
+	expected := &ecc.SourceConfig{
+		Include: []string{"a", "b", "@slsa3"},
+		Exclude: []string{"@slsa3", "@redhat", "c"},
+	}
+	if !reflect.DeepEqual(expected, config) {
+		t.Errorf("expected %v, got %v", expected, config)
+	}
+
+	if expected := []string{"redhat", "slsa3"}; !reflect.DeepEqual(expected, undefined) {
+		t.Errorf("expected undefined %v, got %v", expected, undefined)
+	}
+}
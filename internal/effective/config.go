@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effective
+
+import (
+	"time"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// Config is the effective configuration of a policy for an image at a time
+type Config struct {
+	// PublicKey as given in the policy, PEM encoded or a reference to a
+	// Secret
+	PublicKey string `json:"publicKey,omitempty"`
+	// Identity of keyless signatures
+	Identity *ecc.Identity `json:"identity,omitempty"`
+	// RekorUrl is the URL of the Rekor instance, rewritten by SourceRewrites
+	RekorUrl string `json:"rekorUrl,omitempty"`
+	// Sources are the effective configurations of the policy sources
+	Sources []Source `json:"sources"`
+}
+
+// Source is the effective configuration of a policy source
+type Source struct {
+	Name string `json:"name,omitempty"`
+	// Policy and Data are the source URLs, rewritten by SourceRewrites
+	Policy []string `json:"policy,omitempty"`
+	Data   []string `json:"data,omitempty"`
+	// Include and Exclude are the rules included and excluded, including
+	// the applying volatile configuration, with the collections defined by
+	// RuleCollections expanded
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+	// VolatileInclude and VolatileExclude are the volatile criteria applying
+	VolatileInclude []ecc.VolatileCriteria `json:"volatileInclude,omitempty"`
+	VolatileExclude []ecc.VolatileCriteria `json:"volatileExclude,omitempty"`
+	// UndefinedCollections are the collections referred to that are not
+	// defined by RuleCollections, kept as is in the includes and excludes
+	UndefinedCollections []string `json:"undefinedCollections,omitempty"`
+	// BundleSignature and CommitSignature are the keys and identities the
+	// policy rules and data are verified with
+	BundleSignature *ecc.BundleSignature `json:"bundleSignature,omitempty"`
+	CommitSignature *ecc.CommitSignature `json:"commitSignature,omitempty"`
+}
+
+// Evaluate computes the effective configuration of the policy for the image
+// with the attributes at the given time. Sources without a configuration fall back to the
+// deprecated configuration of the policy.
+func Evaluate(spec ecc.EnterpriseContractPolicySpec, defined Collections, rewrites Rewrites, image Image, attributes Attributes, at time.Time) Config {
+	config := Config{
+		PublicKey: spec.PublicKey,
+		Identity:  spec.Identity,
+		Sources:   make([]Source, 0, len(spec.Sources)),
+	}
+	config.RekorUrl, _ = rewrites.Rewrite(spec.RekorUrl)
+
+	for _, s := range spec.Sources {
+		source := Source{
+			Name:            s.Name,
+			Policy:          s.Policy,
+			Data:            s.Data,
+			BundleSignature: s.BundleSignature,
+			CommitSignature: s.CommitSignature,
+		}
+		if policy := rewrites.RewriteAll(s.Policy); policy != nil {
+			source.Policy = policy
+		}
+		if data := rewrites.RewriteAll(s.Data); data != nil {
+			source.Data = data
+		}
+
+		var include, exclude []string
+		switch {
+		case s.Config != nil:
+			include, exclude = s.Config.Include, s.Config.Exclude
+		case spec.Configuration != nil:
+			include, exclude = spec.Configuration.Include, spec.Configuration.Exclude
+			for _, c := range spec.Configuration.Collections {
+				include = append(include, ecc.CollectionPrefix+c)
+			}
+		}
+
+		if s.VolatileConfig != nil {
+			var values []string
+			source.VolatileInclude, values = applying(s.VolatileConfig.Include, image, attributes, at)
+			include = append(append([]string(nil), include...), values...)
+			source.VolatileExclude, values = applying(s.VolatileConfig.Exclude, image, attributes, at)
+			exclude = append(append([]string(nil), exclude...), values...)
+		}
+
+		var undefinedIncludes, undefinedExcludes []string
+		source.Include, undefinedIncludes = defined.Expand(include)
+		source.Exclude, undefinedExcludes = defined.Expand(exclude)
+		source.UndefinedCollections = union(undefinedIncludes, undefinedExcludes)
+
+		config.Sources = append(config.Sources, source)
+	}
+
+	return config
+}
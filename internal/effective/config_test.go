@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effective
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+)
+
+const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestParseImage(t *testing.T) {
+	cases := []struct {
+		ref      string
+		expected Image
+		err      bool
+	}{
+		{ref: "quay.io/acme/app:v1", expected: Image{Url: "quay.io/acme/app"}},
+		{ref: "quay.io/acme/app@" + digest, expected: Image{Url: "quay.io/acme/app", Digest: digest}},
+		{ref: "registry.local:5000/acme/app", expected: Image{Url: "registry.local:5000/acme/app"}},
+		{ref: "nginx:1.27", expected: Image{Url: "docker.io/library/nginx"}},
+		{ref: "quay.io/Acme/app", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.ref, func(t *testing.T) {
+			got, err := ParseImage(c.ref)
+			if c.err != (err != nil) {
+				t.Fatalf("expected error %v, got %v", c.err, err)
+			}
+			if got != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestApplies(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	image := Image{Url: "quay.io/acme/app", Digest: digest}
+
+	cases := []struct {
+		name       string
+		criteria   ecc.VolatileCriteria
+		image      Image
+		attributes Attributes
+		expected   bool
+	}{
+		{name: "unconstrained", criteria: ecc.VolatileCriteria{Value: "a"}, image: image, expected: true},
+		{name: "effective", criteria: ecc.VolatileCriteria{EffectiveOn: "2025-06-01T12:00:00Z", EffectiveUntil: "2025-06-02T00:00:00Z"}, image: image, expected: true},
+		{name: "not yet effective", criteria: ecc.VolatileCriteria{EffectiveOn: "2025-06-02T00:00:00Z"}, image: image},
+		{name: "expired", criteria: ecc.VolatileCriteria{EffectiveUntil: "2025-06-01T12:00:00Z"}, image: image},
+		{name: "invalid time", criteria: ecc.VolatileCriteria{EffectiveOn: "June"}, image: image},
+		{name: "digest", criteria: ecc.VolatileCriteria{ImageDigest: digest}, image: image, expected: true},
+		{name: "other digest", criteria: ecc.VolatileCriteria{ImageDigest: "sha256:ff"}, image: image},
+		{name: "digest of image by tag", criteria: ecc.VolatileCriteria{ImageDigest: digest}, image: Image{Url: "quay.io/acme/app"}},
+		{name: "deprecated image ref", criteria: ecc.VolatileCriteria{ImageRef: digest}, image: image, expected: true},
+		{name: "url", criteria: ecc.VolatileCriteria{ImageUrl: "quay.io/acme/app"}, image: image, expected: true},
+		{name: "other url", criteria: ecc.VolatileCriteria{ImageUrl: "quay.io/acme/other"}, image: image},
+		{name: "url glob", criteria: ecc.VolatileCriteria{ImageUrl: "quay.io/acme/**"}, image: image, expected: true},
+		{name: "url without image", criteria: ecc.VolatileCriteria{ImageUrl: "quay.io/acme/**"}},
+		{name: "component", criteria: ecc.VolatileCriteria{Component: "api"}, image: image, attributes: Attributes{ecc.ComponentAttribute: "api"}, expected: true},
+		{name: "other component", criteria: ecc.VolatileCriteria{Component: "api"}, image: image, attributes: Attributes{ecc.ComponentAttribute: "web"}},
+		{name: "component not supplied", criteria: ecc.VolatileCriteria{Component: "api"}, image: image},
+		{name: "url and attribute", criteria: ecc.VolatileCriteria{ImageUrl: "quay.io/acme/**", Attributes: map[string]string{"team": "payments"}}, image: image, attributes: Attributes{"team": "payments"}, expected: true},
+		{name: "url and digest", criteria: ecc.VolatileCriteria{ImageUrl: "quay.io/acme/other", ImageDigest: digest}, image: image},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Applies(c.criteria, c.image, c.attributes, now); got != c.expected {
+				t.Errorf("expected %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	image := Image{Url: "quay.io/acme/app", Digest: digest}
+
+	expiring := ecctesting.NewVolatileCriteria("cve").EffectiveUntil(now.Add(time.Hour)).ForImageUrl("quay.io/acme/app")
+	expired := ecctesting.NewVolatileCriteria("tasks").EffectiveUntil(now.Add(-time.Hour))
+	other := ecctesting.NewVolatileCriteria("test").ForImageUrl("quay.io/acme/other")
+	upcoming := ecctesting.NewVolatileCriteria("@next").EffectiveOn(now.Add(-time.Minute))
+	component := ecctesting.NewVolatileCriteria("sbom").ForComponent("api").ForApplication("shop")
+	otherComponent := ecctesting.NewVolatileCriteria("test").ForComponent("web")
+
+	spec := ecctesting.NewPolicySpec().
+		WithPublicKey("k8s://keys/signing-key").
+		WithConfiguration(ecc.EnterpriseContractPolicyConfiguration{Include: []string{"a"}, Collections: []string{"minimal"}}).
+		WithSources(
+			ecctesting.NewSource("release").
+				WithPolicy("oci::quay.io/acme/policy:latest").
+				WithInclude("@acme").
+				WithExclude("x").
+				WithVolatileInclude(upcoming).
+				WithVolatileExclude(expiring, expired, other, component, otherComponent),
+			ecctesting.NewSource("legacy").
+				WithPolicy("git::https://github.com/acme/policy")).
+		Build()
+	spec.RekorUrl = "https://rekor.sigstore.dev"
+
+	defined := Collections{"acme": {"b", "c"}, "next": {"d"}}
+	rewrites := RewritesFrom([]ecc.SourceRewrite{*ecctesting.NewSourceRewrite("mirror",
+		ecc.PrefixRewrite{Source: "oci::quay.io/", Mirror: "oci::registry.internal/"},
+		ecc.PrefixRewrite{Source: "https://rekor.sigstore.dev", Mirror: "https://rekor.internal"},
+	)})
+
+	got := Evaluate(spec, defined, rewrites, image, Attributes{ecc.ComponentAttribute: "api", ecc.ApplicationAttribute: "shop"}, now)
+
+	expected := Config{
+		PublicKey: "k8s://keys/signing-key",
+		RekorUrl:  "https://rekor.internal",
+		Sources: []Source{
+			{
+				Name:            "release",
+				Policy:          []string{"oci::registry.internal/acme/policy:latest"},
+				Include:         []string{"b", "c", "d"},
+				Exclude:         []string{"x", "cve", "sbom"},
+				VolatileInclude: []ecc.VolatileCriteria{upcoming.Build()},
+				VolatileExclude: []ecc.VolatileCriteria{expiring.Build(), component.Build()},
+			},
+			{
+				Name:                 "legacy",
+				Policy:               []string{"git::https://github.com/acme/policy"},
+				Include:              []string{"a", "@minimal"},
+				UndefinedCollections: []string{"minimal"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %#v, got %#v", expected, got)
+	}
+}
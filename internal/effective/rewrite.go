@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effective
+
+import (
+	"sort"
+	"strings"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// Rewrites are the prefix rewrite rules of all SourceRewrite resources,
+// ordered by precedence
+type Rewrites []ecc.PrefixRewrite
+
+// RewritesFrom returns the rewrite rules of the given SourceRewrite
+// resources. Rules with longer source prefixes take precedence, for the same
+// source prefix the rule of the SourceRewrite first by name does.
+func RewritesFrom(rewrites []ecc.SourceRewrite) Rewrites {
+	sorted := append([]ecc.SourceRewrite(nil), rewrites...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var rules Rewrites
+	for _, r := range sorted {
+		rules = append(rules, r.Spec.Rewrites...)
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool { return len(rules[i].Source) > len(rules[j].Source) })
+
+	return rules
+}
+
+// Rewrite returns the URL with the source prefix of the rule taking
+// precedence replaced by its mirror prefix, and whether any rule applied
+func (r Rewrites) Rewrite(url string) (string, bool) {
+	for _, rule := range r {
+		if rest, ok := strings.CutPrefix(url, rule.Source); ok {
+			return rule.Mirror + rest, true
+		}
+	}
+
+	return url, false
+}
+
+// RewriteAll rewrites each of the URLs, returning nil when no rule applied to
+// any of them
+func (r Rewrites) RewriteAll(urls []string) []string {
+	rewritten := make([]string, 0, len(urls))
+	changed := false
+	for _, url := range urls {
+		u, ok := r.Rewrite(url)
+		rewritten = append(rewritten, u)
+		changed = changed || ok
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return rewritten
+}
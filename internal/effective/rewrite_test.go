@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effective
+
+import (
+	"reflect"
+	"testing"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+)
+
+func TestRewrite(t *testing.T) {
+	rewrites := RewritesFrom([]ecc.SourceRewrite{
+		*ecctesting.NewSourceRewrite("b",
+			ecc.PrefixRewrite{Source: "oci::quay.io/", Mirror: "oci::registry.internal/quay/"},
+			ecc.PrefixRewrite{Source: "https://rekor.sigstore.dev", Mirror: "https://rekor.internal"},
+		),
+		*ecctesting.NewSourceRewrite("a",
+			ecc.PrefixRewrite{Source: "oci::quay.io/enterprise-contract/", Mirror: "oci::registry.internal/ec/"},
+			ecc.PrefixRewrite{Source: "oci::quay.io/", Mirror: "oci::registry.internal/a/"},
+		),
+	})
+
+	cases := []struct {
+		url       string
+		expected  string
+		rewritten bool
+	}{
+		{url: "oci::quay.io/enterprise-contract/ec-release-policy:latest", expected: "oci::registry.internal/ec/ec-release-policy:latest", rewritten: true},
+		{url: "oci::quay.io/acme/policy:latest", expected: "oci::registry.internal/a/acme/policy:latest", rewritten: true},
+		{url: "https://rekor.sigstore.dev", expected: "https://rekor.internal", rewritten: true},
+		{url: "quay.io/acme/policy:latest", expected: "quay.io/acme/policy:latest"},
+		{url: "github.com/acme/policy//data", expected: "github.com/acme/policy//data"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.url, func(t *testing.T) {
+			got, rewritten := rewrites.Rewrite(c.url)
+			if got != c.expected || rewritten != c.rewritten {
+				t.Errorf("expected %q (%v), got %q (%v)", c.expected, c.rewritten, got, rewritten)
+			}
+		})
+	}
+}
+
+func TestRewriteAll(t *testing.T) {
+	rewrites := Rewrites{{Source: "git::https://github.com/", Mirror: "git::https://git.internal/"}}
+
+	if got := rewrites.RewriteAll([]string{"oci::quay.io/acme/data:latest"}); got != nil {
+		t.Errorf("expected nil when nothing is rewritten, got %v", got)
+	}
+
+	got := rewrites.RewriteAll([]string{"oci::quay.io/acme/data:latest", "git::https://github.com/acme/data"})
+	if expected := []string{"oci::quay.io/acme/data:latest", "git::https://git.internal/acme/data"}; !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := Rewrites(nil).RewriteAll([]string{"oci::quay.io/acme/data:latest"}); got != nil {
+		t.Errorf("expected nil without rewrites, got %v", got)
+	}
+}
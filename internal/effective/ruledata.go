@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effective
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// RuleData is rule data from a single origin
+type RuleData struct {
+	// Values of the rule data keys
+	Values map[string]any
+	// Sensitive marks rule data that must not be disclosed, i.e. rule data
+	// from Secrets
+	Sensitive bool
+	// Revision identifies the revision of sensitive rule data, it is hashed
+	// in place of its values
+	Revision string
+}
+
+// ParseRuleData returns the rule data held in the data of a ConfigMap or a
+// Secret. When key is given the value of the key must be a JSON or YAML
+// object holding the rule data, otherwise each key is a rule data key with
+// the value parsed as JSON or YAML.
+func ParseRuleData(data map[string][]byte, key string) (map[string]any, error) {
+	if key != "" {
+		value, ok := data[key]
+		if !ok {
+			return nil, fmt.Errorf("key %q not found", key)
+		}
+
+		values := map[string]any{}
+		if err := yaml.Unmarshal(value, &values); err != nil {
+			return nil, fmt.Errorf("unable to parse key %q as a JSON or YAML object: %w", key, err)
+		}
+
+		return values, nil
+	}
+
+	values := make(map[string]any, len(data))
+	for k, v := range data {
+		var value any
+		if err := yaml.Unmarshal(v, &value); err != nil {
+			return nil, fmt.Errorf("unable to parse key %q as JSON or YAML: %w", k, err)
+		}
+		values[k] = value
+	}
+
+	return values, nil
+}
+
+// MergeRuleData merges the rule data in order, the values of later rule data
+// replacing the values of the same keys of earlier rule data. Returns the
+// merged rule data and the keys of the merged rule data with values from
+// sensitive rule data, sorted.
+func MergeRuleData(ruleData ...RuleData) (map[string]any, []string) {
+	merged := map[string]any{}
+	sensitive := map[string]bool{}
+	for _, rd := range ruleData {
+		for k, v := range rd.Values {
+			merged[k] = v
+			sensitive[k] = rd.Sensitive
+		}
+	}
+
+	var keys []string
+	for k, s := range sensitive {
+		if s {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	return merged, keys
+}
+
+// RuleDataJSON marshals the rule data, leaving out the given keys
+func RuleDataJSON(ruleData map[string]any, without ...string) (*extv1.JSON, error) {
+	m := make(map[string]any, len(ruleData))
+	for k, v := range ruleData {
+		m[k] = v
+	}
+	for _, k := range without {
+		delete(m, k)
+	}
+
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal the rule data: %w", err)
+	}
+
+	return &extv1.JSON{Raw: raw}, nil
+}
+
+// HashRuleData returns the SHA-256 hash of the merged rule data, the same
+// rule data always hashes the same as the keys are marshalled in order. The
+// values of sensitive rule data are not hashed, guessed values could be
+// confirmed against the hash, its revision is hashed in their place.
+func HashRuleData(ruleData ...RuleData) (string, error) {
+	merged, sensitive := MergeRuleData(ruleData...)
+	for _, k := range sensitive {
+		delete(merged, k)
+	}
+
+	var revisions []string
+	for _, rd := range ruleData {
+		if rd.Sensitive {
+			revisions = append(revisions, rd.Revision)
+		}
+	}
+
+	var hashed any = merged
+	if len(revisions) > 0 {
+		hashed = map[string]any{"ruleData": merged, "revisions": revisions}
+	}
+
+	raw, err := json.Marshal(hashed)
+	if err != nil {
+		return "", fmt.Errorf("unable to marshal the rule data: %w", err)
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(raw)), nil
+}
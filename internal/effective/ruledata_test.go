@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effective
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRuleData(t *testing.T) {
+	data := map[string][]byte{
+		"rule_data.yaml":            []byte("max_age_days: 30\nallowed_registry_prefixes: [registry.io/]"),
+		"max_age_days":              []byte("7"),
+		"allowed_registry_prefixes": []byte(`["quay.io/"]`),
+		"broken":                    []byte("["),
+	}
+
+	cases := []struct {
+		name     string
+		data     map[string][]byte
+		key      string
+		expected map[string]any
+		err      bool
+	}{
+		{
+			name:     "key",
+			data:     data,
+			key:      "rule_data.yaml",
+			expected: map[string]any{"max_age_days": float64(30), "allowed_registry_prefixes": []any{"registry.io/"}},
+		},
+		{
+			name: "all keys",
+			data: map[string][]byte{"max_age_days": data["max_age_days"], "allowed_registry_prefixes": data["allowed_registry_prefixes"]},
+			expected: map[string]any{
+				"max_age_days":              float64(7),
+				"allowed_registry_prefixes": []any{"quay.io/"},
+			},
+		},
+		{name: "missing key", data: data, key: "missing", err: true},
+		{name: "key not an object", data: data, key: "max_age_days", err: true},
+		{name: "unparsable value", data: data, err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			values, err := ParseRuleData(c.data, c.key)
+			if c.err {
+				if err == nil {
+					t.Errorf("expected an error, got %v", values)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(c.expected, values) {
+				t.Errorf("expected %v, got %v", c.expected, values)
+			}
+		})
+	}
+}
+
+func TestMergeRuleData(t *testing.T) {
+	merged, sensitive := MergeRuleData(
+		RuleData{Values: map[string]any{"a": 1, "b": 1}},
+		RuleData{Values: map[string]any{"b": 2, "c": 2, "d": 2}, Sensitive: true},
+		RuleData{Values: map[string]any{"c": 3}},
+	)
+
+	if expected := map[string]any{"a": 1, "b": 2, "c": 3, "d": 2}; !reflect.DeepEqual(expected, merged) {
+		t.Errorf("expected merged rule data %v, got %v", expected, merged)
+	}
+	if expected := []string{"b", "d"}; !reflect.DeepEqual(expected, sensitive) {
+		t.Errorf("expected sensitive keys %v, got %v", expected, sensitive)
+	}
+}
+
+func TestRuleDataJSON(t *testing.T) {
+	ruleData := map[string]any{"a": 1, "b": 2}
+
+	got, err := RuleDataJSON(ruleData, "b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := `{"a":1}`; string(got.Raw) != expected {
+		t.Errorf("expected %s, got %s", expected, got.Raw)
+	}
+	if len(ruleData) != 2 {
+		t.Errorf("expected the rule data to be left unchanged, got %v", ruleData)
+	}
+}
+
+func TestHashRuleData(t *testing.T) {
+	hash := func(ruleData ...RuleData) string {
+		t.Helper()
+		h, err := HashRuleData(ruleData...)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return h
+	}
+
+	a := hash(RuleData{Values: map[string]any{"a": 1, "b": []any{"x"}}})
+	if b := hash(RuleData{Values: map[string]any{"b": []any{"x"}, "a": 1}}); a != b {
+		t.Errorf("expected the same rule data to hash the same, got %s and %s", a, b)
+	}
+	if b := hash(RuleData{Values: map[string]any{"a": 2, "b": []any{"x"}}}); a == b {
+		t.Errorf("expected different rule data to hash differently, got %s", a)
+	}
+
+	secret := func(value, revision string) RuleData {
+		return RuleData{Values: map[string]any{"token": value}, Sensitive: true, Revision: revision}
+	}
+	s := hash(RuleData{Values: map[string]any{"a": 1}}, secret("s3cr3t", "1"))
+	if b := hash(RuleData{Values: map[string]any{"a": 1}}, secret("guessed", "1")); s != b {
+		t.Errorf("expected the values of sensitive rule data not to be hashed, got %s and %s", s, b)
+	}
+	if b := hash(RuleData{Values: map[string]any{"a": 1}}, secret("s3cr3t", "2")); s == b {
+		t.Errorf("expected the revision of sensitive rule data to be hashed, got %s", s)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package effective
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// Image is the image volatile configuration is evaluated for
+type Image struct {
+	// Url of the image without a tag or digest, e.g. quay.io/acme/app, as
+	// returned by ecc.ImageRepository
+	Url string `json:"url,omitempty"`
+	// Digest of the image, e.g. sha256:..., empty when the image is referred
+	// to by tag
+	Digest string `json:"digest,omitempty"`
+}
+
+// ParseImage parses the image reference, by tag or by digest
+func ParseImage(ref string) (Image, error) {
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		return Image{}, fmt.Errorf("unable to parse image reference %q: %w", ref, err)
+	}
+
+	image := Image{Url: ecc.ImageRepository(ref)}
+	if d, ok := r.(name.Digest); ok {
+		image.Digest = d.DigestStr()
+	}
+
+	return image, nil
+}
+
+// Attributes are the key/value pairs supplied at evaluation time the
+// component, application and attributes of volatile criteria are matched
+// against, e.g. the component and application the image belongs to
+type Attributes map[string]string
+
+// Applies reports whether the volatile criteria applies to the image with the
+// attributes at the given time: the time is within the effective period of
+// the criteria, the image matches the criteria as by
+// ecc.VolatileCriteria.MatchesImage and the attributes as by
+// ecc.VolatileCriteria.MatchesAttributes. Criteria with an effective period
+// that cannot be parsed never apply.
+func Applies(c ecc.VolatileCriteria, image Image, attributes Attributes, at time.Time) bool {
+	if c.EffectiveOn != "" {
+		on, err := time.Parse(time.RFC3339, c.EffectiveOn)
+		if err != nil || at.Before(on) {
+			return false
+		}
+	}
+
+	if c.EffectiveUntil != "" {
+		until, err := time.Parse(time.RFC3339, c.EffectiveUntil)
+		if err != nil || !at.Before(until) {
+			return false
+		}
+	}
+
+	return c.MatchesImage(image.reference()) && c.MatchesAttributes(attributes)
+}
+
+// reference returns the reference of the image, by digest when known
+func (i Image) reference() string {
+	if i.Digest == "" {
+		return i.Url
+	}
+
+	return i.Url + "@" + i.Digest
+}
+
+// applying returns the volatile criteria applying to the image with the
+// attributes at the given time and their values
+func applying(criteria []ecc.VolatileCriteria, image Image, attributes Attributes, at time.Time) ([]ecc.VolatileCriteria, []string) {
+	var applied []ecc.VolatileCriteria
+	var values []string
+	for _, c := range criteria {
+		if Applies(c, image, attributes, at) {
+			applied = append(applied, c)
+			values = append(values, c.Value)
+		}
+	}
+
+	return applied, values
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fetch downloads policy rules and data referred to by go-getter
+// style URLs from local directories, git repositories and OCI registries.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// Kind is the kind of location policy rules or data is fetched from
+type Kind string
+
+const (
+	// Local is a directory on the local file system
+	Local Kind = "file"
+	// Git is a git repository
+	Git Kind = "git"
+	// OCI is an artifact in an OCI registry
+	OCI Kind = "oci"
+)
+
+// Location is a parsed go-getter style URL
+type Location struct {
+	Kind Kind
+	// Address of the content, i.e. the path, the URL of the git repository or
+	// the reference of the OCI artifact
+	Address string
+	// Subdir is the directory within the fetched content to use
+	Subdir string
+	// Ref is the git revision to check out
+	Ref string
+}
+
+// IsLocal reports whether the location is on the local file system of the
+// process: a local directory or a git repository cloned from a local path
+func (l Location) IsLocal() bool {
+	switch l.Kind {
+	case Local:
+		return true
+	case Git:
+		if u, err := url.Parse(l.Address); err == nil && u.Scheme != "" {
+			return u.Scheme == "file"
+		}
+		// without a scheme, git treats addresses without a colon, or with a
+		// slash before the first colon, as local paths, and others as the
+		// scp-like syntax, e.g. git@github.com:acme/policy.git
+		colon := strings.Index(l.Address, ":")
+		return colon < 0 || strings.Contains(l.Address[:colon], "/")
+	}
+
+	return false
+}
+
+// IsLocal reports whether the go-getter style URL refers to the local file
+// system, see Location.IsLocal. URLs that cannot be parsed are not local.
+func IsLocal(u string) bool {
+	loc, err := Parse(u)
+
+	return err == nil && loc.IsLocal()
+}
+
+// hosts that are known to host git repositories when no getter is forced
+var gitHosts = []string{"github.com/", "gitlab.com/", "bitbucket.org/"}
+
+// Parse parses a go-getter style URL, e.g.
+// git::https://github.com/acme/policy.git//policy?ref=main,
+// oci::quay.io/acme/policy:latest or /path/to/policy. URLs without a forced
+// getter, i.e. the "<getter>::" prefix, are treated as local paths when they
+// are absolute or relative paths, as git repositories when they are on one of
+// the known git hosts and as OCI artifact references otherwise.
+func Parse(u string) (Location, error) {
+	if u == "" {
+		return Location{}, fmt.Errorf("empty URL")
+	}
+
+	forced, address, ok := strings.Cut(u, "::")
+	if !ok {
+		forced, address = "", u
+	}
+
+	switch forced {
+	case "":
+		switch {
+		case strings.HasPrefix(address, "file://"):
+			return parseLocal(strings.TrimPrefix(address, "file://")), nil
+		case strings.HasPrefix(address, "oci://"):
+			return Location{Kind: OCI, Address: strings.TrimPrefix(address, "oci://")}, nil
+		case strings.HasPrefix(address, "git://"), strings.HasPrefix(address, "git@"):
+			return parseGit(address)
+		case filepath.IsAbs(address), strings.HasPrefix(address, "./"), strings.HasPrefix(address, "../"):
+			return parseLocal(address), nil
+		}
+		for _, host := range gitHosts {
+			if strings.HasPrefix(address, host) {
+				return parseGitHost(address)
+			}
+		}
+		return Location{Kind: OCI, Address: address}, nil
+	case "file":
+		return parseLocal(strings.TrimPrefix(address, "file://")), nil
+	case "git":
+		if !strings.Contains(address, "://") && !strings.HasPrefix(address, "git@") {
+			return parseGitHost(address)
+		}
+		return parseGit(address)
+	case "oci":
+		return Location{Kind: OCI, Address: strings.TrimPrefix(address, "oci://")}, nil
+	default:
+		return Location{}, fmt.Errorf("unsupported getter %q in %q", forced, u)
+	}
+}
+
+func parseLocal(address string) Location {
+	return Location{Kind: Local, Address: address}
+}
+
+// parseGitHost parses a repository URL given without the scheme, e.g.
+// github.com/acme/policy//policy
+func parseGitHost(address string) (Location, error) {
+	return parseGit("https://" + address)
+}
+
+func parseGit(address string) (Location, error) {
+	address, subdir := splitSubdir(address)
+
+	loc := Location{Kind: Git, Subdir: subdir}
+	if u, err := url.Parse(address); err == nil && u.Scheme != "" {
+		q := u.Query()
+		loc.Ref = q.Get("ref")
+		q.Del("ref")
+		u.RawQuery = q.Encode()
+		loc.Address = u.String()
+	} else if before, query, ok := strings.Cut(address, "?"); ok {
+		// scp-like syntax, e.g. git@github.com:acme/policy.git?ref=main
+		q, err := url.ParseQuery(query)
+		if err != nil {
+			return Location{}, fmt.Errorf("unable to parse the query of %q: %w", address, err)
+		}
+		loc.Ref = q.Get("ref")
+		loc.Address = before
+	} else {
+		loc.Address = address
+	}
+
+	// refs are passed to git, which would take them as options
+	if strings.HasPrefix(loc.Ref, "-") {
+		return Location{}, fmt.Errorf("invalid ref %q of %q", loc.Ref, address)
+	}
+
+	return loc, nil
+}
+
+// splitSubdir splits the "//" separated subdirectory from the address, moving
+// any query to the address
+func splitSubdir(address string) (string, string) {
+	start := 0
+	if i := strings.Index(address, "://"); i >= 0 {
+		start = i + 3
+	}
+
+	i := strings.Index(address[start:], "//")
+	if i < 0 {
+		return address, ""
+	}
+
+	base, subdir := address[:start+i], address[start+i+2:]
+	if path, query, ok := strings.Cut(subdir, "?"); ok {
+		subdir = path
+		base += "?" + query
+	}
+
+	return base, subdir
+}
+
+// Fetcher fetches policy rules and data
+type Fetcher interface {
+	// Fetch downloads the content referred to by the go-getter style URL to
+	// the given directory and returns the directory holding the content,
+	// which for local directories is the directory itself
+	Fetch(ctx context.Context, url, dir string) (string, error)
+}
+
+// ErrLocal is returned fetching from the local file system when not allowed
+var ErrLocal = errors.New("fetching from the local file system is not allowed")
+
+type fetcher struct {
+	allowLocal bool
+}
+
+// NewFetcher returns a Fetcher that fetches from remote git repositories and
+// OCI registries, refusing local directories and local git repositories with
+// ErrLocal: those would expose the file system of the process, e.g. its
+// service account token, to whoever controls the URLs
+func NewFetcher() Fetcher {
+	return fetcher{}
+}
+
+// NewLocalFetcher returns a Fetcher that also fetches from local directories
+// and local git repositories, for tests and development only
+func NewLocalFetcher() Fetcher {
+	return fetcher{allowLocal: true}
+}
+
+func (f fetcher) Fetch(ctx context.Context, u, dir string) (string, error) {
+	loc, err := Parse(u)
+	if err != nil {
+		return "", err
+	}
+	if loc.IsLocal() && !f.allowLocal {
+		return "", fmt.Errorf("unable to fetch %q: %w", u, ErrLocal)
+	}
+
+	switch loc.Kind {
+	case Local:
+		return loc.Address, nil
+	case Git:
+		if err := fetchGit(ctx, loc, dir); err != nil {
+			return "", fmt.Errorf("unable to fetch %q: %w", u, err)
+		}
+	case OCI:
+		if err := fetchOCI(ctx, loc, dir); err != nil {
+			return "", fmt.Errorf("unable to fetch %q: %w", u, err)
+		}
+	}
+
+	return subdir(dir, loc.Subdir)
+}
+
+// subdir returns the subdirectory of dir, making sure it's within dir
+func subdir(dir, sub string) (string, error) {
+	if sub == "" {
+		return dir, nil
+	}
+
+	path := filepath.Join(dir, sub)
+	if rel, err := filepath.Rel(dir, path); err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("subdirectory %q is outside of the fetched content", sub)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,489 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetch
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		url      string
+		expected Location
+		err      bool
+	}{
+		{url: "", err: true},
+		{url: "s3::bucket/policy", err: true},
+		{url: "/policy", expected: Location{Kind: Local, Address: "/policy"}},
+		{url: "./policy", expected: Location{Kind: Local, Address: "./policy"}},
+		{url: "file::/policy", expected: Location{Kind: Local, Address: "/policy"}},
+		{url: "file:///policy", expected: Location{Kind: Local, Address: "/policy"}},
+		{
+			url:      "oci::quay.io/enterprise-contract/ec-release-policy:latest",
+			expected: Location{Kind: OCI, Address: "quay.io/enterprise-contract/ec-release-policy:latest"},
+		},
+		{
+			url:      "oci://quay.io/enterprise-contract/ec-release-policy:latest",
+			expected: Location{Kind: OCI, Address: "quay.io/enterprise-contract/ec-release-policy:latest"},
+		},
+		{
+			url:      "quay.io/hacbs-contract/ec-release-policy:latest",
+			expected: Location{Kind: OCI, Address: "quay.io/hacbs-contract/ec-release-policy:latest"},
+		},
+		{
+			url:      "localhost:5000/policy@sha256:cfe1335814d92eabecfe9802f13298539caa7bbd0a13b61f320dc45bdded473d",
+			expected: Location{Kind: OCI, Address: "localhost:5000/policy@sha256:cfe1335814d92eabecfe9802f13298539caa7bbd0a13b61f320dc45bdded473d"},
+		},
+		{
+			url:      "git::https://github.com/acme/ec-policy.git//policy?ref=prod",
+			expected: Location{Kind: Git, Address: "https://github.com/acme/ec-policy.git", Subdir: "policy", Ref: "prod"},
+		},
+		{
+			url:      "git::https://github.com/acme/ec-policy.git?ref=prod",
+			expected: Location{Kind: Git, Address: "https://github.com/acme/ec-policy.git", Ref: "prod"},
+		},
+		{
+			url:      "git::github.com/conforma/policy//example/data",
+			expected: Location{Kind: Git, Address: "https://github.com/conforma/policy", Subdir: "example/data"},
+		},
+		{
+			url:      "github.com/release-engineering/rhtap-ec-policy//data",
+			expected: Location{Kind: Git, Address: "https://github.com/release-engineering/rhtap-ec-policy", Subdir: "data"},
+		},
+		{
+			url:      "git::file:///srv/git/policy.git//policy?ref=main",
+			expected: Location{Kind: Git, Address: "file:///srv/git/policy.git", Subdir: "policy", Ref: "main"},
+		},
+		{
+			url:      "git::git@github.com:acme/policy.git?ref=v1",
+			expected: Location{Kind: Git, Address: "git@github.com:acme/policy.git", Ref: "v1"},
+		},
+		{url: "git::https://github.com/acme/ec-policy.git?ref=--orphan=x", err: true},
+		{url: "git::git@github.com:acme/policy.git?ref=-b", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.url, func(t *testing.T) {
+			loc, err := Parse(c.url)
+			if c.err {
+				if err == nil {
+					t.Errorf("expected an error, got %v", loc)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if loc != c.expected {
+				t.Errorf("expected %#v, got %#v", c.expected, loc)
+			}
+		})
+	}
+}
+
+func TestFetchLocal(t *testing.T) {
+	local := t.TempDir()
+	dir, err := NewLocalFetcher().Fetch(context.Background(), "file::"+local, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dir != local {
+		t.Errorf("expected the local directory to be used as is, got %q", dir)
+	}
+}
+
+func TestFetchLocalRefused(t *testing.T) {
+	repo, _ := gitRepository(t)
+
+	for _, u := range []string{"/var/run/secrets", "./policy", "../policy", "file:///etc", "file::/etc", "git::" + repo, "git::file:///srv/git/policy.git"} {
+		t.Run(u, func(t *testing.T) {
+			if _, err := NewFetcher().Fetch(context.Background(), u, filepath.Join(t.TempDir(), "fetched")); !errors.Is(err, ErrLocal) {
+				t.Errorf("expected ErrLocal, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIsLocal(t *testing.T) {
+	cases := map[string]bool{
+		"/policy":                         true,
+		"./policy":                        true,
+		"file::/policy":                   true,
+		"file:///policy":                  true,
+		"git::file:///srv/git/policy.git": true,
+		"git::https://github.com/acme/policy.git":   false,
+		"git::git@github.com:acme/policy.git":       false,
+		"github.com/acme/policy//policy":            false,
+		"oci::quay.io/acme/policy:latest":           false,
+		"quay.io/acme/policy:latest":                false,
+		"git::ssh://git@github.com/acme/policy.git": false,
+		"k8s://acme/policy-data":                    false,
+	}
+
+	for u, expected := range cases {
+		if got := IsLocal(u); got != expected {
+			t.Errorf("expected IsLocal(%q) to be %v, got %v", u, expected, got)
+		}
+	}
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v: %s", args, err, out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+// gitRepository creates a bare repository with two commits, returning its
+// file URL and the hash of the first commit
+func gitRepository(t *testing.T) (string, string) {
+	t.Helper()
+
+	work := t.TempDir()
+	gitCmd(t, work, "init", "--quiet", "--initial-branch=main")
+	writeTestFile(t, filepath.Join(work, "policy", "policy.rego"), "package first")
+	gitCmd(t, work, "add", ".")
+	gitCmd(t, work, "commit", "--quiet", "-m", "first")
+	first := gitCmd(t, work, "rev-parse", "HEAD")
+	writeTestFile(t, filepath.Join(work, "policy", "policy.rego"), "package second")
+	gitCmd(t, work, "commit", "--quiet", "-am", "second")
+
+	bare := filepath.Join(t.TempDir(), "policy.git")
+	gitCmd(t, work, "clone", "--quiet", "--bare", work, bare)
+
+	return (&url.URL{Scheme: "file", Path: bare}).String(), first
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFetchGit(t *testing.T) {
+	repo, first := gitRepository(t)
+
+	cases := []struct {
+		name     string
+		url      string
+		expected string
+		commits  string
+	}{
+		{name: "default branch", url: "git::" + repo + "//policy", expected: "package second", commits: "1"},
+		{name: "commit", url: "git::" + repo + "//policy?ref=" + first, expected: "package first", commits: "1"},
+		{name: "branch", url: "git::" + repo + "//policy?ref=main", expected: "package second", commits: "1"},
+		// abbreviated commits cannot be fetched, the repository is cloned
+		{name: "abbreviated commit", url: "git::" + repo + "//policy?ref=" + first[:7], expected: "package first", commits: "2"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fetched := filepath.Join(t.TempDir(), "fetched")
+			dir, err := NewLocalFetcher().Fetch(context.Background(), c.url, fetched)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(dir, "policy.rego"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, content)
+			}
+
+			// only the requested commit is fetched when possible
+			if count, err := RunGit(context.Background(), fetched, "rev-list", "--count", "--all"); err != nil || strings.TrimSpace(count) != c.commits {
+				t.Errorf("expected %s commits to be fetched, got %q: %v", c.commits, count, err)
+			}
+		})
+	}
+}
+
+func TestFetchGitUnknownRef(t *testing.T) {
+	repo, _ := gitRepository(t)
+
+	if _, err := NewLocalFetcher().Fetch(context.Background(), "git::"+repo+"?ref=nope", filepath.Join(t.TempDir(), "fetched")); err == nil {
+		t.Error("expected an error fetching an unknown ref")
+	}
+}
+
+func layer(t *testing.T, content []byte, annotations map[string]string) mutate.Addendum {
+	t.Helper()
+
+	return mutate.Addendum{
+		Layer:       static.NewLayer(content, types.MediaType("application/vnd.cncf.openpolicyagent.policy.layer.v1+rego")),
+		Annotations: annotations,
+	}
+}
+
+func archive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// pushBundle pushes an image with the given layers to an in-process registry
+// and returns its reference
+func pushBundle(t *testing.T, layers ...mutate.Addendum) string {
+	t.Helper()
+
+	srv := httptest.NewServer(registry.New())
+	t.Cleanup(srv.Close)
+
+	img, err := mutate.Append(empty.Image, layers...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, _ := url.Parse(srv.URL)
+	ref, err := name.ParseReference(u.Host + "/policy:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return ref.String()
+}
+
+func TestFetchOCI(t *testing.T) {
+	ref := pushBundle(t,
+		layer(t, []byte("package file"), map[string]string{titleAnnotation: "policy/file.rego"}),
+		layer(t, archive(t, map[string]string{"lib/lib.rego": "package lib"}), map[string]string{
+			titleAnnotation:  "policy",
+			unpackAnnotation: "true",
+		}),
+		layer(t, []byte("ignored"), nil),
+	)
+
+	dir, err := NewFetcher().Fetch(context.Background(), "oci::"+ref, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for path, expected := range map[string]string{
+		"policy/file.rego":    "package file",
+		"policy/lib/lib.rego": "package lib",
+	} {
+		content, err := os.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			t.Errorf("unable to read %s: %v", path, err)
+			continue
+		}
+		if string(content) != expected {
+			t.Errorf("expected %q in %s, got %q", expected, path, content)
+		}
+	}
+}
+
+func TestFetchOCIOutsideOfDirectory(t *testing.T) {
+	ref := pushBundle(t, layer(t, []byte("x"), map[string]string{titleAnnotation: "../escape.rego"}))
+
+	if _, err := NewFetcher().Fetch(context.Background(), "oci::"+ref, t.TempDir()); err == nil {
+		t.Error("expected an error fetching a layer outside of the directory")
+	}
+}
+
+func TestFetchOCIMissing(t *testing.T) {
+	ref := pushBundle(t)
+
+	if _, err := NewFetcher().Fetch(context.Background(), "oci::"+ref+"-missing", t.TempDir()); err == nil {
+		t.Error("expected an error fetching a missing image")
+	}
+}
+
+func TestPinGit(t *testing.T) {
+	repo, first := gitRepository(t)
+
+	cases := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{name: "commit", url: "git::" + repo + "//policy?ref=" + first, expected: "git::" + repo + "//policy?ref=" + first},
+		{name: "first", url: "git::" + repo + "?ref=" + first, expected: "git::" + repo + "?ref=" + first},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := pin(context.Background(), c.url, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+
+	for _, u := range []string{"git::" + repo + "//policy", "git::" + repo + "//policy?ref=main"} {
+		t.Run(u, func(t *testing.T) {
+			got, err := pin(context.Background(), u, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			prefix := "git::" + repo + "//policy?ref="
+			if !strings.HasPrefix(got, prefix) || !commitHash.MatchString(strings.TrimPrefix(got, prefix)) || strings.HasSuffix(got, first) {
+				t.Fatalf("expected %q to be pinned to the second commit, got %q", u, got)
+			}
+
+			dir, err := NewLocalFetcher().Fetch(context.Background(), got, filepath.Join(t.TempDir(), "fetched"))
+			if err != nil {
+				t.Fatalf("unexpected error fetching %q: %v", got, err)
+			}
+			if content, err := os.ReadFile(filepath.Join(dir, "policy.rego")); err != nil || string(content) != "package second" {
+				t.Errorf("expected the pinned commit to hold the second revision, got %q (%v)", content, err)
+			}
+		})
+	}
+
+	if _, err := pin(context.Background(), "git::"+repo+"?ref=nope", true); err == nil {
+		t.Error("expected an error pinning an unknown ref")
+	}
+}
+
+func TestRemoteCommit(t *testing.T) {
+	out := strings.Join([]string{
+		"1111111111111111111111111111111111111111\tHEAD",
+		"2222222222222222222222222222222222222222\trefs/heads/dev/main",
+		"3333333333333333333333333333333333333333\trefs/heads/main",
+		"4444444444444444444444444444444444444444\trefs/tags/dev/v1",
+		"5555555555555555555555555555555555555555\trefs/tags/dev/v1^{}",
+		"6666666666666666666666666666666666666666\trefs/tags/v1",
+		"7777777777777777777777777777777777777777\trefs/tags/v1^{}",
+		"8888888888888888888888888888888888888888\trefs/tags/v2",
+		"9999999999999999999999999999999999999999\trefs/heads/release",
+		"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\trefs/tags/release",
+		"",
+	}, "\n")
+
+	cases := []struct {
+		ref      string
+		expected string
+	}{
+		{ref: "HEAD", expected: "1111111111111111111111111111111111111111"},
+		{ref: "main", expected: "3333333333333333333333333333333333333333"},
+		{ref: "refs/heads/main", expected: "3333333333333333333333333333333333333333"},
+		{ref: "dev/main", expected: "2222222222222222222222222222222222222222"},
+		{ref: "v1", expected: "7777777777777777777777777777777777777777"},
+		{ref: "refs/tags/v1", expected: "7777777777777777777777777777777777777777"},
+		{ref: "dev/v1", expected: "5555555555555555555555555555555555555555"},
+		{ref: "v2", expected: "8888888888888888888888888888888888888888"},
+		{ref: "release", expected: "9999999999999999999999999999999999999999"},
+		{ref: "ain"},
+		{ref: "v3"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.ref, func(t *testing.T) {
+			if got := remoteCommit(out, c.ref); got != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestPinOCI(t *testing.T) {
+	ref := pushBundle(t, layer(t, []byte("package file"), map[string]string{titleAnnotation: "policy/file.rego"}))
+
+	got, err := Pin(context.Background(), "oci::"+ref)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d, err := name.NewDigest(strings.TrimPrefix(got, "oci::"))
+	if err != nil {
+		t.Fatalf("expected a digest reference, got %q: %v", got, err)
+	}
+	if !strings.HasPrefix(ref, d.Context().String()+":") {
+		t.Errorf("expected %q to be pinned in the same repository, got %q", ref, got)
+	}
+
+	if again, err := Pin(context.Background(), got); err != nil || again != got {
+		t.Errorf("expected a pinned URL to be kept, got %q (%v)", again, err)
+	}
+
+	if _, err := Pin(context.Background(), "oci::"+ref+"-missing"); err == nil {
+		t.Error("expected an error pinning a missing image")
+	}
+}
+
+func TestPinLocal(t *testing.T) {
+	repo, _ := gitRepository(t)
+
+	for _, u := range []string{"/path/to/policy", "git::" + repo + "?ref=main"} {
+		if got, err := Pin(context.Background(), u); err != nil || got != u {
+			t.Errorf("expected local paths and repositories to be kept, got %q (%v)", got, err)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// fetchGit fetches the requested revision of the repository to dir, without
+// the history of the repository. Servers not allowing to fetch commits by
+// their id are fully cloned instead.
+func fetchGit(ctx context.Context, loc Location, dir string) error {
+	ref := loc.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	if err := shallowFetch(ctx, loc.Address, ref, dir); err == nil {
+		return nil
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("unable to remove the shallow clone: %w", err)
+	}
+
+	if _, err := RunGit(ctx, "", "clone", "--quiet", "--", loc.Address, dir); err != nil {
+		return err
+	}
+
+	if loc.Ref == "" {
+		return nil
+	}
+
+	// the ref is resolved first, as not all versions of git checkout take
+	// --end-of-options, so that it is never taken as an option
+	commit, err := RunGit(ctx, dir, "rev-parse", "--verify", "--end-of-options", loc.Ref+"^{commit}")
+	if err != nil {
+		return err
+	}
+
+	_, err = RunGit(ctx, dir, "checkout", "--quiet", "--detach", strings.TrimSpace(commit))
+
+	return err
+}
+
+// shallowFetch fetches only the commit the ref refers to and checks it out
+func shallowFetch(ctx context.Context, address, ref, dir string) error {
+	if _, err := RunGit(ctx, "", "init", "--quiet", dir); err != nil {
+		return err
+	}
+
+	if _, err := RunGit(ctx, dir, "fetch", "--quiet", "--depth", "1", "--", address, ref); err != nil {
+		return err
+	}
+
+	_, err := RunGit(ctx, dir, "checkout", "--quiet", "FETCH_HEAD")
+
+	return err
+}
+
+// RunGit runs the git command line tool in the given directory, returning
+// its standard output. Git never prompts for credentials.
+func RunGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	// never prompt for credentials
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
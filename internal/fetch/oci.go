@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetch
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+const (
+	// titleAnnotation holds the name of the file or directory a layer is
+	// extracted to
+	titleAnnotation = "org.opencontainers.image.title"
+	// unpackAnnotation marks layers holding gzipped tar archives of directories
+	unpackAnnotation = "io.deis.oras.content.unpack"
+)
+
+// fetchOCI pulls the layers of the artifact to dir, as done by conftest and
+// oras: each layer is either a file, or when annotated to be unpacked, an
+// archived directory, named by the title annotation of the layer
+func fetchOCI(ctx context.Context, loc Location, dir string) error {
+	ref, err := name.ParseReference(loc.Address)
+	if err != nil {
+		return fmt.Errorf("unable to parse reference: %w", err)
+	}
+
+	img, err := remote.Image(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return fmt.Errorf("unable to pull: %w", err)
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return fmt.Errorf("unable to read the manifest: %w", err)
+	}
+
+	for _, desc := range manifest.Layers {
+		title := desc.Annotations[titleAnnotation]
+		if title == "" {
+			continue
+		}
+
+		target, err := subdir(dir, title)
+		if err != nil {
+			return err
+		}
+
+		layer, err := img.LayerByDigest(desc.Digest)
+		if err != nil {
+			return fmt.Errorf("unable to read layer %s: %w", desc.Digest, err)
+		}
+
+		content, err := layer.Compressed()
+		if err != nil {
+			return fmt.Errorf("unable to read layer %s: %w", desc.Digest, err)
+		}
+
+		if desc.Annotations[unpackAnnotation] == "true" {
+			err = untar(content, target)
+		} else {
+			err = writeFile(content, target)
+		}
+		content.Close()
+		if err != nil {
+			return fmt.Errorf("unable to extract layer %s: %w", desc.Digest, err)
+		}
+	}
+
+	return nil
+}
+
+func writeFile(r io.Reader, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, r)
+
+	return err
+}
+
+func untar(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	t := tar.NewReader(gz)
+	for {
+		h, err := t.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		path, err := subdir(dir, h.Name)
+		if err != nil {
+			return err
+		}
+
+		switch h.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeFile(t, path); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// commitHash matches full SHA-1 and SHA-256 git commit hashes
+var commitHash = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// Pin returns the go-getter style URL pinned to the content it currently
+// refers to: OCI artifacts are pinned to their digest and git repositories to
+// the commit of the ref. Local directories and local git repositories are not
+// accessed, they cannot be pinned and are returned as is.
+func Pin(ctx context.Context, u string) (string, error) {
+	return pin(ctx, u, false)
+}
+
+// PinLocal pins the go-getter style URL as Pin does, also pinning local git
+// repositories to the commit of the ref, for tests and development only
+func PinLocal(ctx context.Context, u string) (string, error) {
+	return pin(ctx, u, true)
+}
+
+// pin pins the URL as Pin does, pinning local git repositories to the commit
+// of the ref when allowed
+func pin(ctx context.Context, u string, allowLocal bool) (string, error) {
+	loc, err := Parse(u)
+	if err != nil {
+		return "", err
+	}
+	if loc.Kind == Local || loc.IsLocal() && !allowLocal {
+		return u, nil
+	}
+
+	switch loc.Kind {
+	case OCI:
+		return pinOCI(ctx, loc)
+	case Git:
+		return pinGit(ctx, loc)
+	default:
+		return u, nil
+	}
+}
+
+func pinOCI(ctx context.Context, loc Location) (string, error) {
+	ref, err := name.ParseReference(loc.Address)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse reference: %w", err)
+	}
+
+	if d, ok := ref.(name.Digest); ok {
+		return "oci::" + d.String(), nil
+	}
+
+	desc, err := remote.Head(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve the digest of %q: %w", loc.Address, err)
+	}
+
+	return "oci::" + ref.Context().Digest(desc.Digest.String()).String(), nil
+}
+
+func pinGit(ctx context.Context, loc Location) (string, error) {
+	commit := loc.Ref
+	if !commitHash.MatchString(commit) {
+		ref := loc.Ref
+		if ref == "" {
+			ref = "HEAD"
+		}
+
+		out, err := RunGit(ctx, "", "ls-remote", "--", loc.Address, ref)
+		if err != nil {
+			return "", err
+		}
+
+		if commit = remoteCommit(out, ref); commit == "" {
+			return "", fmt.Errorf("unable to resolve ref %q of %q", ref, loc.Address)
+		}
+	}
+
+	address, query, _ := strings.Cut(loc.Address, "?")
+	if loc.Subdir != "" {
+		address += "//" + loc.Subdir
+	}
+	if query != "" {
+		query += "&"
+	}
+
+	return "git::" + address + "?" + query + "ref=" + commit, nil
+}
+
+// remoteCommit returns the commit of the ref in the git ls-remote output.
+// Only refs named exactly as the ref match, as git resolves them: the branch
+// first, then the tag, peeled to the commit annotated tags refer to, then the
+// ref itself, e.g. HEAD or refs/heads/main.
+func remoteCommit(out, ref string) string {
+	refs := map[string]string{}
+	for _, line := range strings.Split(out, "\n") {
+		if hash, name, ok := strings.Cut(line, "\t"); ok {
+			refs[name] = hash
+		}
+	}
+
+	for _, name := range []string{"refs/heads/" + ref, "refs/tags/" + ref + "^{}", "refs/tags/" + ref, ref + "^{}", ref} {
+		if hash, ok := refs[name]; ok {
+			return hash
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package governance holds the cluster-level rules the excludes of policies
+// are held to on admission, e.g. that volatile excludes link to an issue and
+// expire within a window.
+package governance
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// ConfigKey is the key of the governance configuration in its ConfigMap
+const ConfigKey = "governance.yaml"
+
+// Config is the governance configuration, a policy is held to every rule
+// selecting its namespace
+type Config struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule constrains the excludes of the policies in the namespaces it selects
+type Rule struct {
+	// Name of the rule, reported in admission errors
+	Name string `json:"name"`
+	// NamespaceSelector selects the namespaces of the policies the rule
+	// applies to by label, all namespaces when not set
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// ReferencePattern is the regular expression the Reference of volatile
+	// excludes must match, a Reference is required when set
+	ReferencePattern string `json:"referencePattern,omitempty"`
+	// MaxExpiry is how far in the future the EffectiveUntil of volatile
+	// excludes may be at the time of admission, an EffectiveUntil is required
+	// when set
+	MaxExpiry *metav1.Duration `json:"maxExpiry,omitempty"`
+	// ForbidUnbounded forbids excludes that never expire: volatile excludes
+	// without an EffectiveUntil, the excludes of the source configurations
+	// and of the deprecated policy configuration
+	ForbidUnbounded bool `json:"forbidUnbounded,omitempty"`
+
+	selector labels.Selector
+	pattern  *regexp.Regexp
+}
+
+// Parse parses and checks the YAML or JSON governance configuration
+func Parse(data []byte) (*Config, error) {
+	config := Config{}
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("unable to parse the governance configuration: %w", err)
+	}
+
+	var errs []error
+	for i := range config.Rules {
+		r := &config.Rules[i]
+		if r.Name == "" {
+			errs = append(errs, fmt.Errorf("rule %d: the name is required", i))
+		}
+
+		var err error
+		r.selector = labels.Everything()
+		if r.NamespaceSelector != nil {
+			if r.selector, err = metav1.LabelSelectorAsSelector(r.NamespaceSelector); err != nil {
+				errs = append(errs, fmt.Errorf("rule %q: invalid namespace selector: %w", r.Name, err))
+			}
+		}
+
+		if r.ReferencePattern != "" {
+			if r.pattern, err = regexp.Compile(r.ReferencePattern); err != nil {
+				errs = append(errs, fmt.Errorf("rule %q: invalid reference pattern: %w", r.Name, err))
+			}
+		}
+
+		if r.MaxExpiry != nil && r.MaxExpiry.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("rule %q: the maximum expiry must be positive", r.Name))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid governance configuration: %w", err)
+	}
+
+	return &config, nil
+}
+
+// Validate holds the policy in a namespace with the given labels to the rules
+// selecting the namespace at the given time. On update, with the policy
+// before the update given as old, the excludes already in the same source, or
+// the deprecated configuration, of the old policy are not validated again, so
+// that rules added or tightened do not block unrelated changes to policies.
+func (c *Config) Validate(policy, old *ecc.EnterpriseContractPolicy, namespaceLabels map[string]string, now time.Time) field.ErrorList {
+	if c == nil {
+		return nil
+	}
+
+	existing := existingExcludes(old)
+
+	var errs field.ErrorList
+	for _, r := range c.Rules {
+		if r.selector != nil && !r.selector.Matches(labels.Set(namespaceLabels)) {
+			continue
+		}
+		errs = append(errs, r.validate(policy, existing, now)...)
+	}
+
+	return errs
+}
+
+func (r Rule) validate(policy *ecc.EnterpriseContractPolicy, existing excludes, now time.Time) field.ErrorList {
+	var errs field.ErrorList
+
+	if r.ForbidUnbounded {
+		if policy.Spec.Configuration != nil {
+			configuration := field.NewPath("spec", "configuration")
+			path := configuration.Child("exclude")
+			for i, v := range policy.Spec.Configuration.Exclude {
+				if !existing.values[configuration.String()][v] {
+					errs = append(errs, r.forbidden(path.Index(i), "excludes that never expire are not allowed, use a volatile exclude with an effectiveUntil"))
+				}
+			}
+		}
+	}
+
+	sources := field.NewPath("spec", "sources")
+	for i, s := range policy.Spec.Sources {
+		source := sources.Index(i)
+		if r.ForbidUnbounded && s.Config != nil {
+			path := source.Child("config", "exclude")
+			for j, v := range s.Config.Exclude {
+				if !existing.values[source.String()][v] {
+					errs = append(errs, r.forbidden(path.Index(j), "excludes that never expire are not allowed, use a volatile exclude with an effectiveUntil"))
+				}
+			}
+		}
+
+		if s.VolatileConfig == nil {
+			continue
+		}
+
+		path := source.Child("volatileConfig", "exclude")
+		for j, c := range s.VolatileConfig.Exclude {
+			if existing.has(source, c) {
+				continue
+			}
+			errs = append(errs, r.validateVolatile(c, path.Index(j), now)...)
+		}
+	}
+
+	return errs
+}
+
+func (r Rule) validateVolatile(c ecc.VolatileCriteria, path *field.Path, now time.Time) field.ErrorList {
+	var errs field.ErrorList
+
+	if r.pattern != nil {
+		switch {
+		case c.Reference == "":
+			errs = append(errs, r.required(path.Child("reference"), fmt.Sprintf("a reference matching %s is required", r.ReferencePattern)))
+		case !r.pattern.MatchString(c.Reference):
+			errs = append(errs, r.invalid(path.Child("reference"), c.Reference, fmt.Sprintf("must match %s", r.ReferencePattern)))
+		}
+	}
+
+	if c.EffectiveUntil == "" {
+		switch {
+		case r.MaxExpiry != nil:
+			errs = append(errs, r.required(path.Child("effectiveUntil"), fmt.Sprintf("an expiry within %s is required", r.MaxExpiry.Duration)))
+		case r.ForbidUnbounded:
+			errs = append(errs, r.required(path.Child("effectiveUntil"), "excludes that never expire are not allowed"))
+		}
+
+		return errs
+	}
+
+	if r.MaxExpiry == nil {
+		return errs
+	}
+
+	until, err := time.Parse(time.RFC3339, c.EffectiveUntil)
+	if err != nil {
+		return append(errs, r.invalid(path.Child("effectiveUntil"), c.EffectiveUntil, "not an RFC 3339 time"))
+	}
+
+	if limit := now.Add(r.MaxExpiry.Duration); until.After(limit) {
+		errs = append(errs, r.invalid(path.Child("effectiveUntil"), c.EffectiveUntil,
+			fmt.Sprintf("must be within %s, i.e. not after %s", r.MaxExpiry.Duration, limit.UTC().Format(time.RFC3339))))
+	}
+
+	return errs
+}
+
+func (r Rule) required(path *field.Path, detail string) *field.Error {
+	return field.Required(path, r.detail(detail))
+}
+
+func (r Rule) invalid(path *field.Path, value any, detail string) *field.Error {
+	return field.Invalid(path, value, r.detail(detail))
+}
+
+func (r Rule) forbidden(path *field.Path, detail string) *field.Error {
+	return field.Forbidden(path, r.detail(detail))
+}
+
+func (r Rule) detail(detail string) string {
+	return fmt.Sprintf("%s (governance rule %q)", detail, r.Name)
+}
+
+// excludes are the excludes of the policy before an update, by the path of
+// the configuration holding them: an exclude moved or copied to another
+// source, or between a source and the deprecated configuration, is validated
+// as a new exclude
+type excludes struct {
+	values   map[string]map[string]bool
+	volatile map[string][]ecc.VolatileCriteria
+}
+
+func existingExcludes(policy *ecc.EnterpriseContractPolicy) excludes {
+	e := excludes{values: map[string]map[string]bool{}, volatile: map[string][]ecc.VolatileCriteria{}}
+	if policy == nil {
+		return e
+	}
+
+	add := func(path *field.Path, values []string) {
+		if e.values[path.String()] == nil {
+			e.values[path.String()] = map[string]bool{}
+		}
+		for _, v := range values {
+			e.values[path.String()][v] = true
+		}
+	}
+
+	if policy.Spec.Configuration != nil {
+		add(field.NewPath("spec", "configuration"), policy.Spec.Configuration.Exclude)
+	}
+
+	sources := field.NewPath("spec", "sources")
+	for i, s := range policy.Spec.Sources {
+		if s.Config != nil {
+			add(sources.Index(i), s.Config.Exclude)
+		}
+		if s.VolatileConfig != nil {
+			e.volatile[sources.Index(i).String()] = s.VolatileConfig.Exclude
+		}
+	}
+
+	return e
+}
+
+func (e excludes) has(source *field.Path, c ecc.VolatileCriteria) bool {
+	for _, v := range e.volatile[source.String()] {
+		if equality.Semantic.DeepEqual(c, v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package governance
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+)
+
+const config = `rules:
+- name: linked
+  referencePattern: ^https://issues\.acme\.com/browse/[A-Z]+-[0-9]+$
+  maxExpiry: 2160h
+- name: prod
+  namespaceSelector:
+    matchLabels:
+      env: prod
+  forbidUnbounded: true
+`
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{name: "valid", config: config},
+		{name: "empty", config: ""},
+		{name: "unknown field", config: "rules:\n- name: a\n  maxAge: 1h\n", err: "unknown field"},
+		{name: "no name", config: "rules:\n- forbidUnbounded: true\n", err: "rule 0: the name is required"},
+		{name: "invalid pattern", config: "rules:\n- name: a\n  referencePattern: '('\n", err: `rule "a": invalid reference pattern`},
+		{name: "invalid selector", config: "rules:\n- name: a\n  namespaceSelector:\n    matchExpressions:\n    - key: env\n      operator: Near\n", err: `rule "a": invalid namespace selector`},
+		{name: "negative expiry", config: "rules:\n- name: a\n  maxExpiry: -1h\n", err: `rule "a": the maximum expiry must be positive`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Parse([]byte(c.config))
+			if c.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+				t.Fatalf("expected an error containing %q, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	linked := func(value string) *ecctesting.VolatileCriteriaBuilder {
+		return ecctesting.NewVolatileCriteria(value).WithReference("https://issues.acme.com/browse/SEC-1")
+	}
+
+	cases := []struct {
+		name   string
+		source *ecctesting.SourceBuilder
+		labels map[string]string
+		old    *ecctesting.SourceBuilder
+		fields []string
+	}{
+		{
+			name:   "compliant",
+			source: ecctesting.NewSource("a").WithVolatileExclude(linked("cve").EffectiveUntil(now.Add(24 * time.Hour))),
+			labels: map[string]string{"env": "prod"},
+		},
+		{
+			name: "missing reference and expiry",
+			source: ecctesting.NewSource("a").WithVolatileExclude(
+				ecctesting.NewVolatileCriteria("cve").EffectiveUntil(now.Add(24*time.Hour)),
+				linked("tasks"),
+			),
+			fields: []string{"spec.sources[0].volatileConfig.exclude[0].reference", "spec.sources[0].volatileConfig.exclude[1].effectiveUntil"},
+		},
+		{
+			name: "reference not matching",
+			source: ecctesting.NewSource("a").WithVolatileExclude(
+				ecctesting.NewVolatileCriteria("cve").EffectiveUntil(now.Add(24 * time.Hour)).WithReference("see chat"),
+			),
+			fields: []string{"spec.sources[0].volatileConfig.exclude[0].reference"},
+		},
+		{
+			name:   "expiry too far",
+			source: ecctesting.NewSource("a").WithVolatileExclude(linked("cve").EffectiveUntil(now.Add(91 * 24 * time.Hour))),
+			fields: []string{"spec.sources[0].volatileConfig.exclude[0].effectiveUntil"},
+		},
+		{
+			name:   "static excludes outside of prod",
+			source: ecctesting.NewSource("a").WithExclude("cve"),
+			labels: map[string]string{"env": "stage"},
+		},
+		{
+			name:   "static excludes in prod",
+			source: ecctesting.NewSource("a").WithExclude("cve", "tasks"),
+			labels: map[string]string{"env": "prod"},
+			fields: []string{"spec.sources[0].config.exclude[0]", "spec.sources[0].config.exclude[1]"},
+		},
+		{
+			name:   "unchanged excludes",
+			source: ecctesting.NewSource("a").WithExclude("cve").WithVolatileExclude(ecctesting.NewVolatileCriteria("tasks")),
+			labels: map[string]string{"env": "prod"},
+			old:    ecctesting.NewSource("b").WithExclude("cve").WithVolatileExclude(ecctesting.NewVolatileCriteria("tasks")),
+		},
+		{
+			name:   "changed excludes",
+			source: ecctesting.NewSource("a").WithExclude("cve", "sbom").WithVolatileExclude(ecctesting.NewVolatileCriteria("tasks").ForComponent("api")),
+			labels: map[string]string{"env": "prod"},
+			old:    ecctesting.NewSource("a").WithExclude("cve").WithVolatileExclude(ecctesting.NewVolatileCriteria("tasks")),
+			fields: []string{
+				"spec.sources[0].volatileConfig.exclude[0].reference",
+				"spec.sources[0].volatileConfig.exclude[0].effectiveUntil",
+				"spec.sources[0].config.exclude[1]",
+				"spec.sources[0].volatileConfig.exclude[0].effectiveUntil",
+			},
+		},
+	}
+
+	c, err := Parse([]byte(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := ecctesting.NewPolicySpec().WithSources(tc.source).Policy("acme", "policy")
+			var old *ecc.EnterpriseContractPolicy
+			if tc.old != nil {
+				old = ecctesting.NewPolicySpec().WithSources(tc.old).Policy("acme", "policy")
+			}
+
+			errs := c.Validate(policy, old, tc.labels, now)
+			if len(errs) != len(tc.fields) {
+				t.Fatalf("expected errors for %v, got %v", tc.fields, errs)
+			}
+			for i, e := range errs {
+				if e.Field != tc.fields[i] {
+					t.Errorf("expected an error for %s, got %s", tc.fields[i], e.Field)
+				}
+				if !strings.Contains(e.Detail, "(governance rule ") {
+					t.Errorf("expected the rule in the error, got %q", e.Detail)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMovedExcludes(t *testing.T) {
+	c, err := Parse([]byte(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := ecctesting.NewPolicySpec().
+		WithConfiguration(ecc.EnterpriseContractPolicyConfiguration{Exclude: []string{"sbom"}}).
+		WithSources(
+			ecctesting.NewSource("a").WithExclude("cve").WithVolatileExclude(ecctesting.NewVolatileCriteria("tasks")),
+			ecctesting.NewSource("b")).
+		Policy("acme", "policy")
+
+	// the excludes copied to another source are new excludes there
+	policy := ecctesting.NewPolicySpec().
+		WithConfiguration(ecc.EnterpriseContractPolicyConfiguration{Exclude: []string{"sbom", "cve"}}).
+		WithSources(
+			ecctesting.NewSource("a").WithExclude("cve").WithVolatileExclude(ecctesting.NewVolatileCriteria("tasks")),
+			ecctesting.NewSource("b").WithExclude("cve", "sbom").WithVolatileExclude(ecctesting.NewVolatileCriteria("tasks"))).
+		Policy("acme", "policy")
+
+	errs := c.Validate(policy, old, map[string]string{"env": "prod"}, time.Now())
+
+	expected := []string{
+		"spec.sources[1].volatileConfig.exclude[0].reference",
+		"spec.sources[1].volatileConfig.exclude[0].effectiveUntil",
+		"spec.configuration.exclude[1]",
+		"spec.sources[1].config.exclude[0]",
+		"spec.sources[1].config.exclude[1]",
+		"spec.sources[1].volatileConfig.exclude[0].effectiveUntil",
+	}
+	var fields []string
+	for _, e := range errs {
+		fields = append(fields, e.Field)
+	}
+	if !reflect.DeepEqual(expected, fields) {
+		t.Errorf("expected errors for %v, got %v", expected, errs)
+	}
+}
+
+func TestValidateConfiguration(t *testing.T) {
+	c, err := Parse([]byte(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := ecctesting.NewPolicySpec().
+		WithConfiguration(ecc.EnterpriseContractPolicyConfiguration{Exclude: []string{"cve"}}).
+		Policy("acme", "policy")
+
+	errs := c.Validate(policy, nil, map[string]string{"env": "prod"}, time.Now())
+	if len(errs) != 1 || errs[0].Field != "spec.configuration.exclude[0]" {
+		t.Errorf("expected an error for the deprecated configuration, got %v", errs)
+	}
+	if !strings.Contains(errs.ToAggregate().Error(), `governance rule "prod"`) {
+		t.Errorf("expected the prod rule in the error, got %v", errs)
+	}
+}
+
+func TestValidateNoConfig(t *testing.T) {
+	var c *Config
+	policy := ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").WithExclude("cve")).Policy("acme", "policy")
+
+	if errs := c.Validate(policy, nil, nil, time.Now()); len(errs) != 0 {
+		t.Errorf("expected no errors without a configuration, got %v", errs)
+	}
+}
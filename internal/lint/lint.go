@@ -0,0 +1,358 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint analyzes the rule configuration of EnterpriseContractPolicy
+// resources for conflicting and redundant entries across the configuration of
+// the policy, the config of the sources and their volatile config.
+//
+// Values are compared as written: collections are not expanded and the images
+// matched by volatile criteria are only considered the same when the criteria
+// name the same images in the same way.
+package lint
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// Kind is the kind of a finding
+type Kind string
+
+const (
+	// KindConflict is a value both included and excluded
+	KindConflict Kind = "Conflict"
+	// KindShadowed is an entry without effect because of another entry
+	KindShadowed Kind = "Shadowed"
+	// KindDuplicate is volatile criteria identical to other criteria
+	KindDuplicate Kind = "Duplicate"
+	// KindOverlapping is volatile criteria of the same value and images with
+	// an effective period overlapping the one of other criteria
+	KindOverlapping Kind = "Overlapping"
+	// KindUnreachable is volatile criteria with an effective period in which
+	// it can never apply
+	KindUnreachable Kind = "Unreachable"
+)
+
+// Finding is a conflicting or redundant entry of the rule configuration
+type Finding struct {
+	Kind Kind `json:"kind"`
+	// Field is the path of the entry, e.g. spec.sources[0].config.exclude[1]
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s", f.Field, f.Message)
+}
+
+// entry is a value of the rule configuration and its path
+type entry struct {
+	value string
+	path  *field.Path
+}
+
+// criteria is volatile criteria, its path and its parsed effective period
+type criteria struct {
+	ecc.VolatileCriteria
+	path      *field.Path
+	on, until *time.Time
+}
+
+// Analyze reports the conflicting and redundant entries of the rule
+// configuration of the policy, in the order of the sources and their entries
+func Analyze(spec ecc.EnterpriseContractPolicySpec) []Finding {
+	var findings []Finding
+
+	if spec.Configuration != nil && len(spec.Sources) > 0 && allConfigured(spec.Sources) {
+		findings = append(findings, Finding{
+			Kind:    KindShadowed,
+			Field:   field.NewPath("spec", "configuration").String(),
+			Message: "the configuration is not used, all sources have a config of their own",
+		})
+	}
+
+	for i, s := range spec.Sources {
+		path := field.NewPath("spec", "sources").Index(i)
+		include, exclude := static(spec, s, path)
+
+		findings = append(findings, conflicts(include, exclude)...)
+
+		if s.VolatileConfig == nil {
+			continue
+		}
+
+		volatilePath := path.Child("volatileConfig")
+		var volatileInclude, volatileExclude []criteria
+		volatileInclude, findings = reachable(s.VolatileConfig.Include, volatilePath.Child("include"), findings)
+		volatileExclude, findings = reachable(s.VolatileConfig.Exclude, volatilePath.Child("exclude"), findings)
+
+		findings = append(findings, redundant(volatileInclude)...)
+		findings = append(findings, redundant(volatileExclude)...)
+		findings = append(findings, againstStatic(volatileInclude, include, exclude, "included", "excluded")...)
+		findings = append(findings, againstStatic(volatileExclude, exclude, include, "excluded", "included")...)
+
+		decided := append(append([]entry(nil), include...), exclude...)
+		findings = append(findings, volatileConflicts(undecided(volatileInclude, decided), undecided(volatileExclude, decided))...)
+	}
+
+	return findings
+}
+
+// allConfigured reports whether all sources have a config, leaving the
+// configuration of the policy unused
+func allConfigured(sources []ecc.Source) bool {
+	for _, s := range sources {
+		if s.Config == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// static returns the includes and excludes of the source, from its config or
+// falling back to the configuration of the policy
+func static(spec ecc.EnterpriseContractPolicySpec, s ecc.Source, path *field.Path) ([]entry, []entry) {
+	switch {
+	case s.Config != nil:
+		path = path.Child("config")
+		return entries(s.Config.Include, path.Child("include")), entries(s.Config.Exclude, path.Child("exclude"))
+	case spec.Configuration != nil:
+		path = field.NewPath("spec", "configuration")
+		include := entries(spec.Configuration.Include, path.Child("include"))
+		for j, c := range spec.Configuration.Collections {
+			include = append(include, entry{value: ecc.CollectionPrefix + c, path: path.Child("collections").Index(j)})
+		}
+		return include, entries(spec.Configuration.Exclude, path.Child("exclude"))
+	}
+
+	return nil, nil
+}
+
+func entries(values []string, path *field.Path) []entry {
+	e := make([]entry, 0, len(values))
+	for j, v := range values {
+		e = append(e, entry{value: v, path: path.Index(j)})
+	}
+
+	return e
+}
+
+// conflicts reports the excludes of values also included
+func conflicts(include, exclude []entry) []Finding {
+	var findings []Finding
+	for _, e := range exclude {
+		for _, i := range include {
+			if i.value == e.value {
+				findings = append(findings, Finding{
+					Kind:    KindConflict,
+					Field:   e.path.String(),
+					Message: fmt.Sprintf("%q is also included by %s, the include takes precedence", e.value, i.path),
+				})
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+// reachable parses the effective periods of the volatile criteria, reporting
+// the criteria that can never apply and returning the others
+func reachable(volatile []ecc.VolatileCriteria, path *field.Path, findings []Finding) ([]criteria, []Finding) {
+	parsed := make([]criteria, 0, len(volatile))
+	for j, v := range volatile {
+		c := criteria{VolatileCriteria: v, path: path.Index(j)}
+
+		var err error
+		if c.on, err = parseTime(v.EffectiveOn); err != nil {
+			findings = append(findings, unreachable(c.path.Child("effectiveOn"), fmt.Sprintf("%q is not an RFC 3339 time, the criteria never applies", v.EffectiveOn)))
+			continue
+		}
+		if c.until, err = parseTime(v.EffectiveUntil); err != nil {
+			findings = append(findings, unreachable(c.path.Child("effectiveUntil"), fmt.Sprintf("%q is not an RFC 3339 time, the criteria never applies", v.EffectiveUntil)))
+			continue
+		}
+		if c.on != nil && c.until != nil && !c.until.After(*c.on) {
+			findings = append(findings, unreachable(c.path.Child("effectiveUntil"), fmt.Sprintf("%s is not after effectiveOn %s, the criteria never applies", v.EffectiveUntil, v.EffectiveOn)))
+			continue
+		}
+
+		parsed = append(parsed, c)
+	}
+
+	return parsed, findings
+}
+
+func unreachable(path *field.Path, message string) Finding {
+	return Finding{Kind: KindUnreachable, Field: path.String(), Message: message}
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// redundant reports the volatile criteria duplicating, or overlapping in
+// time with, earlier criteria of the same value and images in the list
+func redundant(volatile []criteria) []Finding {
+	var findings []Finding
+	for j, c := range volatile {
+		for _, earlier := range volatile[:j] {
+			if !sameScope(c, earlier) || !overlap(c, earlier) {
+				continue
+			}
+
+			if equalTime(c.on, earlier.on) && equalTime(c.until, earlier.until) {
+				findings = append(findings, Finding{
+					Kind:    KindDuplicate,
+					Field:   c.path.String(),
+					Message: fmt.Sprintf("duplicates %s", earlier.path),
+				})
+			} else {
+				findings = append(findings, Finding{
+					Kind:    KindOverlapping,
+					Field:   c.path.String(),
+					Message: fmt.Sprintf("the effective period of %q overlaps with the one of %s", c.Value, earlier.path),
+				})
+			}
+			break
+		}
+	}
+
+	return findings
+}
+
+// volatileConflicts reports the volatile excludes of values included by
+// volatile criteria for the same images at the same time
+func volatileConflicts(include, exclude []criteria) []Finding {
+	var findings []Finding
+	for _, e := range exclude {
+		for _, i := range include {
+			if sameScope(e, i) && overlap(e, i) {
+				findings = append(findings, Finding{
+					Kind:    KindConflict,
+					Field:   e.path.String(),
+					Message: fmt.Sprintf("%q is also included by %s while both apply, the include takes precedence", e.Value, i.path),
+				})
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+// againstStatic reports the volatile criteria of values the static
+// configuration always does the same with, as shadowed, or the opposite with,
+// as conflicting
+func againstStatic(volatile []criteria, same, opposite []entry, done, undone string) []Finding {
+	var findings []Finding
+	for _, c := range volatile {
+		if e, ok := find(same, c.Value); ok {
+			findings = append(findings, Finding{
+				Kind:    KindShadowed,
+				Field:   c.path.String(),
+				Message: fmt.Sprintf("%q is always %s by %s, the volatile criteria has no effect", c.Value, done, e.path),
+			})
+			continue
+		}
+
+		if e, ok := find(opposite, c.Value); ok {
+			findings = append(findings, Finding{
+				Kind:    KindConflict,
+				Field:   c.path.String(),
+				Message: fmt.Sprintf("%q is also %s by %s, the include takes precedence", c.Value, undone, e.path),
+			})
+		}
+	}
+
+	return findings
+}
+
+// undecided returns the volatile criteria of values the static configuration
+// neither includes nor excludes, the others are reported against the static
+// configuration already
+func undecided(volatile []criteria, static []entry) []criteria {
+	var remaining []criteria
+	for _, c := range volatile {
+		if _, ok := find(static, c.Value); !ok {
+			remaining = append(remaining, c)
+		}
+	}
+
+	return remaining
+}
+
+func find(entries []entry, value string) (entry, bool) {
+	for _, e := range entries {
+		if e.value == value {
+			return e, true
+		}
+	}
+
+	return entry{}, false
+}
+
+// sameScope reports whether the volatile criteria are of the same value and
+// apply to the same images and attributes
+func sameScope(a, b criteria) bool {
+	return a.Value == b.Value &&
+		strings.EqualFold(digest(a.VolatileCriteria), digest(b.VolatileCriteria)) &&
+		a.ImageUrl == b.ImageUrl &&
+		a.Component == b.Component &&
+		a.Application == b.Application &&
+		(len(a.Attributes) == 0 && len(b.Attributes) == 0 || reflect.DeepEqual(a.Attributes, b.Attributes))
+}
+
+// digest returns the image digest of the criteria, by the deprecated ImageRef
+// when ImageDigest is not set
+func digest(c ecc.VolatileCriteria) string {
+	if c.ImageDigest != "" {
+		return c.ImageDigest
+	}
+
+	return c.ImageRef
+}
+
+// overlap reports whether the effective periods of the criteria overlap, an
+// unset start or end is unbounded
+func overlap(a, b criteria) bool {
+	return (a.on == nil || b.until == nil || a.on.Before(*b.until)) &&
+		(b.on == nil || a.until == nil || b.on.Before(*a.until))
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equal(*b)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	june := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	july := june.AddDate(0, 1, 0)
+	august := july.AddDate(0, 1, 0)
+	criteria := ecctesting.NewVolatileCriteria
+
+	cases := []struct {
+		name     string
+		spec     *ecctesting.PolicySpecBuilder
+		expected []Finding
+	}{
+		{
+			name: "consistent",
+			spec: ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").
+				WithInclude("@minimal").
+				WithExclude("cve").
+				WithVolatileExclude(
+					criteria("tasks").EffectiveUntil(july),
+					criteria("tasks").EffectiveOn(july).EffectiveUntil(august),
+					criteria("sbom").ForComponent("api"),
+					criteria("sbom").ForComponent("ui"),
+				)),
+		},
+		{
+			name: "static conflict",
+			spec: ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").WithInclude("cve", "tasks").WithExclude("tasks")),
+			expected: []Finding{
+				{Kind: KindConflict, Field: "spec.sources[0].config.exclude[0]", Message: `"tasks" is also included by spec.sources[0].config.include[1], the include takes precedence`},
+			},
+		},
+		{
+			name: "conflict with the configuration",
+			spec: ecctesting.NewPolicySpec().
+				WithConfiguration(ecc.EnterpriseContractPolicyConfiguration{Exclude: []string{"cve", "@minimal"}, Collections: []string{"minimal"}}).
+				WithSources(ecctesting.NewSource("a").WithVolatileInclude(criteria("cve"))),
+			expected: []Finding{
+				{Kind: KindConflict, Field: "spec.configuration.exclude[1]", Message: `"@minimal" is also included by spec.configuration.collections[0], the include takes precedence`},
+				{Kind: KindConflict, Field: "spec.sources[0].volatileConfig.include[0]", Message: `"cve" is also excluded by spec.configuration.exclude[0], the include takes precedence`},
+			},
+		},
+		{
+			name: "unused configuration",
+			spec: ecctesting.NewPolicySpec().
+				WithConfiguration(ecc.EnterpriseContractPolicyConfiguration{Exclude: []string{"cve"}}).
+				WithSources(ecctesting.NewSource("a").WithExclude("tasks")),
+			expected: []Finding{
+				{Kind: KindShadowed, Field: "spec.configuration", Message: "the configuration is not used, all sources have a config of their own"},
+			},
+		},
+		{
+			name: "shadowed volatile criteria",
+			spec: ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").
+				WithInclude("cve").
+				WithExclude("tasks").
+				WithVolatileInclude(criteria("cve").EffectiveUntil(july)).
+				WithVolatileExclude(criteria("tasks").ForComponent("api"), criteria("cve"))),
+			expected: []Finding{
+				{Kind: KindShadowed, Field: "spec.sources[0].volatileConfig.include[0]", Message: `"cve" is always included by spec.sources[0].config.include[0], the volatile criteria has no effect`},
+				{Kind: KindShadowed, Field: "spec.sources[0].volatileConfig.exclude[0]", Message: `"tasks" is always excluded by spec.sources[0].config.exclude[0], the volatile criteria has no effect`},
+				{Kind: KindConflict, Field: "spec.sources[0].volatileConfig.exclude[1]", Message: `"cve" is also included by spec.sources[0].config.include[0], the include takes precedence`},
+			},
+		},
+		{
+			name: "duplicate and overlapping criteria",
+			spec: ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").WithVolatileExclude(
+				criteria("cve").ForImageDigest("sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef").EffectiveUntil(july),
+				criteria("cve").ForImageDigest("sha256:0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF").EffectiveUntil(july).WithReference("https://issues.acme.com/browse/SEC-1"),
+				criteria("tasks").EffectiveOn(june).EffectiveUntil(august),
+				criteria("tasks").EffectiveOn(july),
+			)),
+			expected: []Finding{
+				{Kind: KindDuplicate, Field: "spec.sources[0].volatileConfig.exclude[1]", Message: "duplicates spec.sources[0].volatileConfig.exclude[0]"},
+				{Kind: KindOverlapping, Field: "spec.sources[0].volatileConfig.exclude[3]", Message: `the effective period of "tasks" overlaps with the one of spec.sources[0].volatileConfig.exclude[2]`},
+			},
+		},
+		{
+			name: "volatile conflict",
+			spec: ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").
+				WithVolatileInclude(criteria("cve").ForImageUrl("quay.io/acme/app").EffectiveUntil(august), criteria("tasks").EffectiveUntil(june)).
+				WithVolatileExclude(criteria("cve").ForImageUrl("quay.io/acme/app").EffectiveOn(july), criteria("tasks").EffectiveOn(june))),
+			expected: []Finding{
+				{Kind: KindConflict, Field: "spec.sources[0].volatileConfig.exclude[0]", Message: `"cve" is also included by spec.sources[0].volatileConfig.include[0] while both apply, the include takes precedence`},
+			},
+		},
+		{
+			name: "unreachable criteria",
+			spec: ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").
+				WithInclude("cve").
+				WithVolatileExclude(criteria("cve").EffectiveOn(july).EffectiveUntil(june), criteria("tasks").EffectiveOn(july).EffectiveUntil(july))),
+			expected: []Finding{
+				{Kind: KindUnreachable, Field: "spec.sources[0].volatileConfig.exclude[0].effectiveUntil", Message: "2025-06-01T00:00:00Z is not after effectiveOn 2025-07-01T00:00:00Z, the criteria never applies"},
+				{Kind: KindUnreachable, Field: "spec.sources[0].volatileConfig.exclude[1].effectiveUntil", Message: "2025-07-01T00:00:00Z is not after effectiveOn 2025-07-01T00:00:00Z, the criteria never applies"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := Analyze(c.spec.Build())
+			if !reflect.DeepEqual(c.expected, got) {
+				t.Errorf("expected findings:\n%v\ngot:\n%v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestAnalyzeInvalidTime(t *testing.T) {
+	spec := ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").WithVolatileExclude(ecctesting.NewVolatileCriteria("cve"))).Build()
+	spec.Sources[0].VolatileConfig.Exclude[0].EffectiveOn = "tomorrow"
+
+	expected := []Finding{{Kind: KindUnreachable, Field: "spec.sources[0].volatileConfig.exclude[0].effectiveOn", Message: `"tomorrow" is not an RFC 3339 time, the criteria never applies`}}
+	if got := Analyze(spec); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metapolicy evaluates meta-policies, Rego rules platform teams write
+// about the contents of EnterpriseContractPolicy resources, e.g. that all
+// policy sources come from the registry of the organization.
+//
+// Meta-policies are in the meta package and produce messages with deny and
+// warn rules, as strings or as objects with a msg attribute:
+//
+//	package meta
+//
+//	import rego.v1
+//
+//	deny contains msg if {
+//		some source in input.policy.spec.sources
+//		some url in source.policy
+//		not startswith(url, "oci::quay.io/acme/")
+//		msg := sprintf("policy source %s is not from quay.io/acme", [url])
+//	}
+package metapolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// ModuleSuffix is the suffix of the keys of the ConfigMap holding Rego
+// modules
+const ModuleSuffix = ".rego"
+
+// Package is the package of the deny and warn rules
+const Package = "meta"
+
+// forbiddenBuiltins reach out of the webhook, meta-policies are evaluated on
+// the contents of the policy only
+var forbiddenBuiltins = map[string]bool{
+	"http.send":          true,
+	"net.lookup_ip_addr": true,
+	"opa.runtime":        true,
+}
+
+// Input is the input meta-policies are evaluated with
+type Input struct {
+	// Operation is CREATE or UPDATE
+	Operation string `json:"operation"`
+	// Policy is the policy being admitted
+	Policy map[string]any `json:"policy"`
+	// OldPolicy is the policy before an update
+	OldPolicy map[string]any `json:"oldPolicy,omitempty"`
+	// Namespace is the namespace of the policy
+	Namespace Namespace `json:"namespace"`
+}
+
+// Namespace is the namespace of the policy in the input
+type Namespace struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// NewInput returns the input of the policy being created, or updated when old
+// is not nil
+func NewInput(policy, old *ecc.EnterpriseContractPolicy, namespaceLabels map[string]string) (Input, error) {
+	input := Input{
+		Operation: "CREATE",
+		Namespace: Namespace{Name: policy.Namespace, Labels: namespaceLabels},
+	}
+
+	var err error
+	if input.Policy, err = runtime.DefaultUnstructuredConverter.ToUnstructured(policy); err != nil {
+		return Input{}, fmt.Errorf("unable to convert the policy: %w", err)
+	}
+
+	if old != nil {
+		input.Operation = "UPDATE"
+		if input.OldPolicy, err = runtime.DefaultUnstructuredConverter.ToUnstructured(old); err != nil {
+			return Input{}, fmt.Errorf("unable to convert the old policy: %w", err)
+		}
+	}
+
+	return input, nil
+}
+
+// Result holds the messages of the deny and warn rules, sorted
+type Result struct {
+	Deny []string
+	Warn []string
+}
+
+// Policy is a compiled meta-policy
+type Policy struct {
+	query rego.PreparedEvalQuery
+}
+
+// Compile compiles the Rego modules, by file name, into a meta-policy. At
+// least one of the modules is in the meta package.
+func Compile(ctx context.Context, modules map[string]string) (*Policy, error) {
+	if len(modules) == 0 {
+		return nil, errors.New("no Rego modules")
+	}
+
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	path := ast.Ref{ast.DefaultRootDocument, ast.StringTerm(Package)}
+	options := []func(*rego.Rego){
+		rego.Query("result = " + path.String()),
+		rego.Capabilities(capabilities()),
+		rego.StrictBuiltinErrors(true),
+	}
+
+	found := false
+	for _, name := range names {
+		module, err := ast.ParseModule(name, modules[name])
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", name, err)
+		}
+		if module == nil {
+			return nil, fmt.Errorf("%s holds no Rego module", name)
+		}
+		found = found || module.Package.Path.Equal(path)
+		options = append(options, rego.ParsedModule(module))
+	}
+
+	if !found {
+		return nil, fmt.Errorf("none of the Rego modules is in the %s package", Package)
+	}
+
+	query, err := rego.New(options...).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile the meta-policy: %w", err)
+	}
+
+	return &Policy{query: query}, nil
+}
+
+// Evaluate evaluates the deny and warn rules of the meta-policy
+func (p *Policy) Evaluate(ctx context.Context, input Input) (Result, error) {
+	results, err := p.query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return Result{}, fmt.Errorf("unable to evaluate the meta-policy: %w", err)
+	}
+
+	result := Result{}
+	if len(results) == 0 {
+		return result, nil
+	}
+
+	document, ok := results[0].Bindings["result"].(map[string]any)
+	if !ok {
+		return result, nil
+	}
+
+	if result.Deny, err = messages(document, "deny"); err != nil {
+		return Result{}, err
+	}
+	if result.Warn, err = messages(document, "warn"); err != nil {
+		return Result{}, err
+	}
+
+	return result, nil
+}
+
+// messages returns the messages of the rule, strings or objects with a msg
+// attribute
+func messages(document map[string]any, rule string) ([]string, error) {
+	value, ok := document[rule]
+	if !ok {
+		return nil, nil
+	}
+
+	values, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("the %s rule of the meta-policy is not a set of messages", rule)
+	}
+
+	var msgs []string
+	for _, v := range values {
+		switch m := v.(type) {
+		case string:
+			msgs = append(msgs, m)
+		case map[string]any:
+			msg, ok := m["msg"].(string)
+			if !ok {
+				return nil, fmt.Errorf("the %s rule of the meta-policy produced an object without a msg string", rule)
+			}
+			msgs = append(msgs, msg)
+		default:
+			return nil, fmt.Errorf("the %s rule of the meta-policy produced %v, expected a string or an object with a msg", rule, v)
+		}
+	}
+	sort.Strings(msgs)
+
+	return msgs, nil
+}
+
+// capabilities returns the capabilities of OPA without the builtins reaching
+// out of the webhook
+func capabilities() *ast.Capabilities {
+	c := ast.CapabilitiesForThisVersion()
+
+	builtins := c.Builtins[:0]
+	for _, b := range c.Builtins {
+		if !forbiddenBuiltins[b.Name] {
+			builtins = append(builtins, b)
+		}
+	}
+	c.Builtins = builtins
+
+	return c
+}
+
+// Modules returns the Rego modules in the data of a ConfigMap, the values of
+// the keys with the .rego suffix
+func Modules(data map[string]string) map[string]string {
+	modules := map[string]string{}
+	for k, v := range data {
+		if strings.HasSuffix(k, ModuleSuffix) {
+			modules[k] = v
+		}
+	}
+
+	return modules
+}
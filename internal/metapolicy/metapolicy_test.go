@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metapolicy
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+)
+
+const sources = `package meta
+
+import rego.v1
+
+deny contains msg if {
+	some source in input.policy.spec.sources
+	some url in source.policy
+	not startswith(url, "oci::quay.io/acme/")
+	msg := sprintf("policy source %s is not from quay.io/acme", [url])
+}
+
+warn contains {"msg": "the policy has no description"} if {
+	not input.policy.spec.description
+}
+`
+
+const rekor = `package meta
+
+import rego.v1
+
+deny contains "the Rekor URL must be https://rekor.acme.com" if {
+	input.namespace.labels.env == "prod"
+	input.policy.spec.rekorUrl != "https://rekor.acme.com"
+}
+
+warn contains "the Rekor URL changed" if {
+	input.operation == "UPDATE"
+	input.oldPolicy.spec.rekorUrl != input.policy.spec.rekorUrl
+}
+`
+
+func TestEvaluate(t *testing.T) {
+	ctx := context.Background()
+
+	p, err := Compile(ctx, map[string]string{"sources.rego": sources, "rekor.rego": rekor})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compliant := ecctesting.NewPolicySpec().
+		WithDescription("release").
+		WithRekorUrl("https://rekor.acme.com").
+		WithSources(ecctesting.NewSource("a").WithPolicy("oci::quay.io/acme/policy:v1")).
+		Policy("acme", "policy")
+
+	other := ecctesting.NewPolicySpec().
+		WithRekorUrl("https://rekor.sigstore.dev").
+		WithSources(ecctesting.NewSource("a").WithPolicy("oci::quay.io/acme/policy:v1", "git::https://github.com/other/policy")).
+		Policy("acme", "policy")
+
+	cases := []struct {
+		name     string
+		policy   *ecc.EnterpriseContractPolicy
+		old      *ecc.EnterpriseContractPolicy
+		labels   map[string]string
+		expected Result
+	}{
+		{name: "compliant", policy: compliant, labels: map[string]string{"env": "prod"}},
+		{
+			name:   "other sources and Rekor in prod",
+			policy: other,
+			labels: map[string]string{"env": "prod"},
+			expected: Result{
+				Deny: []string{"policy source git::https://github.com/other/policy is not from quay.io/acme", "the Rekor URL must be https://rekor.acme.com"},
+				Warn: []string{"the policy has no description"},
+			},
+		},
+		{
+			name:   "update",
+			policy: other,
+			old:    compliant,
+			expected: Result{
+				Deny: []string{"policy source git::https://github.com/other/policy is not from quay.io/acme"},
+				Warn: []string{"the Rekor URL changed", "the policy has no description"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			input, err := NewInput(c.policy, c.old, c.labels)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := p.Evaluate(ctx, input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(c.expected, got) {
+				t.Errorf("expected %#v, got %#v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestEvaluateInvalidMessages(t *testing.T) {
+	ctx := context.Background()
+
+	p, err := Compile(ctx, map[string]string{"a.rego": "package meta\n\ndeny[{\"message\": \"a\"}] { true }\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	input, err := NewInput(ecctesting.MinimalPolicySpec().Policy("acme", "policy"), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := p.Evaluate(ctx, input); err == nil || !strings.Contains(err.Error(), "without a msg string") {
+		t.Errorf("expected an error about the message, got %v", err)
+	}
+}
+
+func TestCompile(t *testing.T) {
+	cases := []struct {
+		name    string
+		modules map[string]string
+		err     string
+	}{
+		{name: "no modules", err: "no Rego modules"},
+		{name: "syntax error", modules: map[string]string{"a.rego": "package meta\n\ndeny contains"}, err: "unable to parse a.rego"},
+		{name: "other package", modules: map[string]string{"a.rego": "package other\n\ndeny[\"a\"] { true }\n"}, err: "none of the Rego modules is in the meta package"},
+		{name: "http.send", modules: map[string]string{"a.rego": "package meta\n\ndeny[r.body] { r := http.send({\"method\": \"get\", \"url\": \"https://acme.com\"}) }\n"}, err: "http.send"},
+		{name: "helper package", modules: map[string]string{"a.rego": "package meta\n\nimport data.lib\n\ndeny[\"a\"] { lib.bad }\n", "lib.rego": "package lib\n\nbad { true }\n"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := Compile(context.Background(), c.modules)
+			if c.err == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) {
+				t.Fatalf("expected an error containing %q, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestModules(t *testing.T) {
+	got := Modules(map[string]string{"a.rego": "a", "README.md": "b", "b.rego": "c"})
+	if !reflect.DeepEqual(map[string]string{"a.rego": "a", "b.rego": "c"}, got) {
+		t.Errorf("unexpected modules %v", got)
+	}
+}
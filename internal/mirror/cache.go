@@ -0,0 +1,251 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mirror keeps a content-addressed cache of fetched policy rules and
+// data and serves it over HTTP, so that policy runners unable to reach the
+// original sources can fetch them from the cluster.
+//
+// Each entry is a gzipped tar archive of the fetched directory, named after
+// the SHA-256 digest of the archive and served at
+// /sources/sha256/<hex>.tar.gz. Archives are reproducible, the same content
+// always yields the same digest.
+package mirror
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// PathPrefix is the path the cached archives are served under
+	PathPrefix = "/sources/sha256/"
+	// archiveExtension makes go-getter unpack the archive when fetching
+	archiveExtension = ".tar.gz"
+)
+
+// Cache is a content-addressed cache of archives of policy rules and data
+type Cache struct {
+	dir string
+	url string
+}
+
+// NewCache returns a cache keeping the archives in the given directory,
+// reachable by policy runners at the given base URL
+func NewCache(dir, url string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create the mirror directory: %w", err)
+	}
+
+	return &Cache{dir: dir, url: strings.TrimSuffix(url, "/")}, nil
+}
+
+// Store adds an archive of the content of the directory to the cache,
+// returning the URL it is served at. The directory must be within root, the
+// directory the content was fetched to, so that no other files are served.
+// Storing content already in the cache marks it as recently used.
+func (c *Cache) Store(root, dir string) (string, error) {
+	if err := within(root, dir); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "archive-")
+	if err != nil {
+		return "", fmt.Errorf("unable to create an archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h := sha256.New()
+	if err := archive(dir, io.MultiWriter(tmp, h)); err != nil {
+		return "", fmt.Errorf("unable to archive %s: %w", dir, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("unable to write the archive of %s: %w", dir, err)
+	}
+
+	name := hex.EncodeToString(h.Sum(nil)) + archiveExtension
+	dst := filepath.Join(c.dir, name)
+	if _, err := os.Stat(dst); err == nil {
+		now := time.Now()
+		if err := os.Chtimes(dst, now, now); err != nil {
+			return "", fmt.Errorf("unable to mark %s as used: %w", name, err)
+		}
+	} else if err := os.Rename(tmp.Name(), dst); err != nil {
+		return "", fmt.Errorf("unable to add %s to the cache: %w", name, err)
+	}
+
+	return c.url + PathPrefix + name, nil
+}
+
+// within returns an error unless dir is root or a directory within it, once
+// symbolic links are resolved
+func within(root, dir string) error {
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return fmt.Errorf("unable to resolve %s: %w", root, err)
+	}
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return fmt.Errorf("unable to resolve %s: %w", dir, err)
+	}
+
+	rel, err := filepath.Rel(resolvedRoot, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return fmt.Errorf("%s is not within %s, only fetched content is mirrored", dir, root)
+	}
+
+	return nil
+}
+
+// Prune removes the archives neither stored nor served within the given
+// duration
+func (c *Cache) Prune(maxAge time.Duration) error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("unable to read the mirror directory: %w", err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	var errs []error
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), archiveExtension) {
+			continue
+		}
+
+		info, err := e.Info()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if info.ModTime().Before(cutoff) {
+			if err := os.Remove(filepath.Join(c.dir, e.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// path returns the path of the archive with the given name, or an empty
+// string if the name is not that of an archive
+func (c *Cache) path(name string) string {
+	digest, ok := strings.CutSuffix(name, archiveExtension)
+	if !ok || len(digest) != sha256.Size*2 || strings.ToLower(digest) != digest {
+		return ""
+	}
+	if _, err := hex.DecodeString(digest); err != nil {
+		return ""
+	}
+
+	return filepath.Join(c.dir, name)
+}
+
+// archive writes a reproducible gzipped tar archive of the directory,
+// leaving out the .git directory
+func archive(dir string, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	// WalkDir visits the entries in lexical order
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		hdr := tar.Header{
+			Name:    filepath.ToSlash(rel),
+			ModTime: time.Unix(0, 0),
+			Format:  tar.FormatPAX,
+		}
+
+		switch {
+		case d.IsDir():
+			hdr.Typeflag = tar.TypeDir
+			hdr.Name += "/"
+			hdr.Mode = 0o755
+			return tw.WriteHeader(&hdr)
+		case d.Type().IsRegular():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			hdr.Typeflag = tar.TypeReg
+			hdr.Mode = 0o644
+			hdr.Size = info.Size()
+		default:
+			// symbolic links and special files are not mirrored
+			return nil
+		}
+
+		if err := tw.WriteHeader(&hdr); err != nil {
+			return err
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
+
+// archiveName returns the name of the archive requested by the URL path
+func archiveName(urlPath string) (string, bool) {
+	name, ok := strings.CutPrefix(path.Clean(urlPath), PathPrefix)
+	if !ok || strings.Contains(name, "/") {
+		return "", false
+	}
+
+	return name, true
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mirror
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func newCache(t *testing.T) *Cache {
+	t.Helper()
+
+	c, err := NewCache(t.TempDir(), "https://mirror.svc:8444/")
+	if err != nil {
+		t.Fatalf("unexpected error creating the cache: %v", err)
+	}
+
+	return c
+}
+
+func store(t *testing.T, c *Cache, dir string) string {
+	t.Helper()
+
+	url, err := c.Store(dir, dir)
+	if err != nil {
+		t.Fatalf("unexpected error storing %s: %v", dir, err)
+	}
+
+	return url
+}
+
+func unpack(t *testing.T, r io.Reader) map[string]string {
+	t.Helper()
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatalf("unexpected error reading the archive: %v", err)
+	}
+
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error reading the archive: %v", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = string(content)
+	}
+
+	return files
+}
+
+func TestStore(t *testing.T) {
+	c := newCache(t)
+
+	files := map[string]string{
+		"policy/release.rego": "package release",
+		"policy/lib.rego":     "package lib",
+		".git/HEAD":           "ref: refs/heads/main",
+	}
+	first := store(t, c, writeFiles(t, files))
+
+	if !strings.HasPrefix(first, "https://mirror.svc:8444/sources/sha256/") || !strings.HasSuffix(first, ".tar.gz") {
+		t.Errorf("unexpected mirror URL %q", first)
+	}
+
+	// the same content in another directory is stored once
+	time.Sleep(10 * time.Millisecond)
+	if second := store(t, c, writeFiles(t, files)); second != first {
+		t.Errorf("expected the same content to be stored at the same URL, got %q and %q", first, second)
+	}
+
+	if other := store(t, c, writeFiles(t, map[string]string{"policy/release.rego": "package other"})); other == first {
+		t.Errorf("expected different content to be stored at a different URL, got %q", other)
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected two archives in the cache, got %v", entries)
+	}
+
+	f, err := os.Open(filepath.Join(c.dir, filepath.Base(first)))
+	if err != nil {
+		t.Fatalf("unexpected error opening the archive: %v", err)
+	}
+	defer f.Close()
+
+	expected := map[string]string{
+		"policy/release.rego": "package release",
+		"policy/lib.rego":     "package lib",
+	}
+	if got := unpack(t, f); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected archived files %v, got %v", expected, got)
+	}
+}
+
+func TestStoreOutsideRoot(t *testing.T) {
+	c := newCache(t)
+	root := writeFiles(t, map[string]string{"policy/release.rego": "package release"})
+	outside := writeFiles(t, map[string]string{"token": "secret"})
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Store(root, filepath.Join(root, "policy")); err != nil {
+		t.Errorf("unexpected error storing a directory within the root: %v", err)
+	}
+
+	for _, dir := range []string{outside, filepath.Join(root, ".."), filepath.Join(root, "link")} {
+		if _, err := c.Store(root, dir); err == nil || !strings.Contains(err.Error(), "only fetched content is mirrored") {
+			t.Errorf("expected %s not to be stored, got %v", dir, err)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	c := newCache(t)
+
+	old := store(t, c, writeFiles(t, map[string]string{"a.rego": "package a"}))
+	recent := store(t, c, writeFiles(t, map[string]string{"b.rego": "package b"}))
+
+	past := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filepath.Join(c.dir, filepath.Base(old)), past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Prune(time.Hour); err != nil {
+		t.Fatalf("unexpected error pruning: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(c.dir, filepath.Base(old))); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be pruned, got %v", old, err)
+	}
+	if _, err := os.Stat(filepath.Join(c.dir, filepath.Base(recent))); err != nil {
+		t.Errorf("expected %s to be kept, got %v", recent, err)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	c := newCache(t)
+	url := store(t, c, writeFiles(t, map[string]string{"data/rule_data.yml": "rule_data: {}"}))
+	path := strings.TrimPrefix(url, c.url)
+
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if expected := map[string]string{"data/rule_data.yml": "rule_data: {}"}; !reflect.DeepEqual(expected, unpack(t, resp.Body)) {
+		t.Error("unexpected content served")
+	}
+
+	for _, p := range []string{
+		PathPrefix + strings.Repeat("0", 64) + ".tar.gz",
+		PathPrefix + "../" + filepath.Base(path),
+		PathPrefix + "archive-123",
+		"/other/" + filepath.Base(path),
+	} {
+		resp, err := http.Get(srv.URL + p)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("expected status 404 for %s, got %d", p, resp.StatusCode)
+		}
+	}
+
+	resp, err = http.Post(srv.URL+path, "text/plain", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", resp.StatusCode)
+	}
+}
+
+func TestServeHTTPMarksUsed(t *testing.T) {
+	c := newCache(t)
+	url := store(t, c, writeFiles(t, map[string]string{"a.rego": "package a"}))
+	path := filepath.Join(c.dir, filepath.Base(url))
+
+	past := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(path, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + strings.TrimPrefix(url, c.url))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if err := c.Prune(time.Hour); err != nil {
+		t.Fatalf("unexpected error pruning: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected the served archive to be kept, got %v", err)
+	}
+}
+
+func TestServerStop(t *testing.T) {
+	s := Server{Cache: newCache(t), Addr: "127.0.0.1:0", Retention: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Start(ctx) }()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Error("expected the server to stop")
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ServeHTTP serves the archives in the cache
+func (c *Cache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	name, ok := archiveName(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	p := c.path(name)
+	if p == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	// archives fetched by policy runners are in use, not to be pruned
+	now := time.Now()
+	if err := os.Chtimes(p, now, now); err != nil {
+		log.FromContext(r.Context()).Error(err, "unable to mark the archive as used", "archive", name)
+	}
+
+	// the content of an archive never changes
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", `"`+name+`"`)
+	http.ServeContent(w, r, name, info.ModTime(), f)
+}
+
+// Server serves the cache over HTTP and periodically prunes it, run by the
+// manager
+type Server struct {
+	Cache *Cache
+	// Addr is the address to listen on
+	Addr string
+	// Retention is how long archives are kept after they were last stored or
+	// served
+	Retention time.Duration
+}
+
+var _ manager.Runnable = &Server{}
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// Start serves the cache until the context is done
+func (s *Server) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("mirror")
+
+	mux := http.NewServeMux()
+	mux.Handle(PathPrefix, s.Cache)
+
+	srv := http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ln, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %w", s.Addr, err)
+	}
+
+	go func() {
+		interval := s.Retention / 4
+		if interval <= 0 {
+			return
+		}
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				if err := s.Cache.Prune(s.Retention); err != nil {
+					logger.Error(err, "unable to prune the mirror")
+				}
+			}
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "unable to shut down the mirror server")
+		}
+	}()
+
+	logger.Info("serving the mirror", "address", ln.Addr().String())
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// NeedLeaderElection is false as the cache is served by every replica, for
+// more than one replica the cache directory needs to be on a shared volume
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Writer writes the report in a format
+type Writer func(w io.Writer, r Report) error
+
+// Formats are the formats the report can be written in, by name
+var Formats = map[string]Writer{
+	"json":     WriteJSON,
+	"csv":      WriteCSV,
+	"markdown": WriteMarkdown,
+}
+
+// FormatNames returns the sorted names of the formats
+func FormatNames() []string {
+	names := make([]string, 0, len(Formats))
+	for n := range Formats {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// WriteJSON writes the report as indented JSON
+func WriteJSON(w io.Writer, r Report) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+
+	if err := e.Encode(r); err != nil {
+		return fmt.Errorf("unable to write the report: %w", err)
+	}
+
+	return nil
+}
+
+// WriteCSV writes the entries of the report as CSV with a header row
+func WriteCSV(w io.Writer, r Report) error {
+	c := csv.NewWriter(w)
+
+	rows := [][]string{{
+		"namespace", "policy", "source", "kind", "value", "since", "ageDays", "expires",
+		"neverExpires", "reference", "missingReference", "imageUrl", "imageDigest", "component", "application",
+	}}
+	for _, e := range r.Entries {
+		rows = append(rows, []string{
+			e.Namespace, e.Policy, e.Source, string(e.Kind), e.Value, formatTime(e.Since), formatDays(e.AgeDays), formatTime(e.Expires),
+			strconv.FormatBool(e.NeverExpires), e.Reference, strconv.FormatBool(e.MissingReference), e.ImageUrl, e.ImageDigest, e.Component, e.Application,
+		})
+	}
+
+	if err := c.WriteAll(rows); err != nil {
+		return fmt.Errorf("unable to write the report: %w", err)
+	}
+
+	return nil
+}
+
+// WriteMarkdown writes the report as a Markdown document with a table of the
+// entries, the excludes never expiring and without a reference highlighted
+func WriteMarkdown(w io.Writer, r Report) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Exception debt report\n\nGenerated at %s.\n\n", r.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- Active excludes: %d\n", r.Summary.Total)
+	fmt.Fprintf(&b, "- Never expiring: %d\n", r.Summary.NeverExpiring)
+	fmt.Fprintf(&b, "- Missing reference: %d\n", r.Summary.MissingReference)
+
+	if len(r.Entries) > 0 {
+		b.WriteString("\n| Namespace | Policy | Source | Kind | Exclude | Scope | Age | Expires | Reference |\n")
+		b.WriteString("|---|---|---|---|---|---|---|---|---|\n")
+	}
+	for _, e := range r.Entries {
+		expires := "**never**"
+		if !e.NeverExpires {
+			expires = formatTime(e.Expires)
+		}
+
+		reference := "**missing**"
+		if !e.MissingReference {
+			reference = e.Reference
+		}
+
+		age := formatDays(e.AgeDays)
+		if age != "" {
+			age += "d"
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | `%s` | %s | %s | %s | %s |\n",
+			cell(e.Namespace), cell(e.Policy), cell(e.Source), e.Kind, cell(e.Value), cell(scope(e)), age, expires, cell(reference))
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("unable to write the report: %w", err)
+	}
+
+	return nil
+}
+
+// scope describes the images a volatile exclude is limited to
+func scope(e Entry) string {
+	var s []string
+	for _, p := range []struct{ name, value string }{
+		{"image", e.ImageUrl}, {"digest", e.ImageDigest}, {"component", e.Component}, {"application", e.Application},
+	} {
+		if p.value != "" {
+			s = append(s, p.name+": "+p.value)
+		}
+	}
+
+	return strings.Join(s, ", ")
+}
+
+// cell escapes the Markdown table cell
+func cell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func formatDays(d *int) string {
+	if d == nil {
+		return ""
+	}
+
+	return strconv.Itoa(*d)
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package report aggregates the active excludes of policies into an
+// exception debt report, highlighting the excludes that never expire and the
+// excludes without a Reference to related information.
+package report
+
+import (
+	"sort"
+	"time"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+// Kind is where in the policy an exclude is configured
+type Kind string
+
+const (
+	// KindSource is an exclude of SourceConfig.Exclude
+	KindSource Kind = "source"
+	// KindVolatile is an exclude of VolatileSourceConfig.Exclude
+	KindVolatile Kind = "volatile"
+	// KindConfiguration is an exclude of the deprecated
+	// EnterpriseContractPolicyConfiguration.Exclude
+	KindConfiguration Kind = "configuration"
+)
+
+// Entry is an active exclude of a policy
+type Entry struct {
+	// Namespace of the policy, the owner of the exclude
+	Namespace string `json:"namespace"`
+	// Policy is the name of the policy
+	Policy string `json:"policy"`
+	// Source is the name of the policy source, empty for the deprecated
+	// configuration of the policy or for unnamed sources
+	Source string `json:"source,omitempty"`
+	// Kind is where in the policy the exclude is configured
+	Kind Kind `json:"kind"`
+	// Value is the excluded rule, package or collection
+	Value string `json:"value"`
+	// Since is when the exclude took effect, its effectiveOn or the creation
+	// of the policy when not set, nil when unknown
+	Since *time.Time `json:"since,omitempty"`
+	// AgeDays is the number of whole days since the exclude took effect
+	AgeDays *int `json:"ageDays,omitempty"`
+	// Expires is the effectiveUntil of the exclude, nil when it never expires
+	Expires *time.Time `json:"expires,omitempty"`
+	// NeverExpires is set for excludes without an effectiveUntil
+	NeverExpires bool `json:"neverExpires"`
+	// Reference is the link to related information of the exclude
+	Reference string `json:"reference,omitempty"`
+	// MissingReference is set for excludes without a Reference
+	MissingReference bool `json:"missingReference"`
+	// ImageUrl, ImageDigest, Component and Application are the scope of
+	// volatile excludes
+	ImageUrl    string `json:"imageUrl,omitempty"`
+	ImageDigest string `json:"imageDigest,omitempty"`
+	Component   string `json:"component,omitempty"`
+	Application string `json:"application,omitempty"`
+}
+
+// Summary counts the entries of the report
+type Summary struct {
+	Total            int `json:"total"`
+	NeverExpiring    int `json:"neverExpiring"`
+	MissingReference int `json:"missingReference"`
+}
+
+// Report is the exception debt report
+type Report struct {
+	// GeneratedAt is the time the report is generated at, excludes are active
+	// at that time
+	GeneratedAt time.Time `json:"generatedAt"`
+	Summary     Summary   `json:"summary"`
+	Entries     []Entry   `json:"entries"`
+}
+
+// Generate reports the excludes of the policies active at the given time,
+// sorted by namespace, policy, source and value. Volatile excludes not yet or
+// no longer effective are left out.
+func Generate(policies []ecc.EnterpriseContractPolicy, now time.Time) Report {
+	now = now.UTC()
+	report := Report{GeneratedAt: now, Entries: []Entry{}}
+
+	for _, p := range policies {
+		var created *time.Time
+		if !p.CreationTimestamp.IsZero() {
+			t := p.CreationTimestamp.UTC()
+			created = &t
+		}
+
+		entry := func(source string, kind Kind, value string) Entry {
+			return Entry{
+				Namespace:        p.Namespace,
+				Policy:           p.Name,
+				Source:           source,
+				Kind:             kind,
+				Value:            value,
+				Since:            created,
+				NeverExpires:     true,
+				MissingReference: true,
+			}
+		}
+
+		if p.Spec.Configuration != nil {
+			for _, v := range p.Spec.Configuration.Exclude {
+				report.Entries = append(report.Entries, entry("", KindConfiguration, v))
+			}
+		}
+
+		for _, s := range p.Spec.Sources {
+			if s.Config != nil {
+				for _, v := range s.Config.Exclude {
+					report.Entries = append(report.Entries, entry(s.Name, KindSource, v))
+				}
+			}
+
+			if s.VolatileConfig == nil {
+				continue
+			}
+
+			for _, c := range s.VolatileConfig.Exclude {
+				e := entry(s.Name, KindVolatile, c.Value)
+				if !active(c, now, &e) {
+					continue
+				}
+
+				e.Reference = c.Reference
+				e.MissingReference = c.Reference == ""
+				e.ImageUrl = c.ImageUrl
+				e.ImageDigest = c.ImageDigest
+				if e.ImageDigest == "" {
+					e.ImageDigest = c.ImageRef
+				}
+				e.Component = c.Component
+				e.Application = c.Application
+
+				report.Entries = append(report.Entries, e)
+			}
+		}
+	}
+
+	for i := range report.Entries {
+		e := &report.Entries[i]
+		if e.Since != nil {
+			days := int(now.Sub(*e.Since).Hours() / 24)
+			e.AgeDays = &days
+		}
+
+		report.Summary.Total++
+		if e.NeverExpires {
+			report.Summary.NeverExpiring++
+		}
+		if e.MissingReference {
+			report.Summary.MissingReference++
+		}
+	}
+
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		a, b := report.Entries[i], report.Entries[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Policy != b.Policy {
+			return a.Policy < b.Policy
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Value < b.Value
+	})
+
+	return report
+}
+
+// active reports whether the volatile exclude is effective at the given time,
+// setting the Since and the expiry of the entry. Excludes with an effective
+// period that cannot be parsed are never effective, as when evaluated.
+func active(c ecc.VolatileCriteria, now time.Time, e *Entry) bool {
+	if c.EffectiveOn != "" {
+		on, err := time.Parse(time.RFC3339, c.EffectiveOn)
+		if err != nil || now.Before(on) {
+			return false
+		}
+		on = on.UTC()
+		e.Since = &on
+	}
+
+	if c.EffectiveUntil != "" {
+		until, err := time.Parse(time.RFC3339, c.EffectiveUntil)
+		if err != nil || !now.Before(until) {
+			return false
+		}
+		until = until.UTC()
+		e.Expires = &until
+		e.NeverExpires = false
+	}
+
+	return true
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+)
+
+var now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func policies() []ecc.EnterpriseContractPolicy {
+	release := ecctesting.NewPolicySpec().
+		WithConfiguration(ecc.EnterpriseContractPolicyConfiguration{Exclude: []string{"legacy"}}).
+		WithSources(ecctesting.NewSource("release").
+			WithExclude("cve").
+			WithVolatileExclude(
+				ecctesting.NewVolatileCriteria("tasks").
+					EffectiveOn(now.Add(-10*24*time.Hour)).
+					EffectiveUntil(now.Add(5*24*time.Hour)).
+					WithReference("https://issues.acme.com/SEC-1"),
+				ecctesting.NewVolatileCriteria("sbom").ForImageUrl("quay.io/acme/**").ForComponent("api"),
+				ecctesting.NewVolatileCriteria("expired").EffectiveUntil(now.Add(-time.Hour)),
+				ecctesting.NewVolatileCriteria("upcoming").EffectiveOn(now.Add(time.Hour)),
+			)).
+		Policy("acme", "release")
+	release.CreationTimestamp = metav1.NewTime(now.Add(-30 * 24 * time.Hour))
+
+	other := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("default").WithExclude("test")).
+		Policy("apps", "default")
+
+	return []ecc.EnterpriseContractPolicy{*other, *release}
+}
+
+func TestGenerate(t *testing.T) {
+	days := func(d int) *int { return &d }
+	at := func(t time.Time) *time.Time { return &t }
+	created := now.Add(-30 * 24 * time.Hour)
+
+	got := Generate(policies(), now)
+
+	expected := Report{
+		GeneratedAt: now,
+		Summary:     Summary{Total: 5, NeverExpiring: 4, MissingReference: 4},
+		Entries: []Entry{
+			{Namespace: "acme", Policy: "release", Kind: KindConfiguration, Value: "legacy", Since: &created, AgeDays: days(30), NeverExpires: true, MissingReference: true},
+			{Namespace: "acme", Policy: "release", Source: "release", Kind: KindSource, Value: "cve", Since: &created, AgeDays: days(30), NeverExpires: true, MissingReference: true},
+			{Namespace: "acme", Policy: "release", Source: "release", Kind: KindVolatile, Value: "sbom", Since: &created, AgeDays: days(30), NeverExpires: true, MissingReference: true, ImageUrl: "quay.io/acme/**", Component: "api"},
+			{Namespace: "acme", Policy: "release", Source: "release", Kind: KindVolatile, Value: "tasks", Since: at(now.Add(-10 * 24 * time.Hour)), AgeDays: days(10), Expires: at(now.Add(5 * 24 * time.Hour)), Reference: "https://issues.acme.com/SEC-1"},
+			{Namespace: "apps", Policy: "default", Source: "default", Kind: KindSource, Value: "test", NeverExpires: true, MissingReference: true},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestGenerateEmpty(t *testing.T) {
+	got := Generate(nil, now)
+
+	if got.Entries == nil || len(got.Entries) != 0 || got.Summary != (Summary{}) {
+		t.Errorf("expected an empty report, got %+v", got)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	r := Generate(policies(), now)
+
+	if names := FormatNames(); !reflect.DeepEqual([]string{"csv", "json", "markdown"}, names) {
+		t.Errorf("unexpected formats %v", names)
+	}
+
+	t.Run("json", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := WriteJSON(&b, r); err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := Report{}
+		if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(r, decoded) {
+			t.Errorf("expected %+v, got %+v", r, decoded)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := WriteCSV(&b, r); err != nil {
+			t.Fatal(err)
+		}
+
+		rows, err := csv.NewReader(&b).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 6 {
+			t.Fatalf("expected a header and 5 rows, got %d", len(rows))
+		}
+		expected := []string{
+			"acme", "release", "release", "volatile", "tasks", "2025-05-22T12:00:00Z", "10", "2025-06-06T12:00:00Z",
+			"false", "https://issues.acme.com/SEC-1", "false", "", "", "", "",
+		}
+		if !reflect.DeepEqual(expected, rows[4]) {
+			t.Errorf("expected %q, got %q", expected, rows[4])
+		}
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := WriteMarkdown(&b, r); err != nil {
+			t.Fatal(err)
+		}
+
+		out := b.String()
+		for _, expected := range []string{
+			"- Active excludes: 5\n",
+			"- Never expiring: 4\n",
+			"| acme | release | release | volatile | `sbom` | image: quay.io/acme/**, component: api | 30d | **never** | **missing** |\n",
+			"| acme | release | release | volatile | `tasks` |  | 10d | 2025-06-06T12:00:00Z | https://issues.acme.com/SEC-1 |\n",
+			"| apps | default | default | source | `test` |  |  | **never** | **missing** |\n",
+		} {
+			if !strings.Contains(out, expected) {
+				t.Errorf("expected %q in:\n%s", expected, out)
+			}
+		}
+	})
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package verify verifies the signatures of policy rules and data before
+// they are fetched.
+package verify
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	rekor "github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+)
+
+// Options of the verification of bundle signatures
+type Options struct {
+	// PublicKey is the PEM encoded public key the signatures are verified
+	// against, when empty the signatures are verified keyless
+	PublicKey []byte
+	// Identity of the keyless signatures
+	Identity *ecc.Identity
+	// RekorURL is the URL of the Rekor instance, when set the signatures are
+	// verified to be recorded in its transparency log. Keyless signatures
+	// are always verified to be recorded, offline against the bundled log
+	// entry when no Rekor URL is set. The log entries are verified against
+	// the Rekor public keys of the Sigstore TUF root, or the key in the file
+	// named by the SIGSTORE_REKOR_PUBLIC_KEY environment variable, loaded
+	// once, see LoadTrustRoots.
+	RekorURL string
+	// RemoteOptions are the options for accessing the registry
+	RemoteOptions []remote.Option
+}
+
+// IsBundle returns true if the go-getter style URL refers to an OCI artifact
+func IsBundle(url string) bool {
+	if ecc.IsKubernetesURL(url) {
+		return false
+	}
+
+	loc, err := fetch.Parse(url)
+
+	return err == nil && loc.Kind == fetch.OCI
+}
+
+// Bundle verifies the signature of the OCI bundle the go-getter style URL
+// refers to, returning the URL pinned to the digest of the verified bundle
+func Bundle(ctx context.Context, url string, opts Options) (string, error) {
+	loc, err := fetch.Parse(url)
+	if err != nil {
+		return "", err
+	}
+	if loc.Kind != fetch.OCI {
+		return "", fmt.Errorf("%q does not refer to an OCI bundle", url)
+	}
+
+	ref, err := name.ParseReference(loc.Address)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse reference %q: %w", loc.Address, err)
+	}
+
+	co, err := checkOpts(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := ociremote.ResolveDigest(ref, co.RegistryClientOpts...)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve the digest of %q: %w", loc.Address, err)
+	}
+
+	if _, _, err := cosign.VerifyImageSignatures(ctx, digest, co); err != nil {
+		return "", fmt.Errorf("unable to verify the signature of %q: %w", loc.Address, err)
+	}
+
+	return "oci::" + digest.String(), nil
+}
+
+func checkOpts(ctx context.Context, opts Options) (*cosign.CheckOpts, error) {
+	remoteOpts := append([]remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain)}, opts.RemoteOptions...)
+
+	co := cosign.CheckOpts{
+		ClaimVerifier:      cosign.SimpleClaimVerifier,
+		RegistryClientOpts: []ociremote.Option{ociremote.WithRemoteOptions(remoteOpts...)},
+	}
+
+	if opts.RekorURL != "" {
+		client, err := rekor.GetRekorClient(opts.RekorURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create a Rekor client: %w", err)
+		}
+		co.RekorClient = client
+	}
+
+	switch {
+	case len(opts.PublicKey) > 0:
+		pub, err := cryptoutils.UnmarshalPEMToPublicKey(opts.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the public key: %w", err)
+		}
+		if co.SigVerifier, err = signature.LoadVerifier(pub, crypto.SHA256); err != nil {
+			return nil, fmt.Errorf("unable to load the public key: %w", err)
+		}
+		co.IgnoreTlog = opts.RekorURL == ""
+	case opts.Identity != nil:
+		co.Identities = []cosign.Identity{{
+			Issuer:        opts.Identity.Issuer,
+			IssuerRegExp:  opts.Identity.IssuerRegExp,
+			Subject:       opts.Identity.Subject,
+			SubjectRegExp: opts.Identity.SubjectRegExp,
+		}}
+
+		roots, err := keylessTrustRoots(ctx)
+		if err != nil {
+			return nil, err
+		}
+		co.RootCerts, co.IntermediateCerts, co.CTLogPubKeys = roots.fulcio, roots.intermediates, roots.ctLogPubKeys
+	default:
+		return nil, fmt.Errorf("neither a public key nor an identity is set")
+	}
+
+	// the log entries are verified against the trusted Rekor public keys,
+	// never against the keys served by the Rekor instance itself
+	if !co.IgnoreTlog {
+		var err error
+		if co.RekorPubKeys, err = rekorTrustRoots(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return &co, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify/verifytest"
+)
+
+func TestBundle(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	key, pub := verifytest.GenerateKey(t)
+	_, otherPub := verifytest.GenerateKey(t)
+
+	signed := verifytest.PushBundle(t, host+"/acme/policy:latest", map[string]string{"release.rego": "package release"})
+	verifytest.Sign(t, signed, key)
+
+	verifytest.PushBundle(t, host+"/acme/unsigned:latest", map[string]string{"release.rego": "package unsigned"})
+
+	cases := []struct {
+		name      string
+		url       string
+		publicKey []byte
+		expected  string
+		err       string
+	}{
+		{name: "signed tag", url: "oci::" + host + "/acme/policy:latest", publicKey: pub, expected: "oci::" + signed.String()},
+		{name: "signed without forced getter", url: host + "/acme/policy:latest", publicKey: pub, expected: "oci::" + signed.String()},
+		{name: "signed digest", url: "oci::" + signed.String(), publicKey: pub, expected: "oci::" + signed.String()},
+		{name: "other key", url: "oci::" + host + "/acme/policy:latest", publicKey: otherPub, err: "unable to verify the signature"},
+		{name: "unsigned", url: "oci::" + host + "/acme/unsigned:latest", publicKey: pub, err: "no signatures found"},
+		{name: "missing", url: "oci::" + host + "/acme/missing:latest", publicKey: pub, err: "unable to resolve the digest"},
+		{name: "not a bundle", url: "git::https://github.com/acme/policy", publicKey: pub, err: "does not refer to an OCI bundle"},
+		{name: "invalid key", url: "oci::" + host + "/acme/policy:latest", publicKey: []byte("key"), err: "unable to parse the public key"},
+		{name: "no key or identity", url: "oci::" + host + "/acme/policy:latest", err: "neither a public key nor an identity"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Bundle(context.Background(), c.url, Options{PublicKey: c.publicKey})
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Errorf("expected error containing %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestBundleRekor(t *testing.T) {
+	host := verifytest.NewRegistry(t)
+	key, pub := verifytest.GenerateKey(t)
+	rekorKey := verifytest.RekorKey(t)
+	otherRekorKey, _ := verifytest.GenerateKey(t)
+
+	recorded := verifytest.PushBundle(t, host+"/acme/recorded:latest", map[string]string{"release.rego": "package recorded"})
+	verifytest.SignRecorded(t, recorded, key, rekorKey)
+
+	otherLog := verifytest.PushBundle(t, host+"/acme/other-log:latest", map[string]string{"release.rego": "package other"})
+	verifytest.SignRecorded(t, otherLog, key, otherRekorKey)
+
+	unrecorded := verifytest.PushBundle(t, host+"/acme/unrecorded:latest", map[string]string{"release.rego": "package unrecorded"})
+	verifytest.Sign(t, unrecorded, key)
+
+	// the unrecorded signature is looked up in the log, not finding it
+	rekor := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(rekor.Close)
+
+	cases := []struct {
+		name     string
+		url      string
+		expected string
+		err      string
+	}{
+		{name: "recorded", url: "oci::" + host + "/acme/recorded:latest", expected: "oci::" + recorded.String()},
+		{name: "recorded in another log", url: "oci::" + host + "/acme/other-log:latest", err: "rekor log public key not found"},
+		{name: "not recorded", url: "oci::" + host + "/acme/unrecorded:latest", err: "unable to verify the signature"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Bundle(context.Background(), c.url, Options{PublicKey: pub, RekorURL: rekor.URL})
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Errorf("expected error containing %q, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.expected {
+				t.Errorf("expected %q, got %q", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsBundle(t *testing.T) {
+	cases := map[string]bool{
+		"oci::quay.io/acme/policy:latest":     true,
+		"quay.io/acme/policy:latest":          true,
+		"git::https://github.com/acme/policy": false,
+		"github.com/acme/policy//policy":      false,
+		"/path/to/policy":                     false,
+		"k8s://acme/rule-data":                false,
+	}
+
+	for url, expected := range cases {
+		if got := IsBundle(url); got != expected {
+			t.Errorf("expected IsBundle(%q) to be %v, got %v", url, expected, got)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+)
+
+const (
+	pgpSignatureHeader = "-----BEGIN PGP SIGNATURE-----"
+	sshSignatureType   = "SSH SIGNATURE"
+	// sshSignatureMagic starts SSH signatures, see
+	// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+	sshSignatureMagic = "SSHSIG"
+	// sshNamespace is the namespace of SSH signatures made by git
+	sshNamespace = "git"
+	// sha1SignatureHeader is the commit header holding the signature in
+	// SHA-1 repositories
+	sha1SignatureHeader = "gpgsig"
+	// sha256SignatureHeader is the commit header holding the signature in
+	// SHA-256 repositories
+	sha256SignatureHeader = "gpgsig-sha256"
+)
+
+// CommitSigner identifies the signer of a commit with a verified signature
+type CommitSigner struct {
+	// Commit is the hash of the commit
+	Commit string
+	// Identity is the user ID of the GPG key or the comment of the SSH key
+	// the commit is signed with
+	Identity string
+	// Fingerprint of the key the commit is signed with
+	Fingerprint string
+}
+
+// allowedKeys are the GPG and SSH public keys commits may be signed with
+type allowedKeys struct {
+	gpg openpgp.EntityList
+	ssh []sshKey
+}
+
+type sshKey struct {
+	key     ssh.PublicKey
+	comment string
+}
+
+// IsGit returns true if the go-getter style URL refers to a git repository
+func IsGit(url string) bool {
+	loc, err := fetch.Parse(url)
+
+	return err == nil && loc.Kind == fetch.Git
+}
+
+// CheckAllowedKey returns an error if the key is neither an ASCII armored GPG
+// public key nor an SSH public key in the authorized_keys format
+func CheckAllowedKey(key string) error {
+	return (&allowedKeys{}).add(key)
+}
+
+func (a *allowedKeys) add(key string) error {
+	if strings.Contains(key, "-----BEGIN PGP PUBLIC KEY BLOCK-----") {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+		if err != nil {
+			return fmt.Errorf("unable to parse GPG public key: %w", err)
+		}
+		if len(entities) == 0 {
+			return errors.New("no GPG public key in the key block")
+		}
+		a.gpg = append(a.gpg, entities...)
+
+		return nil
+	}
+
+	pub, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+	if err != nil {
+		return fmt.Errorf("neither a GPG nor an SSH public key: %w", err)
+	}
+	a.ssh = append(a.ssh, sshKey{key: pub, comment: comment})
+
+	return nil
+}
+
+// Commit verifies that the commit checked out in the git working tree dir is
+// signed, with GPG or SSH, with one of the allowed keys, returning the signer
+func Commit(ctx context.Context, dir string, keys []string) (CommitSigner, error) {
+	allowed := allowedKeys{}
+	for i, k := range keys {
+		if err := allowed.add(k); err != nil {
+			return CommitSigner{}, fmt.Errorf("allowed key #%d: %w", i+1, err)
+		}
+	}
+
+	commit, err := fetch.RunGit(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return CommitSigner{}, err
+	}
+	commit = strings.TrimSpace(commit)
+
+	object, err := fetch.RunGit(ctx, dir, "cat-file", "commit", commit)
+	if err != nil {
+		return CommitSigner{}, err
+	}
+
+	header := sha1SignatureHeader
+	if len(commit) == sha256.Size*2 {
+		header = sha256SignatureHeader
+	}
+
+	payload, signature := splitCommitSignature(object, header)
+	if signature == "" {
+		return CommitSigner{}, fmt.Errorf("commit %s is not signed", commit)
+	}
+
+	var signer CommitSigner
+	if strings.HasPrefix(signature, pgpSignatureHeader) {
+		signer, err = allowed.verifyGPG(payload, signature)
+	} else {
+		signer, err = allowed.verifySSH(payload, signature)
+	}
+	if err != nil {
+		return CommitSigner{}, fmt.Errorf("unable to verify the signature of commit %s: %w", commit, err)
+	}
+	signer.Commit = commit
+
+	return signer, nil
+}
+
+// splitCommitSignature splits the raw commit object into the signed payload,
+// i.e. the commit without the signature headers, and the signature in the
+// given header. Commits of SHA-256 repositories can hold both the signature
+// of the SHA-256 object and of its SHA-1 counterpart, neither is signed.
+func splitCommitSignature(object, header string) (string, string) {
+	var payload, signature strings.Builder
+	headers, in := true, ""
+	for _, line := range strings.SplitAfter(object, "\n") {
+		if headers {
+			if in != "" && strings.HasPrefix(line, " ") {
+				if in == header {
+					signature.WriteString(line[1:])
+				}
+				continue
+			}
+			in = ""
+
+			if name, value, ok := strings.Cut(line, " "); ok && (name == sha1SignatureHeader || name == sha256SignatureHeader) {
+				in = name
+				if name == header {
+					signature.WriteString(value)
+				}
+				continue
+			}
+			headers = line != "\n"
+		}
+		payload.WriteString(line)
+	}
+
+	return payload.String(), signature.String()
+}
+
+func (a *allowedKeys) verifyGPG(payload, signature string) (CommitSigner, error) {
+	if len(a.gpg) == 0 {
+		return CommitSigner{}, errors.New("signed with GPG but no GPG key is allowed")
+	}
+
+	entity, err := openpgp.CheckArmoredDetachedSignature(a.gpg, strings.NewReader(payload), strings.NewReader(signature), nil)
+	if err != nil {
+		return CommitSigner{}, err
+	}
+
+	signer := CommitSigner{Fingerprint: strings.ToUpper(hex.EncodeToString(entity.PrimaryKey.Fingerprint))}
+	if id := entity.PrimaryIdentity(); id != nil {
+		signer.Identity = id.Name
+	}
+
+	return signer, nil
+}
+
+// sshSignature is the SSH signature blob following the magic preamble
+type sshSignature struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+// sshSignedData is the data signed by an SSH signature, following the magic
+// preamble
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+func (a *allowedKeys) verifySSH(payload, signature string) (CommitSigner, error) {
+	block, _ := pem.Decode([]byte(signature))
+	if block == nil || block.Type != sshSignatureType {
+		return CommitSigner{}, errors.New("neither a GPG nor an SSH signature")
+	}
+
+	blob, ok := bytes.CutPrefix(block.Bytes, []byte(sshSignatureMagic))
+	if !ok {
+		return CommitSigner{}, errors.New("malformed SSH signature")
+	}
+
+	sig := sshSignature{}
+	if err := ssh.Unmarshal(blob, &sig); err != nil {
+		return CommitSigner{}, fmt.Errorf("malformed SSH signature: %w", err)
+	}
+	if sig.Version != 1 {
+		return CommitSigner{}, fmt.Errorf("unsupported SSH signature version %d", sig.Version)
+	}
+	if sig.Namespace != sshNamespace {
+		return CommitSigner{}, fmt.Errorf("SSH signature in namespace %q instead of %q", sig.Namespace, sshNamespace)
+	}
+
+	pub, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return CommitSigner{}, fmt.Errorf("malformed SSH signature public key: %w", err)
+	}
+
+	var key *sshKey
+	for i := range a.ssh {
+		if bytes.Equal(a.ssh[i].key.Marshal(), pub.Marshal()) {
+			key = &a.ssh[i]
+			break
+		}
+	}
+	if key == nil {
+		return CommitSigner{}, fmt.Errorf("signed with SSH key %s which is not allowed", ssh.FingerprintSHA256(pub))
+	}
+
+	var hash []byte
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h := sha256.Sum256([]byte(payload))
+		hash = h[:]
+	case "sha512":
+		h := sha512.Sum512([]byte(payload))
+		hash = h[:]
+	default:
+		return CommitSigner{}, fmt.Errorf("unsupported SSH signature hash algorithm %q", sig.HashAlgorithm)
+	}
+
+	s := ssh.Signature{}
+	if err := ssh.Unmarshal(sig.Signature, &s); err != nil {
+		return CommitSigner{}, fmt.Errorf("malformed SSH signature: %w", err)
+	}
+
+	signed := append([]byte(sshSignatureMagic), ssh.Marshal(sshSignedData{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          hash,
+	})...)
+	if err := pub.Verify(signed, &s); err != nil {
+		return CommitSigner{}, fmt.Errorf("invalid SSH signature: %w", err)
+	}
+
+	return CommitSigner{Identity: key.comment, Fingerprint: ssh.FingerprintSHA256(pub)}, nil
+}
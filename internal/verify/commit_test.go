@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify/verifytest"
+)
+
+func TestCommit(t *testing.T) {
+	gpgSign, gpgKey := verifytest.GPGKey(t, "Release Engineering", "release@example.com")
+	otherGPGSign, otherGPGKey := verifytest.GPGKey(t, "Other", "other@example.com")
+	sshSign, sshKey := verifytest.SSHKey(t, "release@example.com")
+	otherSSHSign, _ := verifytest.SSHKey(t, "other@example.com")
+
+	files := map[string]string{"policy/release.rego": "package release"}
+
+	cases := []struct {
+		name     string
+		sign     verifytest.SignFunc
+		allowed  []string
+		identity string
+		err      string
+	}{
+		{name: "GPG", sign: gpgSign, allowed: []string{sshKey, otherGPGKey, gpgKey}, identity: "Release Engineering <release@example.com>"},
+		{name: "SSH", sign: sshSign, allowed: []string{gpgKey, sshKey}, identity: "release@example.com"},
+		{name: "other GPG key", sign: otherGPGSign, allowed: []string{gpgKey, sshKey}, err: "unable to verify the signature"},
+		{name: "other SSH key", sign: otherSSHSign, allowed: []string{gpgKey, sshKey}, err: "which is not allowed"},
+		{name: "no GPG key allowed", sign: gpgSign, allowed: []string{sshKey}, err: "no GPG key is allowed"},
+		{name: "unsigned", allowed: []string{gpgKey, sshKey}, err: "is not signed"},
+		{name: "invalid allowed key", sign: sshSign, allowed: []string{sshKey, "key"}, err: "allowed key #2"},
+	}
+
+	for _, c := range cases {
+		for _, format := range []struct {
+			name       string
+			repository func(*testing.T, map[string]string, verifytest.SignFunc) (string, string)
+		}{{"sha1", verifytest.GitRepository}, {"sha256", verifytest.GitRepositorySHA256}} {
+			t.Run(c.name+" "+format.name, func(t *testing.T) {
+				repo, commit := format.repository(t, files, c.sign)
+
+				dir, err := fetch.NewLocalFetcher().Fetch(context.Background(), "git::"+repo+"//policy", filepath.Join(t.TempDir(), "fetched"))
+				if err != nil {
+					t.Fatalf("unexpected error fetching: %v", err)
+				}
+
+				signer, err := Commit(context.Background(), dir, c.allowed)
+				if c.err != "" {
+					if err == nil || !strings.Contains(err.Error(), c.err) {
+						t.Errorf("expected error containing %q, got %v", c.err, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if signer.Commit != commit {
+					t.Errorf("expected commit %s, got %s", commit, signer.Commit)
+				}
+				if signer.Identity != c.identity {
+					t.Errorf("expected identity %q, got %q", c.identity, signer.Identity)
+				}
+				if signer.Fingerprint == "" {
+					t.Error("expected the fingerprint of the signing key")
+				}
+			})
+		}
+	}
+}
+
+func TestSplitCommitSignature(t *testing.T) {
+	const payload = "tree 1\nauthor a <a@example.com> 1 +0000\ncommitter a <a@example.com> 1 +0000\n\nmessage\n gpgsig in the message\n"
+	const sha1Sig = "gpgsig -----BEGIN SHA-1-----\n sha1\n -----END SHA-1-----\n"
+	const sha256Sig = "gpgsig-sha256 -----BEGIN SHA-256-----\n sha256\n -----END SHA-256-----\n"
+	headers, message, _ := strings.Cut(payload, "\n\n")
+	headers += "\n"
+	message = "\n" + message
+
+	cases := []struct {
+		name      string
+		object    string
+		header    string
+		signature string
+	}{
+		{"gpgsig", headers + sha1Sig + message, sha1SignatureHeader, "-----BEGIN SHA-1-----\nsha1\n-----END SHA-1-----\n"},
+		{"gpgsig-sha256", headers + sha256Sig + message, sha256SignatureHeader, "-----BEGIN SHA-256-----\nsha256\n-----END SHA-256-----\n"},
+		{"both, sha1", headers + sha256Sig + sha1Sig + message, sha1SignatureHeader, "-----BEGIN SHA-1-----\nsha1\n-----END SHA-1-----\n"},
+		{"both, sha256", headers + sha1Sig + sha256Sig + message, sha256SignatureHeader, "-----BEGIN SHA-256-----\nsha256\n-----END SHA-256-----\n"},
+		{"other header", headers + sha1Sig + message, sha256SignatureHeader, ""},
+		{"unsigned", payload, sha1SignatureHeader, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gotPayload, gotSignature := splitCommitSignature(c.object, c.header)
+			if gotPayload != payload {
+				t.Errorf("expected payload %q, got %q", payload, gotPayload)
+			}
+			if gotSignature != c.signature {
+				t.Errorf("expected signature %q, got %q", c.signature, gotSignature)
+			}
+		})
+	}
+}
+
+func TestCheckAllowedKey(t *testing.T) {
+	_, gpgKey := verifytest.GPGKey(t, "Release Engineering", "release@example.com")
+	_, sshKey := verifytest.SSHKey(t, "release@example.com")
+
+	for _, key := range []string{gpgKey, sshKey} {
+		if err := CheckAllowedKey(key); err != nil {
+			t.Errorf("unexpected error for %q: %v", key, err)
+		}
+	}
+
+	for _, key := range []string{"", "ssh-ed25519 AAAA", "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\n-----END PGP PUBLIC KEY BLOCK-----"} {
+		if err := CheckAllowedKey(key); err == nil {
+			t.Errorf("expected an error for %q", key)
+		}
+	}
+}
+
+func TestIsGit(t *testing.T) {
+	cases := map[string]bool{
+		"git::https://github.com/acme/policy": true,
+		"github.com/acme/policy//policy":      true,
+		"oci::quay.io/acme/policy:latest":     false,
+		"/path/to/policy":                     false,
+	}
+
+	for url, expected := range cases {
+		if got := IsGit(url); got != expected {
+			t.Errorf("expected IsGit(%q) to be %v, got %v", url, expected, got)
+		}
+	}
+}
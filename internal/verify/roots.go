@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/sigstore/pkg/fulcioroots"
+)
+
+// rootsEnv are the environment variables overriding the trust roots of the
+// Sigstore TUF root
+var rootsEnv = []string{"TUF_ROOT", "SIGSTORE_ROOT_FILE", "SIGSTORE_CT_LOG_PUBLIC_KEY_FILE", "SIGSTORE_REKOR_PUBLIC_KEY"}
+
+// rootsCache keeps the trust roots once loaded, by the environment variables
+// overriding them, so that they are fetched from the TUF root only once and
+// not on every verification. Failures to load are not kept, the next use
+// loads the roots again.
+type rootsCache[T any] struct {
+	mu     sync.Mutex
+	loaded map[string]T
+}
+
+func (c *rootsCache[T]) get(load func() (T, error)) (T, error) {
+	values := make([]string, 0, len(rootsEnv))
+	for _, name := range rootsEnv {
+		values = append(values, os.Getenv(name))
+	}
+	key := strings.Join(values, "\x00")
+
+	// held while loading, concurrent verifications wait for the roots to be
+	// loaded once rather than all fetching them
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if v, ok := c.loaded[key]; ok {
+		return v, nil
+	}
+
+	v, err := load()
+	if err != nil {
+		return v, err
+	}
+
+	if c.loaded == nil {
+		c.loaded = map[string]T{}
+	}
+	c.loaded[key] = v
+
+	return v, nil
+}
+
+// keylessRoots are the trust roots of keyless signatures
+type keylessRoots struct {
+	fulcio        *x509.CertPool
+	intermediates *x509.CertPool
+	ctLogPubKeys  *cosign.TrustedTransparencyLogPubKeys
+}
+
+var (
+	keyless      rootsCache[keylessRoots]
+	rekorPubKeys rootsCache[*cosign.TrustedTransparencyLogPubKeys]
+)
+
+// keylessTrustRoots returns the Fulcio roots and intermediates and the CT log
+// public keys keyless signatures are verified against
+func keylessTrustRoots(ctx context.Context) (keylessRoots, error) {
+	return keyless.get(func() (keylessRoots, error) {
+		var roots keylessRoots
+		var err error
+		if roots.fulcio, err = fulcioroots.Get(); err != nil {
+			return roots, fmt.Errorf("unable to get the Fulcio roots: %w", err)
+		}
+		if roots.intermediates, err = fulcioroots.GetIntermediates(); err != nil {
+			return roots, fmt.Errorf("unable to get the Fulcio intermediates: %w", err)
+		}
+		if roots.ctLogPubKeys, err = cosign.GetCTLogPubs(ctx); err != nil {
+			return roots, fmt.Errorf("unable to get the CT log public keys: %w", err)
+		}
+
+		return roots, nil
+	})
+}
+
+// rekorTrustRoots returns the Rekor public keys log entries are verified
+// against
+func rekorTrustRoots(ctx context.Context) (*cosign.TrustedTransparencyLogPubKeys, error) {
+	return rekorPubKeys.get(func() (*cosign.TrustedTransparencyLogPubKeys, error) {
+		keys, err := cosign.GetRekorPubs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the Rekor public keys: %w", err)
+		}
+
+		return keys, nil
+	})
+}
+
+// LoadTrustRoots loads the trust roots keyless signatures and Rekor log
+// entries are verified against ahead of their first use, e.g. at startup.
+// They are loaded once and reused by every verification, failing to load them
+// here they are loaded again on first use.
+func LoadTrustRoots(ctx context.Context) error {
+	_, keylessErr := keylessTrustRoots(ctx)
+	_, rekorErr := rekorTrustRoots(ctx)
+
+	return errors.Join(keylessErr, rekorErr)
+}
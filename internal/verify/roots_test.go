@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify/verifytest"
+)
+
+func TestRekorTrustRootsLoadedOnce(t *testing.T) {
+	verifytest.RekorKey(t)
+
+	first, err := rekorTrustRoots(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the key file is not read again
+	if err := os.WriteFile(os.Getenv("SIGSTORE_REKOR_PUBLIC_KEY"), []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	again, err := rekorTrustRoots(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again != first {
+		t.Error("expected the loaded Rekor public keys to be reused")
+	}
+
+	// other keys configured are loaded
+	verifytest.RekorKey(t)
+	other, err := rekorTrustRoots(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == first {
+		t.Error("expected the Rekor public keys configured to be loaded")
+	}
+}
+
+func TestRootsCacheFailures(t *testing.T) {
+	var c rootsCache[int]
+
+	calls := 0
+	load := func() (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, errors.New("unavailable")
+		}
+		return calls, nil
+	}
+
+	if _, err := c.get(load); err == nil {
+		t.Error("expected the failure to load")
+	}
+	for range 2 {
+		if v, err := c.get(load); err != nil || v != 2 {
+			t.Errorf("expected the roots loaded once again, got %d, %v", v, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected the roots to be loaded twice, got %d", calls)
+	}
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verifytest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"golang.org/x/crypto/ssh"
+)
+
+// SignFunc signs a commit, returning the ASCII armored signature
+type SignFunc func(t *testing.T, payload []byte) string
+
+// GPGKey generates a GPG key, returning a function signing commits with it
+// and the ASCII armored public key
+func GPGKey(t *testing.T, name, email string) (SignFunc, string) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity(name, "", email, nil)
+	if err != nil {
+		t.Fatalf("unable to generate a GPG key: %v", err)
+	}
+
+	var pub bytes.Buffer
+	w, err := armor.Encode(&pub, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatalf("unable to serialize the GPG key: %v", err)
+	}
+	w.Close()
+
+	sign := func(t *testing.T, payload []byte) string {
+		t.Helper()
+
+		var sig bytes.Buffer
+		if err := openpgp.ArmoredDetachSign(&sig, entity, bytes.NewReader(payload), nil); err != nil {
+			t.Fatalf("unable to sign with GPG: %v", err)
+		}
+
+		return sig.String() + "\n"
+	}
+
+	return sign, pub.String()
+}
+
+// SSHKey generates an Ed25519 SSH key, returning a function signing commits
+// with it, as done by ssh-keygen -Y sign, and the public key in the
+// authorized_keys format
+func SSHKey(t *testing.T, comment string) (SignFunc, string) {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate an SSH key: %v", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(t *testing.T, payload []byte) string {
+		t.Helper()
+
+		hash := sha512.Sum512(payload)
+		signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+			Namespace     string
+			Reserved      string
+			HashAlgorithm string
+			Hash          []byte
+		}{"git", "", "sha512", hash[:]})...)
+
+		sig, err := signer.Sign(rand.Reader, signed)
+		if err != nil {
+			t.Fatalf("unable to sign with SSH: %v", err)
+		}
+
+		blob := append([]byte("SSHSIG"), ssh.Marshal(struct {
+			Version       uint32
+			PublicKey     []byte
+			Namespace     string
+			Reserved      string
+			HashAlgorithm string
+			Signature     []byte
+		}{1, signer.PublicKey().Marshal(), "git", "", "sha512", ssh.Marshal(sig)})...)
+
+		return string(pem.EncodeToMemory(&pem.Block{Type: "SSH SIGNATURE", Bytes: blob}))
+	}
+
+	pub := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))) + " " + comment
+
+	return sign, pub
+}
+
+// GitRepository creates a bare repository with a commit of the files on the
+// main branch, signed with sign unless nil, returning the file URL of the
+// repository and the hash of the commit
+func GitRepository(t *testing.T, files map[string]string, sign SignFunc) (string, string) {
+	t.Helper()
+
+	return gitRepository(t, "sha1", "gpgsig", files, sign)
+}
+
+// GitRepositorySHA256 creates a repository as GitRepository does, with the
+// SHA-256 object format
+func GitRepositorySHA256(t *testing.T, files map[string]string, sign SignFunc) (string, string) {
+	t.Helper()
+
+	return gitRepository(t, "sha256", "gpgsig-sha256", files, sign)
+}
+
+// gitRepository creates a repository with the object format, the commit
+// signed in the given header
+func gitRepository(t *testing.T, objectFormat, header string, files map[string]string, sign SignFunc) (string, string) {
+	t.Helper()
+
+	work := t.TempDir()
+	gitCmd(t, work, nil, "init", "--quiet", "--initial-branch=main", "--object-format="+objectFormat)
+	for name, content := range files {
+		path := filepath.Join(work, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gitCmd(t, work, nil, "add", ".")
+	tree := gitCmd(t, work, nil, "write-tree")
+
+	headers := fmt.Sprintf("tree %s\n"+
+		"author test <test@example.com> 1735689600 +0000\n"+
+		"committer test <test@example.com> 1735689600 +0000\n", tree)
+	message := "\npolicy\n"
+
+	object := headers + message
+	if sign != nil {
+		sig := strings.TrimSuffix(sign(t, []byte(object)), "\n")
+		object = headers + header + " " + strings.ReplaceAll(sig, "\n", "\n ") + "\n" + message
+	}
+
+	commit := gitCmd(t, work, []byte(object), "hash-object", "-t", "commit", "-w", "--stdin")
+	gitCmd(t, work, nil, "update-ref", "refs/heads/main", commit)
+
+	bare := filepath.Join(t.TempDir(), "policy.git")
+	gitCmd(t, work, nil, "clone", "--quiet", "--bare", work, bare)
+
+	return (&url.URL{Scheme: "file", Path: bare}).String(), commit
+}
+
+func gitCmd(t *testing.T, dir string, stdin []byte, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v: %s", args, err, out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
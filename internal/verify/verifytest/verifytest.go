@@ -0,0 +1,285 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package verifytest provides an in-process registry, git repositories and
+// signing of OCI bundles and commits with local keys for use in tests.
+package verifytest
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	cbundle "github.com/sigstore/cosign/v2/pkg/cosign/bundle"
+	ocimutate "github.com/sigstore/cosign/v2/pkg/oci/mutate"
+	ociremote "github.com/sigstore/cosign/v2/pkg/oci/remote"
+	ocistatic "github.com/sigstore/cosign/v2/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/payload"
+)
+
+// titleAnnotation names the file a layer of a bundle is extracted to
+const titleAnnotation = "org.opencontainers.image.title"
+
+// NewRegistry starts an in-process registry, stopped when the test ends, and
+// returns its host
+func NewRegistry(t *testing.T) string {
+	t.Helper()
+
+	return serve(t, registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+}
+
+// NewAuthRegistry starts an in-process registry, as NewRegistry does, only
+// accessible with the given basic auth credentials
+func NewAuthRegistry(t *testing.T, username, password string) string {
+	t.Helper()
+
+	reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+
+	return serve(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != username || p != password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		reg.ServeHTTP(w, r)
+	}))
+}
+
+func serve(t *testing.T, handler http.Handler) string {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return u.Host
+}
+
+// PushBundle pushes an OCI bundle with a layer per file, extracted to the
+// file name, and returns the digest of the pushed bundle
+func PushBundle(t *testing.T, ref string, files map[string]string) name.Digest {
+	t.Helper()
+
+	r, err := name.ParseReference(ref)
+	if err != nil {
+		t.Fatalf("unable to parse reference %q: %v", ref, err)
+	}
+
+	img := empty.Image
+	for file, content := range files {
+		img, err = mutate.Append(img, mutate.Addendum{
+			Layer:       static.NewLayer([]byte(content), types.OCILayer),
+			Annotations: map[string]string{titleAnnotation: file},
+		})
+		if err != nil {
+			t.Fatalf("unable to add %s to the bundle: %v", file, err)
+		}
+	}
+
+	if err := remote.Write(r, img); err != nil {
+		t.Fatalf("unable to push %q: %v", ref, err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r.Context().Digest(digest.String())
+}
+
+// GenerateKey generates an ECDSA P-256 key, returning the key and the PEM
+// encoded public key
+func GenerateKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate a key: %v", err)
+	}
+
+	pub, err := cryptoutils.MarshalPublicKeyToPEM(key.Public())
+	if err != nil {
+		t.Fatalf("unable to marshal the public key: %v", err)
+	}
+
+	return key, pub
+}
+
+// RekorKey generates the key of a Rekor transparency log, trusted for the
+// rest of the test through the SIGSTORE_REKOR_PUBLIC_KEY environment variable
+func RekorKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, pub := GenerateKey(t)
+
+	path := filepath.Join(t.TempDir(), "rekor.pub")
+	if err := os.WriteFile(path, pub, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SIGSTORE_REKOR_PUBLIC_KEY", path)
+
+	return key
+}
+
+// Sign signs the bundle with the given digest with the key, as done by
+// cosign sign --key --tlog-upload=false, and pushes the signature
+func Sign(t *testing.T, digest name.Digest, key *ecdsa.PrivateKey) {
+	t.Helper()
+
+	sign(t, digest, key, nil)
+}
+
+// SignRecorded signs the bundle with the given digest with the key and
+// records the signature in the Rekor transparency log with the given key, as
+// done by cosign sign --key, and pushes the signature along with the Rekor
+// bundle of the log entry
+func SignRecorded(t *testing.T, digest name.Digest, key, rekorKey *ecdsa.PrivateKey) {
+	t.Helper()
+
+	sign(t, digest, key, rekorKey)
+}
+
+func sign(t *testing.T, digest name.Digest, key, rekorKey *ecdsa.PrivateKey) {
+	t.Helper()
+
+	p, err := payload.Cosign{Image: digest}.MarshalJSON()
+	if err != nil {
+		t.Fatalf("unable to create the signature payload: %v", err)
+	}
+
+	signer, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := signer.SignMessage(bytes.NewReader(p))
+	if err != nil {
+		t.Fatalf("unable to sign: %v", err)
+	}
+
+	var opts []ocistatic.Option
+	if rekorKey != nil {
+		opts = append(opts, ocistatic.WithBundle(rekorBundle(t, p, sig, key, rekorKey)))
+	}
+
+	ociSig, err := ocistatic.NewSignature(p, base64.StdEncoding.EncodeToString(sig), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	se, err := ociremote.SignedImage(digest)
+	if err != nil {
+		t.Fatalf("unable to get %s: %v", digest, err)
+	}
+
+	se, err = ocimutate.AttachSignatureToImage(se, ociSig)
+	if err != nil {
+		t.Fatalf("unable to attach the signature: %v", err)
+	}
+
+	if err := ociremote.WriteSignatures(digest.Repository, se); err != nil {
+		t.Fatalf("unable to push the signature: %v", err)
+	}
+}
+
+// rekorBundle returns the Rekor bundle of the hashedrekord log entry of the
+// signature of the payload, the signed entry timestamp signed with rekorKey
+func rekorBundle(t *testing.T, p, sig []byte, key, rekorKey *ecdsa.PrivateKey) *cbundle.RekorBundle {
+	t.Helper()
+
+	pub, err := cryptoutils.MarshalPublicKeyToPEM(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := sha256.Sum256(p)
+	body, err := json.Marshal(map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]any{
+			"data": map[string]any{
+				"hash": map[string]any{"algorithm": "sha256", "value": hex.EncodeToString(hash[:])},
+			},
+			"signature": map[string]any{
+				"content":   base64.StdEncoding.EncodeToString(sig),
+				"publicKey": map[string]any{"content": base64.StdEncoding.EncodeToString(pub)},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logID, err := cosign.GetTransparencyLogID(rekorKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rekorPayload := cbundle.RekorPayload{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		IntegratedTime: time.Now().Unix(),
+		LogIndex:       1,
+		LogID:          logID,
+	}
+
+	contents, err := json.Marshal(rekorPayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonicalized, err := jsoncanonicalizer.Transform(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256(canonicalized)
+	set, err := ecdsa.SignASN1(rand.Reader, rekorKey, digest[:])
+	if err != nil {
+		t.Fatalf("unable to sign the entry timestamp: %v", err)
+	}
+
+	return &cbundle.RekorBundle{SignedEntryTimestamp: set, Payload: rekorPayload}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/deprecation"
+)
+
+// deprecationWarnings warns of each deprecated field used in the policy,
+// naming its replacement. The policies still using the deprecated fields are
+// counted by the policy reconciler.
+func deprecationWarnings(policy *ecc.EnterpriseContractPolicy) admission.Warnings {
+	used := deprecation.Find(policy.Spec)
+	if len(used) == 0 {
+		return nil
+	}
+
+	warnings := make(admission.Warnings, 0, len(used))
+	for _, u := range used {
+		warnings = append(warnings, fmt.Sprintf("%s is deprecated, %s", u.Path, deprecation.Replacements[u.Field]))
+	}
+
+	return warnings
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+)
+
+const imageDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestDeprecationWarnings(t *testing.T) {
+	spec := ecctesting.NewPolicySpec().
+		WithConfiguration(ecc.EnterpriseContractPolicyConfiguration{Include: []string{"a"}, Collections: []string{"minimal"}}).
+		WithSources(ecctesting.NewSource("a").
+			WithPolicy(ecctesting.ReleasePolicyURL).
+			WithVolatileInclude(ecctesting.NewVolatileCriteria("b")).
+			WithVolatileExclude(ecctesting.NewVolatileCriteria("c"), ecctesting.NewVolatileCriteria("d"))).
+		Build()
+	spec.Sources[0].VolatileConfig.Include[0].ImageRef = imageDigest
+	spec.Sources[0].VolatileConfig.Exclude[1].ImageRef = imageDigest
+	policy := &ecc.EnterpriseContractPolicy{Spec: spec}
+	policy.Namespace, policy.Name = "acme", "policy"
+
+	v := EnterpriseContractPolicyValidator{}
+	warnings, err := v.ValidateCreate(context.Background(), policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := admission.Warnings{
+		"spec.configuration is deprecated, use the config of the policy sources instead",
+		`spec.configuration.collections is deprecated, list the collections in include with the "@" prefix instead, e.g. "@minimal"`,
+		"spec.sources[0].volatileConfig.include[0].imageRef is deprecated, use imageDigest instead",
+		"spec.sources[0].volatileConfig.exclude[1].imageRef is deprecated, use imageDigest instead",
+	}
+	if !reflect.DeepEqual(expected, warnings) {
+		t.Errorf("expected warnings %q, got %q", expected, warnings)
+	}
+}
+
+func TestNoDeprecationWarnings(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("a").
+			WithPolicy(ecctesting.ReleasePolicyURL).
+			WithInclude("@minimal").
+			WithVolatileExclude(ecctesting.NewVolatileCriteria("c").ForImageDigest(imageDigest))).
+		Policy("acme", "policy")
+
+	warnings, err := (&EnterpriseContractPolicyValidator{}).ValidateCreate(context.Background(), policy)
+	if err != nil || len(warnings) != 0 {
+		t.Errorf("expected neither warnings nor errors, got %q, %v", warnings, err)
+	}
+}
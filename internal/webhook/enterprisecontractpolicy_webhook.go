@@ -0,0 +1,340 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook validates EnterpriseContractPolicy resources on admission.
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/governance"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/lint"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/metapolicy"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify"
+)
+
+//+kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1alpha1-enterprisecontractpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=enterprisecontractpolicies,verbs=create;update,versions=v1alpha1,name=venterprisecontractpolicy.kb.io,admissionReviewVersions=v1
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// EnterpriseContractPolicyValidator validates EnterpriseContractPolicy
+// resources on creation and update
+type EnterpriseContractPolicyValidator struct {
+	// Client reads the governance configuration, the meta-policy and the
+	// labels of the namespaces of the policies
+	Client client.Reader
+	// Governance is the ConfigMap holding the governance configuration the
+	// excludes of the policies are held to, none when the name is empty
+	Governance types.NamespacedName
+	// MetaPolicy is the ConfigMap holding the Rego meta-policy the policies
+	// are evaluated against, none when the name is empty
+	MetaPolicy types.NamespacedName
+	// AllowLocalSources admits policy sources on the local file system of the
+	// manager, for development only
+	AllowLocalSources bool
+	// now returns the current time, time.Now when nil
+	now func() time.Time
+
+	// compiled caches the meta-policy compiled from the ConfigMap
+	compiled struct {
+		sync.Mutex
+		// version is the UID and resource version of the ConfigMap compiled
+		version string
+		policy  *metapolicy.Policy
+	}
+}
+
+var _ webhook.CustomValidator = &EnterpriseContractPolicyValidator{}
+
+// SetupWebhookWithManager registers the validating webhook with the manager
+func (v *EnterpriseContractPolicyValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&ecc.EnterpriseContractPolicy{}).
+		WithValidator(v).
+		Complete()
+}
+
+// ValidateCreate validates a policy being created
+func (v *EnterpriseContractPolicyValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj, nil)
+}
+
+// ValidateUpdate validates a policy being updated
+func (v *EnterpriseContractPolicyValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*ecc.EnterpriseContractPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an EnterpriseContractPolicy, got %T", oldObj)
+	}
+
+	return v.validate(ctx, newObj, old)
+}
+
+// ValidateDelete allows any policy to be deleted
+func (v *EnterpriseContractPolicyValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *EnterpriseContractPolicyValidator) validate(ctx context.Context, obj runtime.Object, old *ecc.EnterpriseContractPolicy) (admission.Warnings, error) {
+	policy, ok := obj.(*ecc.EnterpriseContractPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an EnterpriseContractPolicy, got %T", obj)
+	}
+
+	errs := validateSources(policy.Spec.Sources, policy.Namespace, field.NewPath("spec", "sources"), v.AllowLocalSources)
+
+	labels := v.namespaceLabels(policy.Namespace)
+
+	governanceErrs, err := v.validateGovernance(ctx, policy, old, labels)
+	if err != nil {
+		return nil, err
+	}
+	errs = append(errs, governanceErrs...)
+
+	metaErrs, metaWarnings, err := v.validateMetaPolicy(ctx, policy, old, labels)
+	if err != nil {
+		return nil, err
+	}
+	errs = append(errs, metaErrs...)
+
+	warnings := append(deprecationWarnings(policy), lintWarnings(policy)...)
+	warnings = append(warnings, metaWarnings...)
+
+	if len(errs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(ecc.GroupVersion.WithKind("EnterpriseContractPolicy").GroupKind(), policy.Name, errs)
+}
+
+// lintWarnings warns of the conflicting and redundant entries of the rule
+// configuration of the policy
+func lintWarnings(policy *ecc.EnterpriseContractPolicy) admission.Warnings {
+	var warnings admission.Warnings
+	for _, f := range lint.Analyze(policy.Spec) {
+		warnings = append(warnings, f.String())
+	}
+
+	return warnings
+}
+
+// namespaceLabels returns a function getting the labels of the namespace once
+func (v *EnterpriseContractPolicyValidator) namespaceLabels(name string) func(context.Context) (map[string]string, error) {
+	var namespace *corev1.Namespace
+	return func(ctx context.Context) (map[string]string, error) {
+		if namespace == nil {
+			namespace = &corev1.Namespace{}
+			if err := v.Client.Get(ctx, client.ObjectKey{Name: name}, namespace); err != nil {
+				namespace = nil
+				return nil, fmt.Errorf("unable to get namespace %s: %w", name, err)
+			}
+		}
+
+		return namespace.Labels, nil
+	}
+}
+
+// configMap returns the ConfigMap, nil when not found
+func (v *EnterpriseContractPolicyValidator) configMap(ctx context.Context, key types.NamespacedName) (*corev1.ConfigMap, error) {
+	cm := corev1.ConfigMap{}
+	if err := v.Client.Get(ctx, key, &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to get ConfigMap %s: %w", key, err)
+	}
+
+	return &cm, nil
+}
+
+// validateGovernance holds the excludes of the policy to the governance
+// configuration. A missing governance ConfigMap imposes no rules, while an
+// invalid one rejects all policies until fixed.
+func (v *EnterpriseContractPolicyValidator) validateGovernance(ctx context.Context, policy, old *ecc.EnterpriseContractPolicy, labels func(context.Context) (map[string]string, error)) (field.ErrorList, error) {
+	if v.Governance.Name == "" {
+		return nil, nil
+	}
+
+	cm, err := v.configMap(ctx, v.Governance)
+	if err != nil || cm == nil {
+		return nil, err
+	}
+
+	config, err := governance.Parse([]byte(cm.Data[governance.ConfigKey]))
+	if err != nil {
+		return nil, fmt.Errorf("governance configuration %s: %w", v.Governance, err)
+	}
+	if len(config.Rules) == 0 {
+		return nil, nil
+	}
+
+	namespaceLabels, err := labels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now
+	if v.now != nil {
+		now = v.now
+	}
+
+	return config.Validate(policy, old, namespaceLabels, now()), nil
+}
+
+// validateMetaPolicy evaluates the Rego meta-policy against the policy, the
+// deny messages are returned as errors and the warn messages as warnings. As
+// with the governance configuration, a missing ConfigMap or one without Rego
+// modules imposes no rules, while an invalid one rejects all policies until
+// fixed.
+func (v *EnterpriseContractPolicyValidator) validateMetaPolicy(ctx context.Context, policy, old *ecc.EnterpriseContractPolicy, labels func(context.Context) (map[string]string, error)) (field.ErrorList, admission.Warnings, error) {
+	if v.MetaPolicy.Name == "" {
+		return nil, nil, nil
+	}
+
+	cm, err := v.configMap(ctx, v.MetaPolicy)
+	if err != nil || cm == nil {
+		return nil, nil, err
+	}
+
+	meta, err := v.compile(ctx, cm)
+	if err != nil || meta == nil {
+		return nil, nil, err
+	}
+
+	namespaceLabels, err := labels(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	input, err := metapolicy.NewInput(policy, old, namespaceLabels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := meta.Evaluate(ctx, input)
+	if err != nil {
+		return nil, nil, fmt.Errorf("meta-policy %s: %w", v.MetaPolicy, err)
+	}
+
+	var errs field.ErrorList
+	for _, msg := range result.Deny {
+		errs = append(errs, field.Forbidden(field.NewPath("spec"), msg+" (meta-policy)"))
+	}
+
+	return errs, result.Warn, nil
+}
+
+// compile returns the meta-policy of the ConfigMap, compiled once per
+// version of the ConfigMap, nil when the ConfigMap holds no Rego modules
+func (v *EnterpriseContractPolicyValidator) compile(ctx context.Context, cm *corev1.ConfigMap) (*metapolicy.Policy, error) {
+	modules := metapolicy.Modules(cm.Data)
+	if len(modules) == 0 {
+		return nil, nil
+	}
+
+	v.compiled.Lock()
+	defer v.compiled.Unlock()
+
+	version := string(cm.UID) + "/" + cm.ResourceVersion
+	if v.compiled.policy != nil && v.compiled.version == version {
+		return v.compiled.policy, nil
+	}
+
+	meta, err := metapolicy.Compile(ctx, modules)
+	if err != nil {
+		return nil, fmt.Errorf("meta-policy %s: %w", v.MetaPolicy, err)
+	}
+	v.compiled.version, v.compiled.policy = version, meta
+
+	return meta, nil
+}
+
+// localSourceDetail is the detail of the errors of sources on the local file
+// system of the manager
+const localSourceDetail = "local paths and git repositories are not allowed, use a remote git repository or an OCI registry"
+
+// validateSources validates the sources of a policy in the given namespace
+func validateSources(sources []ecc.Source, namespace string, path *field.Path, allowLocal bool) field.ErrorList {
+	var errs field.ErrorList
+	for i, s := range sources {
+		for j, url := range s.Policy {
+			if ecc.IsKubernetesURL(url) {
+				errs = append(errs, field.Invalid(path.Index(i).Child("policy").Index(j), url, "policy rules cannot be held in Kubernetes resources"))
+			}
+			if !allowLocal && fetch.IsLocal(url) {
+				errs = append(errs, field.Invalid(path.Index(i).Child("policy").Index(j), url, localSourceDetail))
+			}
+		}
+
+		for j, url := range s.Data {
+			if !allowLocal && fetch.IsLocal(url) {
+				errs = append(errs, field.Invalid(path.Index(i).Child("data").Index(j), url, localSourceDetail))
+			}
+			if !ecc.IsKubernetesURL(url) {
+				continue
+			}
+			if _, err := ecc.ParseKubernetesURLIn(url, namespace); err != nil {
+				errs = append(errs, field.Invalid(path.Index(i).Child("data").Index(j), url, err.Error()))
+			}
+		}
+
+		if s.BundleSignature != nil && s.BundleSignature.PublicKey != "" {
+			errs = append(errs, validatePublicKey(s.BundleSignature.PublicKey, namespace, path.Index(i).Child("bundleSignature", "publicKey"))...)
+		}
+
+		if s.CommitSignature != nil {
+			for j, key := range s.CommitSignature.AllowedKeys {
+				if err := verify.CheckAllowedKey(key); err != nil {
+					errs = append(errs, field.Invalid(path.Index(i).Child("commitSignature", "allowedKeys").Index(j), key, err.Error()))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// validatePublicKey validates that the public key is either PEM encoded or a
+// well formed reference to a Secret in the namespace of the policy
+func validatePublicKey(key, namespace string, path *field.Path) field.ErrorList {
+	if ecc.IsKubernetesURL(key) {
+		if _, err := ecc.ParseKubernetesURLIn(key, namespace); err != nil {
+			return field.ErrorList{field.Invalid(path, key, err.Error())}
+		}
+		return nil
+	}
+
+	if _, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(key)); err != nil {
+		return field.ErrorList{field.Invalid(path, key, "not a PEM encoded public key nor a k8s://namespace/name reference to a Secret")}
+	}
+
+	return nil
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	ecctesting "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1/testing"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/governance"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify/verifytest"
+)
+
+func TestValidateDataSources(t *testing.T) {
+	cases := []struct {
+		name   string
+		source *ecctesting.SourceBuilder
+		fields []string
+	}{
+		{
+			name:   "go-getter data",
+			source: ecctesting.NewSource("a").WithPolicy(ecctesting.ReleasePolicyURL).WithData(ecctesting.PolicyDataURL),
+		},
+		{
+			name:   "ConfigMap data",
+			source: ecctesting.NewSource("a").WithPolicy(ecctesting.ReleasePolicyURL).WithData("k8s://acme/policy-data"),
+		},
+		{
+			name:   "invalid ConfigMap data",
+			source: ecctesting.NewSource("a").WithPolicy(ecctesting.ReleasePolicyURL).WithData(ecctesting.PolicyDataURL, "k8s://policy-data", "k8s://acme/Data"),
+			fields: []string{"spec.sources[0].data[1]", "spec.sources[0].data[2]"},
+		},
+		{
+			name:   "ConfigMap data in another namespace",
+			source: ecctesting.NewSource("a").WithPolicy(ecctesting.ReleasePolicyURL).WithData("k8s://acme/policy-data", "k8s://shared/policy-data"),
+			fields: []string{"spec.sources[0].data[1]"},
+		},
+		{
+			name:   "Kubernetes policy rules",
+			source: ecctesting.NewSource("a").WithPolicy("k8s://acme/policy"),
+			fields: []string{"spec.sources[0].policy[0]"},
+		},
+		{
+			name: "local sources",
+			source: ecctesting.NewSource("a").
+				WithPolicy("/var/run/secrets/kubernetes.io/serviceaccount", "git::file:///srv/git/policy.git", ecctesting.ReleasePolicyURL).
+				WithData("../data", "file::/etc"),
+			fields: []string{"spec.sources[0].policy[0]", "spec.sources[0].policy[1]", "spec.sources[0].data[0]", "spec.sources[0].data[1]"},
+		},
+	}
+
+	v := EnterpriseContractPolicyValidator{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy := ecctesting.NewPolicySpec().WithSources(c.source).Policy("acme", "policy")
+
+			_, err := v.ValidateCreate(context.Background(), policy)
+			assertInvalidFields(t, err, c.fields)
+
+			_, err = v.ValidateUpdate(context.Background(), ecctesting.MinimalPolicySpec().Policy("acme", "policy"), policy)
+			assertInvalidFields(t, err, c.fields)
+		})
+	}
+}
+
+func TestValidateLocalSourcesAllowed(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("a").WithPolicy("./policy").WithData("file::/data")).
+		Policy("acme", "policy")
+
+	if _, err := (&EnterpriseContractPolicyValidator{AllowLocalSources: true}).ValidateCreate(context.Background(), policy); err != nil {
+		t.Errorf("expected local sources to be allowed, got %v", err)
+	}
+}
+
+func TestValidateBundleSignature(t *testing.T) {
+	pub := "-----BEGIN PUBLIC KEY-----\n" +
+		"MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEZP/0htjhVt2y0ohjgtIIgICOtQtA\n" +
+		"naYJRuLprwIv6FDhZ5yFjYUEtsmoNcW7rx2KM6FOXGsCX3BNc7qhHELT+g==\n" +
+		"-----END PUBLIC KEY-----\n"
+
+	cases := []struct {
+		name      string
+		signature ecc.BundleSignature
+		fields    []string
+	}{
+		{name: "PEM encoded key", signature: ecc.BundleSignature{PublicKey: pub}},
+		{name: "Secret", signature: ecc.BundleSignature{PublicKey: "k8s://acme/policy-key"}},
+		{name: "identity", signature: ecc.BundleSignature{Identity: &ecc.Identity{Subject: "https://github.com/acme/policy"}}},
+		{name: "invalid Secret", signature: ecc.BundleSignature{PublicKey: "k8s://policy-key"}, fields: []string{"spec.sources[0].bundleSignature.publicKey"}},
+		{name: "Secret in another namespace", signature: ecc.BundleSignature{PublicKey: "k8s://keys/policy-key"}, fields: []string{"spec.sources[0].bundleSignature.publicKey"}},
+		{name: "invalid key", signature: ecc.BundleSignature{PublicKey: "cosign.pub"}, fields: []string{"spec.sources[0].bundleSignature.publicKey"}},
+	}
+
+	v := EnterpriseContractPolicyValidator{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy := ecctesting.NewPolicySpec().
+				WithSources(ecctesting.NewSource("a").WithPolicy(ecctesting.ReleasePolicyURL).WithBundleSignature(c.signature)).
+				Policy("acme", "policy")
+
+			_, err := v.ValidateCreate(context.Background(), policy)
+			assertInvalidFields(t, err, c.fields)
+		})
+	}
+}
+
+func TestValidateCommitSignature(t *testing.T) {
+	_, gpgKey := verifytest.GPGKey(t, "Release Engineering", "release@example.com")
+	_, sshKey := verifytest.SSHKey(t, "release@example.com")
+
+	cases := []struct {
+		name   string
+		keys   []string
+		fields []string
+	}{
+		{name: "GPG and SSH keys", keys: []string{gpgKey, sshKey}},
+		{name: "invalid keys", keys: []string{gpgKey, "ssh-ed25519", "cosign.pub"}, fields: []string{"spec.sources[0].commitSignature.allowedKeys[1]", "spec.sources[0].commitSignature.allowedKeys[2]"}},
+	}
+
+	v := EnterpriseContractPolicyValidator{}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			policy := ecctesting.NewPolicySpec().
+				WithSources(ecctesting.NewSource("a").WithPolicy("git::https://github.com/acme/policy").WithCommitSignature(c.keys...)).
+				Policy("acme", "policy")
+
+			_, err := v.ValidateCreate(context.Background(), policy)
+			assertInvalidFields(t, err, c.fields)
+		})
+	}
+}
+
+func TestValidateGovernance(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	key := types.NamespacedName{Namespace: "enterprise-contract", Name: "governance"}
+	prod := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "acme", Labels: map[string]string{"env": "prod"}}}
+	configMap := func(config string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+			Data:       map[string]string{governance.ConfigKey: config},
+		}
+	}
+
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("a").
+			WithPolicy(ecctesting.ReleasePolicyURL).
+			WithExclude("cve").
+			WithVolatileExclude(ecctesting.NewVolatileCriteria("tasks").EffectiveUntil(now.Add(24*time.Hour)))).
+		Policy("acme", "policy")
+
+	cases := []struct {
+		name    string
+		objects []client.Object
+		fields  []string
+		err     string
+	}{
+		{name: "no governance ConfigMap", objects: []client.Object{prod}},
+		{name: "no rules", objects: []client.Object{configMap("")}},
+		{
+			name:    "rules",
+			objects: []client.Object{prod, configMap("rules:\n- name: prod\n  namespaceSelector:\n    matchLabels:\n      env: prod\n  forbidUnbounded: true\n- name: linked\n  referencePattern: ^https://\n")},
+			fields:  []string{"spec.sources[0].config.exclude[0]", "spec.sources[0].volatileConfig.exclude[0].reference"},
+		},
+		{name: "invalid governance ConfigMap", objects: []client.Object{prod, configMap("rules: [")}, err: "governance configuration enterprise-contract/governance"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v := EnterpriseContractPolicyValidator{
+				Client:     ecctesting.NewFakeClient(c.objects...),
+				Governance: key,
+				now:        func() time.Time { return now },
+			}
+
+			_, err := v.ValidateCreate(context.Background(), policy)
+			if c.err != "" {
+				if err == nil || apierrors.IsInvalid(err) || !strings.Contains(err.Error(), c.err) {
+					t.Fatalf("expected an error containing %q, got %v", c.err, err)
+				}
+				return
+			}
+			assertInvalidFields(t, err, c.fields)
+		})
+	}
+}
+
+func TestValidateMetaPolicy(t *testing.T) {
+	key := types.NamespacedName{Namespace: "enterprise-contract", Name: "meta-policy"}
+	prod := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "acme", Labels: map[string]string{"env": "prod"}}}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Data: map[string]string{
+			"sources.rego": "package meta\n\nimport rego.v1\n\n" +
+				"deny contains sprintf(\"%s is not from quay.io/acme\", [url]) if {\n" +
+				"\tinput.namespace.labels.env == \"prod\"\n" +
+				"\tsome url in input.policy.spec.sources[_].policy\n" +
+				"\tnot startswith(url, \"oci::quay.io/acme/\")\n}\n\n" +
+				"warn contains \"no description\" if not input.policy.spec.description\n",
+		},
+	}
+
+	c := ecctesting.NewFakeClient(prod, cm)
+	v := EnterpriseContractPolicyValidator{Client: c, MetaPolicy: key}
+
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("a").WithPolicy("oci::quay.io/acme/policy:v1", ecctesting.ReleasePolicyURL)).
+		Policy("acme", "policy")
+
+	warnings, err := v.ValidateCreate(context.Background(), policy)
+	assertInvalidFields(t, err, []string{"spec"})
+	if !strings.Contains(err.Error(), ecctesting.ReleasePolicyURL+" is not from quay.io/acme (meta-policy)") {
+		t.Errorf("expected the deny message in the error, got %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != "no description" {
+		t.Errorf("expected the warn message as warning, got %v", warnings)
+	}
+
+	// the meta-policy is compiled again once the ConfigMap changes
+	cm.Data["sources.rego"] = "package meta\n\nwarn[\"changed\"] { true }\n"
+	if err := c.Update(context.Background(), cm); err != nil {
+		t.Fatal(err)
+	}
+
+	warnings, err = v.ValidateCreate(context.Background(), policy)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0] != "changed" {
+		t.Errorf("expected the warning of the changed meta-policy, got %v", warnings)
+	}
+
+	// an invalid meta-policy rejects all policies
+	cm.Data["sources.rego"] = "package other\n"
+	if err := c.Update(context.Background(), cm); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = v.ValidateCreate(context.Background(), policy); err == nil || !strings.Contains(err.Error(), "meta-policy enterprise-contract/meta-policy") {
+		t.Errorf("expected an error about the invalid meta-policy, got %v", err)
+	}
+}
+
+func TestValidateConflicts(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().
+		WithSources(ecctesting.NewSource("a").
+			WithPolicy(ecctesting.ReleasePolicyURL).
+			WithInclude("cve").
+			WithExclude("cve").
+			WithVolatileExclude(ecctesting.NewVolatileCriteria("tasks"), ecctesting.NewVolatileCriteria("tasks"))).
+		Policy("acme", "policy")
+
+	warnings, err := (&EnterpriseContractPolicyValidator{}).ValidateCreate(context.Background(), policy)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`spec.sources[0].config.exclude[0]: "cve" is also included by spec.sources[0].config.include[0], the include takes precedence`,
+		"spec.sources[0].volatileConfig.exclude[1]: duplicates spec.sources[0].volatileConfig.exclude[0]",
+	}
+	if strings.Join(warnings, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected warnings %q, got %q", expected, warnings)
+	}
+}
+
+func TestValidateDelete(t *testing.T) {
+	policy := ecctesting.NewPolicySpec().WithSources(ecctesting.NewSource("a").WithPolicy("k8s://acme/policy")).Policy("acme", "policy")
+	if _, err := (&EnterpriseContractPolicyValidator{}).ValidateDelete(context.Background(), policy); err != nil {
+		t.Errorf("unexpected error deleting a policy: %v", err)
+	}
+}
+
+func TestValidateOtherObject(t *testing.T) {
+	if _, err := (&EnterpriseContractPolicyValidator{}).ValidateCreate(context.Background(), &ecc.RuleCollection{}); err == nil {
+		t.Error("expected an error validating an object other than a policy")
+	}
+}
+
+func assertInvalidFields(t *testing.T, err error, fields []string) {
+	t.Helper()
+
+	if len(fields) == 0 {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return
+	}
+
+	if !apierrors.IsInvalid(err) {
+		t.Fatalf("expected an invalid error, got %v", err)
+	}
+
+	causes := err.(apierrors.APIStatus).Status().Details.Causes
+	if len(causes) != len(fields) {
+		t.Fatalf("expected errors for %v, got %v", fields, causes)
+	}
+	for i, c := range causes {
+		if c.Field != fields[i] {
+			t.Errorf("expected an error for %s, got %s", fields[i], c.Field)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	ecc "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+//+kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1alpha1-policysnapshot,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=policysnapshots;policysnapshots/status,verbs=create;update,versions=v1alpha1,name=vpolicysnapshot.kb.io,admissionReviewVersions=v1
+
+// PolicySnapshotValidator validates the export of PolicySnapshot resources and
+// keeps them immutable: the spec never changes and the status no longer
+// changes once the policy is captured
+type PolicySnapshotValidator struct{}
+
+var _ webhook.CustomValidator = &PolicySnapshotValidator{}
+
+// SetupWebhookWithManager registers the validating webhook with the manager
+func (v *PolicySnapshotValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&ecc.PolicySnapshot{}).
+		WithValidator(v).
+		Complete()
+}
+
+// ValidateCreate validates the export of the snapshot
+func (v *PolicySnapshotValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	snapshot, ok := obj.(*ecc.PolicySnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicySnapshot, got %T", obj)
+	}
+
+	export := snapshot.Spec.Export
+	if export == nil {
+		return nil, nil
+	}
+
+	var errs field.ErrorList
+	path := field.NewPath("spec", "export")
+	if _, err := name.ParseReference(export.Repository); err != nil {
+		errs = append(errs, field.Invalid(path.Child("repository"), export.Repository, err.Error()))
+	}
+	if export.SigningKey != "" {
+		if _, err := ecc.ParseKubernetesURLIn(export.SigningKey, snapshot.Namespace); err != nil {
+			errs = append(errs, field.Invalid(path.Child("signingKey"), export.SigningKey, err.Error()))
+		}
+	}
+	if export.PushSecret != "" {
+		if _, err := ecc.ParseKubernetesURLIn(export.PushSecret, snapshot.Namespace); err != nil {
+			errs = append(errs, field.Invalid(path.Child("pushSecret"), export.PushSecret, err.Error()))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(ecc.GroupVersion.WithKind("PolicySnapshot").GroupKind(), snapshot.Name, errs)
+}
+
+// ValidateUpdate rejects changes to the spec, and to the status of captured
+// snapshots
+func (v *PolicySnapshotValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, ok := oldObj.(*ecc.PolicySnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicySnapshot, got %T", oldObj)
+	}
+	snapshot, ok := newObj.(*ecc.PolicySnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a PolicySnapshot, got %T", newObj)
+	}
+
+	var errs field.ErrorList
+	if !equality.Semantic.DeepEqual(snapshot.Spec, old.Spec) {
+		errs = append(errs, field.Forbidden(field.NewPath("spec"), "the spec of a snapshot is immutable"))
+	}
+	if old.Status.CapturedAt != nil && !equality.Semantic.DeepEqual(snapshot.Status, old.Status) {
+		errs = append(errs, field.Forbidden(field.NewPath("status"), "the status of a captured snapshot is immutable"))
+	}
+
+	if len(errs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(ecc.GroupVersion.WithKind("PolicySnapshot").GroupKind(), snapshot.Name, errs)
+}
+
+// ValidateDelete allows any snapshot to be deleted
+func (v *PolicySnapshotValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
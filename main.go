@@ -17,23 +17,35 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	appstudioredhatcomv1alpha1 "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
 	"github.com/enterprise-contract/enterprise-contract-controller/controllers"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/dryrun"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/fetch"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/mirror"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/verify"
+	"github.com/enterprise-contract/enterprise-contract-controller/internal/webhook"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -42,6 +54,10 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// syncPeriod is the period every policy is reconciled at, storing the mirror
+// archives it uses again
+const syncPeriod = 10 * time.Hour
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
@@ -53,11 +69,37 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var mirrorDir string
+	var mirrorAddr string
+	var mirrorURL string
+	var mirrorRetention time.Duration
+	var dryRunAddr string
+	var governanceConfigMap string
+	var metaPolicyConfigMap string
+	var allowLocalSources bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&mirrorDir, "mirror-dir", "",
+		"Directory caching the fetched policy rules and data to be served to policy runners. "+
+			"Mirroring is disabled when empty.")
+	flag.StringVar(&mirrorAddr, "mirror-bind-address", "127.0.0.1:8082", "The address the mirror endpoint binds to.")
+	flag.StringVar(&mirrorURL, "mirror-url", "",
+		"The URL policy runners reach the mirror endpoint at, published in the status of the policies.")
+	flag.DurationVar(&mirrorRetention, "mirror-retention", 24*time.Hour,
+		"How long mirrored policy rules and data are kept once no longer used by any policy, "+
+			"neither stored by a reconcile nor served. Must be longer than the 10h period policies are reconciled at.")
+	flag.StringVar(&dryRunAddr, "dry-run-bind-address", "",
+		"The address the effective configuration of policies is served at for dry runs, disabled when empty.")
+	flag.StringVar(&governanceConfigMap, "governance-configmap", "",
+		"The namespace/name of the ConfigMap holding the governance rules the excludes of policies are held to on admission.")
+	flag.StringVar(&metaPolicyConfigMap, "meta-policy-configmap", "",
+		"The namespace/name of the ConfigMap holding the Rego meta-policy policies are evaluated against on admission.")
+	flag.BoolVar(&allowLocalSources, "allow-local-sources", false,
+		"Allow policy sources on the local file system of the manager, exposing its files to whoever can create policies. "+
+			"For development only.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -72,21 +114,109 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "8f1bb6c7.redhat.com",
+		Cache:                  crcache.Options{SyncPeriod: ptr.To(syncPeriod)},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	var cache *mirror.Cache
+	if mirrorDir != "" {
+		if mirrorURL == "" {
+			setupLog.Error(nil, "--mirror-url is required with --mirror-dir")
+			os.Exit(1)
+		}
+		// archives of policies not reconciled within the retention would be
+		// pruned while in use
+		if mirrorRetention > 0 && mirrorRetention <= syncPeriod {
+			setupLog.Error(nil, "--mirror-retention must be longer than the period policies are reconciled at", "period", syncPeriod)
+			os.Exit(1)
+		}
+
+		if cache, err = mirror.NewCache(mirrorDir, mirrorURL); err != nil {
+			setupLog.Error(err, "unable to create mirror")
+			os.Exit(1)
+		}
+
+		if err := mgr.Add(&mirror.Server{Cache: cache, Addr: mirrorAddr, Retention: mirrorRetention}); err != nil {
+			setupLog.Error(err, "unable to set up mirror server")
+			os.Exit(1)
+		}
+	}
+
+	if dryRunAddr != "" {
+		if err := mgr.Add(&dryrun.Server{Client: mgr.GetClient(), Addr: dryRunAddr}); err != nil {
+			setupLog.Error(err, "unable to set up dry-run server")
+			os.Exit(1)
+		}
+	}
+
+	fetcher, pin := fetch.NewFetcher(), fetch.Pin
+	if allowLocalSources {
+		setupLog.Info("policy sources on the local file system are allowed")
+		fetcher, pin = fetch.NewLocalFetcher(), fetch.PinLocal
+	}
+
 	if err = (&controllers.EnterpriseContractPolicyReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Fetcher:   fetcher,
+		Mirror:    cache,
+		APIReader: mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "EnterpriseContractPolicy")
 		os.Exit(1)
 	}
+	if err = (&controllers.PolicySnapshotReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Pin:       pin,
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PolicySnapshot")
+		os.Exit(1)
+	}
+	if err = (&controllers.PolicySubscriptionReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Fetcher: fetcher,
+		Pin:     pin,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PolicySubscription")
+		os.Exit(1)
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		validator := webhook.EnterpriseContractPolicyValidator{
+			Client:     mgr.GetClient(),
+			Governance: namespacedName("governance-configmap", governanceConfigMap),
+			MetaPolicy: namespacedName("meta-policy-configmap", metaPolicyConfigMap),
+
+			AllowLocalSources: allowLocalSources,
+		}
+		if err = validator.SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "EnterpriseContractPolicy")
+			os.Exit(1)
+		}
+		if err = (&webhook.PolicySnapshotValidator{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "PolicySnapshot")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	// the Sigstore trust roots are loaded once and reused by all the
+	// verifications, failing here they are loaded on first use
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		if err := verify.LoadTrustRoots(ctx); err != nil {
+			setupLog.Info("unable to load the Sigstore trust roots ahead of their use", "error", err.Error())
+		}
+		return nil
+	})); err != nil {
+		setupLog.Error(err, "unable to set up loading the Sigstore trust roots")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -102,3 +232,19 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// namespacedName parses the namespace/name value of the flag, exiting when
+// malformed, an empty value is returned as is
+func namespacedName(flag, value string) types.NamespacedName {
+	if value == "" {
+		return types.NamespacedName{}
+	}
+
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" || name == "" {
+		setupLog.Error(nil, "--"+flag+" must be of the form namespace/name")
+		os.Exit(1)
+	}
+
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
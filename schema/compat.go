@@ -0,0 +1,262 @@
+// Copyright 2025 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Incompatibility is a change in the schema that could make a document valid
+// against the previously published schema invalid against the new schema
+type Incompatibility struct {
+	// Path to the changed element, e.g. spec.sources[].policy
+	Path string
+	// Reason describes the change
+	Reason string
+}
+
+func (i Incompatibility) String() string {
+	return fmt.Sprintf("%s: %s", i.Path, i.Reason)
+}
+
+// CheckCompatibility compares the new JSON schema against the previously
+// published one and returns all backwards-incompatible changes found
+func CheckCompatibility(previous, current []byte) ([]Incompatibility, error) {
+	var old, new map[string]any
+	if err := json.Unmarshal(previous, &old); err != nil {
+		return nil, fmt.Errorf("unable to parse the previous schema: %w", err)
+	}
+	if err := json.Unmarshal(current, &new); err != nil {
+		return nil, fmt.Errorf("unable to parse the new schema: %w", err)
+	}
+
+	c := compatChecker{
+		oldDefs: definitions(old),
+		newDefs: definitions(new),
+		active:  map[string]bool{},
+	}
+	c.compare("spec", old, new)
+
+	sort.Slice(c.found, func(i, j int) bool {
+		if c.found[i].Path == c.found[j].Path {
+			return c.found[i].Reason < c.found[j].Reason
+		}
+		return c.found[i].Path < c.found[j].Path
+	})
+
+	return c.found, nil
+}
+
+// Unaccepted returns the incompatibilities not listed in the accepted changes,
+// one incompatibility per line as reported, e.g.
+//
+//	spec.sources: minItems raised from unset to 1
+//
+// Blank lines and lines starting with # are ignored.
+func Unaccepted(incompatibilities []Incompatibility, accepted []byte) []Incompatibility {
+	acceptedChanges := map[string]bool{}
+	for _, line := range strings.Split(string(accepted), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			acceptedChanges[line] = true
+		}
+	}
+
+	var remaining []Incompatibility
+	for _, i := range incompatibilities {
+		if !acceptedChanges[i.String()] {
+			remaining = append(remaining, i)
+		}
+	}
+
+	return remaining
+}
+
+type compatChecker struct {
+	oldDefs map[string]any
+	newDefs map[string]any
+	// definitions being compared, guards against recursive definitions
+	active map[string]bool
+	found  []Incompatibility
+}
+
+func definitions(schema map[string]any) map[string]any {
+	defs, _ := schema["$defs"].(map[string]any)
+	return defs
+}
+
+func (c *compatChecker) report(path, format string, args ...any) {
+	c.found = append(c.found, Incompatibility{Path: path, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (c *compatChecker) compare(path string, old, new map[string]any) {
+	if ref, ok := old["$ref"].(string); ok {
+		if c.active[ref] {
+			return
+		}
+		c.active[ref] = true
+		defer delete(c.active, ref)
+	}
+
+	old = resolveRef(old, c.oldDefs)
+	new = resolveRef(new, c.newDefs)
+
+	if o, n := old["type"], new["type"]; n != nil && !reflect.DeepEqual(o, n) {
+		c.report(path, "type changed from %v to %v", o, n)
+	}
+
+	if o, n := str(old["pattern"]), str(new["pattern"]); n != "" && o != n {
+		if o == "" {
+			c.report(path, "pattern %q added", n)
+		} else {
+			c.report(path, "pattern changed from %q to %q", o, n)
+		}
+	}
+
+	if o, n := str(old["format"]), str(new["format"]); n != "" && o != n {
+		if o == "" {
+			c.report(path, "format %q added", n)
+		} else {
+			c.report(path, "format changed from %q to %q", o, n)
+		}
+	}
+
+	for _, k := range []string{"minItems", "minLength", "minProperties", "minimum"} {
+		if o, n := number(old[k]), number(new[k]); n != nil && (o == nil || *n > *o) {
+			c.report(path, "%s raised from %s to %v", k, describe(o), *n)
+		}
+	}
+
+	for _, k := range []string{"maxItems", "maxLength", "maxProperties", "maximum"} {
+		if o, n := number(old[k]), number(new[k]); n != nil && (o == nil || *n < *o) {
+			c.report(path, "%s lowered from %s to %v", k, describe(o), *n)
+		}
+	}
+
+	if n, ok := new["enum"].([]any); ok {
+		o, _ := old["enum"].([]any)
+		if o == nil {
+			c.report(path, "enum restriction added")
+		}
+		for _, v := range o {
+			if !contains(n, v) {
+				c.report(path, "enum value %v removed", v)
+			}
+		}
+	}
+
+	if o, n := old["additionalProperties"], new["additionalProperties"]; n == false && o != false {
+		c.report(path, "additional properties no longer allowed")
+	}
+
+	oldRequired := set(old["required"])
+	for _, r := range sortedKeys(set(new["required"])) {
+		if !oldRequired[r] {
+			c.report(path+"."+r, "new required field")
+		}
+	}
+
+	oldProps, _ := old["properties"].(map[string]any)
+	newProps, _ := new["properties"].(map[string]any)
+	for _, name := range sortedKeys(oldProps) {
+		n, ok := newProps[name]
+		if !ok {
+			c.report(path+"."+name, "field removed")
+			continue
+		}
+		c.compare(path+"."+name, oldProps[name].(map[string]any), n.(map[string]any))
+	}
+
+	if o, ok := old["items"].(map[string]any); ok {
+		if n, ok := new["items"].(map[string]any); ok {
+			c.compare(path+"[]", o, n)
+		}
+	}
+}
+
+// resolveRef follows the $ref, keywords next to the $ref take precedence over
+// the ones in the referenced definition
+func resolveRef(s map[string]any, defs map[string]any) map[string]any {
+	ref, ok := s["$ref"].(string)
+	if !ok {
+		return s
+	}
+
+	def, _ := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+	resolved := map[string]any{}
+	for k, v := range resolveRef(def, defs) {
+		resolved[k] = v
+	}
+	for k, v := range s {
+		if k != "$ref" {
+			resolved[k] = v
+		}
+	}
+
+	return resolved
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func number(v any) *float64 {
+	n, ok := v.(float64)
+	if !ok {
+		return nil
+	}
+	return &n
+}
+
+func describe(n *float64) string {
+	if n == nil {
+		return "unset"
+	}
+	return fmt.Sprint(*n)
+}
+
+func set(v any) map[string]bool {
+	s := map[string]bool{}
+	items, _ := v.([]any)
+	for _, i := range items {
+		s[fmt.Sprint(i)] = true
+	}
+	return s
+}
+
+func contains(values []any, v any) bool {
+	for _, e := range values {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	v1alpha1 "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	base := `{
+		"$ref": "#/$defs/Spec",
+		"$defs": {
+			"Spec": {
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"sources": {"type": "array", "items": {"$ref": "#/$defs/Source"}}
+				}
+			},
+			"Source": {
+				"type": "object",
+				"required": ["value"],
+				"properties": {
+					"value": {"type": "string"},
+					"digest": {"type": "string", "pattern": "^sha256:.*$"},
+					"kind": {"type": "string", "enum": ["a", "b"]}
+				}
+			}
+		}
+	}`
+
+	tests := []struct {
+		name     string
+		modify   func(defs map[string]any)
+		expected []string
+	}{
+		{
+			name:   "unchanged",
+			modify: func(map[string]any) {},
+		},
+		{
+			name: "optional field added",
+			modify: func(defs map[string]any) {
+				props(defs, "Spec")["description"] = map[string]any{"type": "string"}
+			},
+		},
+		{
+			name: "pattern removed",
+			modify: func(defs map[string]any) {
+				delete(props(defs, "Source")["digest"].(map[string]any), "pattern")
+			},
+		},
+		{
+			name: "field removed",
+			modify: func(defs map[string]any) {
+				delete(props(defs, "Spec"), "name")
+			},
+			expected: []string{"spec.name: field removed"},
+		},
+		{
+			name: "nested field removed",
+			modify: func(defs map[string]any) {
+				delete(props(defs, "Source"), "digest")
+			},
+			expected: []string{"spec.sources[].digest: field removed"},
+		},
+		{
+			name: "new required field",
+			modify: func(defs map[string]any) {
+				defs["Spec"].(map[string]any)["required"] = []any{"name"}
+			},
+			expected: []string{"spec.name: new required field"},
+		},
+		{
+			name: "pattern changed",
+			modify: func(defs map[string]any) {
+				props(defs, "Source")["digest"].(map[string]any)["pattern"] = "^sha512:.*$"
+			},
+			expected: []string{`spec.sources[].digest: pattern changed from "^sha256:.*$" to "^sha512:.*$"`},
+		},
+		{
+			name: "pattern added",
+			modify: func(defs map[string]any) {
+				props(defs, "Spec")["name"].(map[string]any)["pattern"] = "^[a-z]+$"
+			},
+			expected: []string{`spec.name: pattern "^[a-z]+$" added`},
+		},
+		{
+			name: "format added",
+			modify: func(defs map[string]any) {
+				props(defs, "Spec")["name"].(map[string]any)["format"] = "date-time"
+			},
+			expected: []string{`spec.name: format "date-time" added`},
+		},
+		{
+			name: "type changed",
+			modify: func(defs map[string]any) {
+				props(defs, "Spec")["name"].(map[string]any)["type"] = "integer"
+			},
+			expected: []string{"spec.name: type changed from string to integer"},
+		},
+		{
+			name: "minItems added",
+			modify: func(defs map[string]any) {
+				props(defs, "Spec")["sources"].(map[string]any)["minItems"] = float64(1)
+			},
+			expected: []string{"spec.sources: minItems raised from unset to 1"},
+		},
+		{
+			name: "enum value removed",
+			modify: func(defs map[string]any) {
+				props(defs, "Source")["kind"].(map[string]any)["enum"] = []any{"a"}
+			},
+			expected: []string{"spec.sources[].kind: enum value b removed"},
+		},
+		{
+			name: "enum value added",
+			modify: func(defs map[string]any) {
+				props(defs, "Source")["kind"].(map[string]any)["enum"] = []any{"a", "b", "c"}
+			},
+		},
+		{
+			name: "additional properties disallowed",
+			modify: func(defs map[string]any) {
+				defs["Spec"].(map[string]any)["additionalProperties"] = false
+			},
+			expected: []string{"spec: additional properties no longer allowed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := map[string]any{}
+			if err := json.Unmarshal([]byte(base), &schema); err != nil {
+				t.Fatal(err)
+			}
+			tt.modify(schema["$defs"].(map[string]any))
+
+			current, err := json.Marshal(schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			incompatibilities, err := CheckCompatibility([]byte(base), current)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := []string{}
+			for _, i := range incompatibilities {
+				got = append(got, i.String())
+			}
+			if tt.expected == nil {
+				tt.expected = []string{}
+			}
+			if !reflect.DeepEqual(tt.expected, got) {
+				t.Errorf("expected:\n%s\ngot:\n%s", strings.Join(tt.expected, "\n"), strings.Join(got, "\n"))
+			}
+		})
+	}
+}
+
+func TestCheckCompatibilityWithItself(t *testing.T) {
+	incompatibilities, err := CheckCompatibility([]byte(v1alpha1.Schema), []byte(v1alpha1.Schema))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(incompatibilities) > 0 {
+		t.Errorf("expected no incompatibilities, got: %v", incompatibilities)
+	}
+}
+
+func TestVersionedSchemaID(t *testing.T) {
+	expected := "https://enterprise-contract.github.io/enterprise-contract-controller/schema/v1alpha1/v0.1.42/policy_spec.json"
+	if got := string(versionedSchemaID("v1alpha1", "v0.1.42", "policy_spec.json")); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestReleaseVersion(t *testing.T) {
+	for tag, expected := range map[string]string{"api/v0.1.42": "v0.1.42", "v0.1.42": "v0.1.42"} {
+		if got := releaseVersion(tag); got != expected {
+			t.Errorf("expected %q for tag %q, got %q", expected, tag, got)
+		}
+	}
+}
+
+func TestUnaccepted(t *testing.T) {
+	incompatibilities := []Incompatibility{
+		{Path: "spec.sources", Reason: "minItems raised from unset to 1"},
+		{Path: "spec.sources[].name", Reason: "field removed"},
+		{Path: "spec.sources[].policy", Reason: "minItems raised from unset to 1"},
+	}
+	accepted := "# sources without policy rules were never usable\n\nspec.sources: minItems raised from unset to 1\n  spec.sources[].policy: minItems raised from unset to 1  \nspec.sources[].name: type changed from string to integer\n"
+
+	expected := []Incompatibility{{Path: "spec.sources[].name", Reason: "field removed"}}
+	if got := Unaccepted(incompatibilities, []byte(accepted)); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := Unaccepted(incompatibilities, nil); !reflect.DeepEqual(incompatibilities, got) {
+		t.Errorf("expected all incompatibilities without accepted changes, got %v", got)
+	}
+}
+
+func props(defs map[string]any, name string) map[string]any {
+	return defs[name].(map[string]any)["properties"].(map[string]any)
+}
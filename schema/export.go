@@ -18,10 +18,12 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"path"
 	"reflect"
+	"strings"
 
 	"github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
 	"github.com/invopop/jsonschema"
@@ -33,10 +35,22 @@ var (
 	jsonType = reflect.TypeOf(extv1.JSON{})
 )
 
+// Base URL the versioned schemas are published under
+const schemaBaseURL = "https://enterprise-contract.github.io/enterprise-contract-controller/schema"
+
+var (
+	version  = flag.String("version", "", "module version, when set the schema is written to <dir>/<API version>/<version>/ with a matching $id")
+	baseline = flag.String("baseline", "", "previously published schema to check the new schema for backwards-incompatible changes against")
+	accepted = flag.String("accepted", "", "file listing the backwards-incompatible changes accepted since the baseline, one per line as reported")
+)
+
+// Prefix of the release tags of the api module, the tags are prefixed with
+// the directory of the module, e.g. api/v0.1.42
+const tagPrefix = "api/"
+
 func main() {
-	// Write the JSON schema to a file location provided
-	if len(os.Args) < 3 {
-		fmt.Printf(`
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), `
 Please ensure you've provided the following:
   * A directory location to write the JSON schema to.
     The file will be titled schema.json
@@ -44,22 +58,74 @@ Please ensure you've provided the following:
   * The path to the directory containing the Go source file which contains the struct used for schema creation.
     Example: go run schema/export.go /tmp/enterprise-contract-controller github.com/enterprise-contract/enterprise-contract-controller ./api/v1alpha1/
 
+Options:
 `)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Write the JSON schema to a file location provided
+	args := flag.Args()
+	if len(args) < 3 {
+		flag.Usage()
 		os.Exit(1)
 	}
-	schemaDir := os.Args[1]
-	repo := os.Args[2]
-	sourceFilePath := os.Args[3]
+	schemaDir := args[0]
+	repo := args[1]
+	sourceFilePath := args[2]
 	fileName := "policy_spec.json"
-	if len(os.Args) == 5 {
-		fileName = os.Args[4]
+	if len(args) == 4 {
+		fileName = args[3]
+	}
+
+	var id jsonschema.ID
+	if *version != "" {
+		moduleVersion := releaseVersion(*version)
+		id = versionedSchemaID(v1alpha1.GroupVersion.Version, moduleVersion, fileName)
+		schemaDir = path.Join(schemaDir, v1alpha1.GroupVersion.Version, moduleVersion)
 	}
+
 	// Create a JSON schema from a Go type
-	schema, err := JsonSchemaFromPolicySpec(&v1alpha1.EnterpriseContractPolicySpec{}, repo, sourceFilePath)
+	schema, err := jsonSchemaFromPolicySpec(&v1alpha1.EnterpriseContractPolicySpec{}, repo, sourceFilePath, id)
 	if err != nil {
 		fmt.Println("Error creating JSON schema:", err)
 		os.Exit(1)
 	}
+
+	if *baseline != "" {
+		previous, err := os.ReadFile(*baseline)
+		if err != nil {
+			fmt.Println("Error reading the baseline JSON schema:", err)
+			os.Exit(1)
+		}
+
+		incompatibilities, err := CheckCompatibility(previous, schema)
+		if err != nil {
+			fmt.Println("Error checking JSON schema compatibility:", err)
+			os.Exit(1)
+		}
+
+		if *accepted != "" {
+			acceptedChanges, err := os.ReadFile(*accepted)
+			if err != nil {
+				fmt.Println("Error reading the accepted changes:", err)
+				os.Exit(1)
+			}
+			incompatibilities = Unaccepted(incompatibilities, acceptedChanges)
+		}
+
+		if len(incompatibilities) > 0 {
+			fmt.Println("The JSON schema has backwards-incompatible changes compared to", *baseline)
+			for _, i := range incompatibilities {
+				fmt.Println("  *", i)
+			}
+			if *accepted != "" {
+				fmt.Println("Intended changes can be accepted by adding them to", *accepted)
+			}
+			os.Exit(1)
+		}
+	}
+
 	// Write the JSON schema to a file location provided
 	err = writeSchemaToFile(schemaDir, schema, fileName)
 	if err != nil {
@@ -69,6 +135,19 @@ Please ensure you've provided the following:
 	fmt.Println("JSON schema written to", path.Join(schemaDir, fileName))
 }
 
+// versionedSchemaID returns the $id of the schema published for the given API
+// and module version
+func versionedSchemaID(apiVersion, moduleVersion, fileName string) jsonschema.ID {
+	return jsonschema.ID(fmt.Sprintf("%s/%s/%s/%s", schemaBaseURL, apiVersion, moduleVersion, fileName))
+}
+
+// releaseVersion returns the version of the api module released with the
+// given tag, without the directory of the module, e.g. v0.1.42 for
+// api/v0.1.42
+func releaseVersion(tag string) string {
+	return strings.TrimPrefix(tag, tagPrefix)
+}
+
 // Write the JSON schema to a directory location provided
 func writeSchemaToFile(schemaDir string, schema []byte, fileName string) error {
 	fs := afero.NewOsFs()
@@ -78,16 +157,31 @@ func writeSchemaToFile(schemaDir string, schema []byte, fileName string) error {
 
 // Create a JSON schema from a Go type, and return the JSON as a byte slice
 func JsonSchemaFromPolicySpec(ecp *v1alpha1.EnterpriseContractPolicySpec, repo, dir string) ([]byte, error) {
+	return jsonSchemaFromPolicySpec(ecp, repo, dir, "")
+}
+
+// Create a JSON schema from a Go type with the given $id, the $id derived from
+// the Go type is kept if none is provided
+func jsonSchemaFromPolicySpec(ecp *v1alpha1.EnterpriseContractPolicySpec, repo, dir string, id jsonschema.ID) ([]byte, error) {
 	// Create a JSON schema from a Go type
 	r := jsonschema.Reflector{}
 	if err := r.AddGoComments(repo, dir); err != nil {
 		return nil, err
 	}
 	schema := r.Reflect(ecp)
+	if id != "" {
+		schema.ID = id
+	}
 
 	// allow any property in the "JSON" type definition
 	schema.Definitions["JSON"].AdditionalProperties = jsonschema.TrueSchema
 
+	// reflection only looks at the Go types, the validation rules are given
+	// by the kubebuilder markers in the Go comments
+	if err := applyValidationMarkers(schema); err != nil {
+		return nil, err
+	}
+
 	prettyJSON, err := json.MarshalIndent(schema, "", "  ")
 	if err != nil {
 		return nil, err
@@ -5,6 +5,7 @@ go 1.23.6
 require (
 	github.com/enterprise-contract/enterprise-contract-controller/api v0.1.280
 	github.com/invopop/jsonschema v0.12.0
+	sigs.k8s.io/yaml v1.4.0
 )
 
 require (
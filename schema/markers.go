@@ -0,0 +1,160 @@
+// Copyright 2025 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+)
+
+const validationMarkerPrefix = "+kubebuilder:validation:"
+
+// validationMarker is a single kubebuilder validation marker, e.g.
+// "+kubebuilder:validation:MinItems:=1" is parsed into the name "MinItems"
+// and the value "1".
+type validationMarker struct {
+	name  string
+	value string
+}
+
+// parseValidationMarkers extracts the kubebuilder validation markers from the
+// Go doc comment that AddGoComments placed in the schema description. The
+// doc comments of types are joined into a single line, so markers are also
+// recognized following a space.
+func parseValidationMarkers(description string) []validationMarker {
+	description = strings.ReplaceAll(description, " "+validationMarkerPrefix, "\n"+validationMarkerPrefix)
+
+	var markers []validationMarker
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, validationMarkerPrefix) {
+			continue
+		}
+		line = strings.TrimPrefix(line, validationMarkerPrefix)
+
+		name, value, _ := strings.Cut(line, "=")
+		name = strings.TrimSuffix(name, ":")
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '`' || value[0] == '"') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		markers = append(markers, validationMarker{name: name, value: value})
+	}
+
+	return markers
+}
+
+// applyValidationMarkers carries the kubebuilder validation markers over to
+// the JSON schema so that the JSON schema validates the same constraints as
+// the CRD generated by controller-gen.
+func applyValidationMarkers(schema *jsonschema.Schema) error {
+	if err := applyMarkers("", schema); err != nil {
+		return err
+	}
+
+	for name, def := range schema.Definitions {
+		if err := applyMarkers(name, def); err != nil {
+			return err
+		}
+
+		if def.Properties == nil {
+			continue
+		}
+
+		for p := def.Properties.Oldest(); p != nil; p = p.Next() {
+			if err := applyMarkers(name+"."+p.Key, p.Value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func applyMarkers(path string, s *jsonschema.Schema) error {
+	for _, m := range parseValidationMarkers(s.Description) {
+		if err := applyMarker(s, m); err != nil {
+			return fmt.Errorf("%s: invalid marker %q: %w", path, m.name, err)
+		}
+	}
+
+	return nil
+}
+
+func applyMarker(s *jsonschema.Schema, m validationMarker) error {
+	switch m.name {
+	case "Pattern":
+		s.Pattern = m.value
+	case "Format":
+		s.Format = m.value
+	case "Type":
+		s.Type = m.value
+	case "Enum":
+		s.Enum = nil
+		for _, v := range strings.Split(m.value, ";") {
+			s.Enum = append(s.Enum, v)
+		}
+	case "MinItems":
+		return setUint(&s.MinItems, m.value)
+	case "MaxItems":
+		return setUint(&s.MaxItems, m.value)
+	case "MinLength":
+		return setUint(&s.MinLength, m.value)
+	case "MaxLength":
+		return setUint(&s.MaxLength, m.value)
+	case "MinProperties":
+		return setUint(&s.MinProperties, m.value)
+	case "MaxProperties":
+		return setUint(&s.MaxProperties, m.value)
+	case "Minimum":
+		return setNumber(&s.Minimum, m.value)
+	case "Maximum":
+		return setNumber(&s.Maximum, m.value)
+	case "UniqueItems":
+		s.UniqueItems = m.value == "" || m.value == "true"
+	case "Optional", "Required":
+		// handled by the reflector using the json struct tags
+	default:
+		return fmt.Errorf("unsupported validation marker")
+	}
+
+	return nil
+}
+
+func setUint(dst **uint64, value string) error {
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	*dst = &v
+
+	return nil
+}
+
+func setNumber(dst *json.Number, value string) error {
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return err
+	}
+	*dst = json.Number(value)
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseValidationMarkers(t *testing.T) {
+	cases := []struct {
+		name        string
+		description string
+		expected    []validationMarker
+	}{
+		{name: "none", description: "Field is a field\n+optional"},
+		{
+			name:        "lines",
+			description: "Field is a field\n+kubebuilder:validation:MinItems:=1\n+kubebuilder:validation:Pattern:=`^a$`",
+			expected:    []validationMarker{{name: "MinItems", value: "1"}, {name: "Pattern", value: "^a$"}},
+		},
+		{
+			name:        "joined type comment",
+			description: "Type is a type +kubebuilder:validation:MinProperties:=1 +kubebuilder:validation:MaxProperties:=1",
+			expected:    []validationMarker{{name: "MinProperties", value: "1"}, {name: "MaxProperties", value: "1"}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := parseValidationMarkers(c.description); !reflect.DeepEqual(c.expected, got) {
+				t.Errorf("expected %v, got %v", c.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 Red Hat, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	v1alpha1 "github.com/enterprise-contract/enterprise-contract-controller/api/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// keywords compared verbatim between the CRD and the JSON schema
+var parityKeywords = []string{
+	"type",
+	"pattern",
+	"format",
+	"enum",
+	"minItems",
+	"maxItems",
+	"minLength",
+	"maxLength",
+	"minProperties",
+	"maxProperties",
+	"minimum",
+	"maximum",
+}
+
+func TestCRDAndJSONSchemaParity(t *testing.T) {
+	crd := loadCRDSpecSchema(t)
+
+	js := map[string]any{}
+	if err := json.Unmarshal([]byte(v1alpha1.Schema), &js); err != nil {
+		t.Fatalf("unable to parse the JSON schema: %v", err)
+	}
+	defs, _ := js["$defs"].(map[string]any)
+
+	for _, diff := range compareSchemas("spec", crd, js, defs) {
+		t.Error(diff)
+	}
+}
+
+func TestCompareSchemasReportsDivergence(t *testing.T) {
+	crd := map[string]any{
+		"type":     "object",
+		"required": []any{"a"},
+		"properties": map[string]any{
+			"a": map[string]any{"type": "string", "pattern": "^a$"},
+			"b": map[string]any{"type": "array", "minItems": 1, "items": map[string]any{"type": "string"}},
+			"c": map[string]any{"type": "string", "format": "date-time"},
+		},
+	}
+	js := map[string]any{
+		"$ref": "#/$defs/T",
+	}
+	defs := map[string]any{
+		"T": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"a": map[string]any{"type": "string", "pattern": "^b$"},
+				"b": map[string]any{"type": "array", "items": map[string]any{"type": "integer"}},
+				"d": map[string]any{"type": "string"},
+			},
+		},
+	}
+
+	expected := []string{
+		`spec.a: pattern differs, CRD: "^a$", JSON schema: "^b$"`,
+		`spec.b: minItems differs, CRD: 1, JSON schema: <nil>`,
+		`spec.b[]: type differs, CRD: "string", JSON schema: "integer"`,
+		`spec.c: missing in the JSON schema`,
+		`spec.d: missing in the CRD`,
+		`spec: required differs, CRD: [a], JSON schema: []`,
+	}
+
+	got := compareSchemas("spec", crd, js, defs)
+	sort.Strings(expected)
+	sort.Strings(got)
+	if !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected differences:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func loadCRDSpecSchema(t *testing.T) map[string]any {
+	t.Helper()
+
+	bytes, err := os.ReadFile("../api/config/appstudio.redhat.com_enterprisecontractpolicies.yaml")
+	if err != nil {
+		t.Fatalf("unable to read the CRD: %v", err)
+	}
+
+	crd := map[string]any{}
+	if err := yaml.Unmarshal(bytes, &crd); err != nil {
+		t.Fatalf("unable to parse the CRD: %v", err)
+	}
+
+	spec := lookup(crd, "spec", "versions", 0, "schema", "openAPIV3Schema", "properties", "spec")
+	if spec == nil {
+		t.Fatal("unable to find the spec schema in the CRD")
+	}
+
+	return spec.(map[string]any)
+}
+
+func lookup(v any, path ...any) any {
+	for _, p := range path {
+		switch k := p.(type) {
+		case string:
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil
+			}
+			v = m[k]
+		case int:
+			s, ok := v.([]any)
+			if !ok || len(s) <= k {
+				return nil
+			}
+			v = s[k]
+		}
+	}
+
+	return v
+}
+
+// compareSchemas structurally compares the CRD OpenAPI schema with the JSON
+// schema and returns a description of each difference found
+func compareSchemas(path string, crd, js map[string]any, defs map[string]any) []string {
+	js = resolveRef(js, defs)
+
+	var diffs []string
+	for _, k := range parityKeywords {
+		c, j := normalize(crd[k]), normalize(js[k])
+		if !reflect.DeepEqual(c, j) {
+			diffs = append(diffs, fmt.Sprintf("%s: %s differs, CRD: %s, JSON schema: %s", path, k, format(c), format(j)))
+		}
+	}
+
+	if c, j := stringSet(crd["required"]), stringSet(js["required"]); !slices.Equal(c, j) {
+		diffs = append(diffs, fmt.Sprintf("%s: required differs, CRD: %v, JSON schema: %v", path, c, j))
+	}
+
+	if crdItems, ok := crd["items"].(map[string]any); ok {
+		jsItems, _ := js["items"].(map[string]any)
+		diffs = append(diffs, compareSchemas(path+"[]", crdItems, jsItems, defs)...)
+	} else if _, ok := js["items"]; ok {
+		diffs = append(diffs, fmt.Sprintf("%s[]: missing in the CRD", path))
+	}
+
+	if preserve, _ := crd["x-kubernetes-preserve-unknown-fields"].(bool); preserve {
+		// arbitrary JSON, properties are not defined in the CRD
+		return diffs
+	}
+
+	crdProps, _ := crd["properties"].(map[string]any)
+	jsProps, _ := js["properties"].(map[string]any)
+	for name, c := range crdProps {
+		j, ok := jsProps[name]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s.%s: missing in the JSON schema", path, name))
+			continue
+		}
+		diffs = append(diffs, compareSchemas(path+"."+name, c.(map[string]any), j.(map[string]any), defs)...)
+	}
+	for name := range jsProps {
+		if _, ok := crdProps[name]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%s.%s: missing in the CRD", path, name))
+		}
+	}
+
+	return diffs
+}
+
+// normalize makes numbers from YAML and JSON comparable
+func normalize(v any) any {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int64:
+		return float64(n)
+	}
+
+	return v
+}
+
+func format(v any) string {
+	if v == nil {
+		return "<nil>"
+	}
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+func stringSet(v any) []string {
+	set := []string{}
+	items, _ := v.([]any)
+	for _, i := range items {
+		set = append(set, fmt.Sprint(i))
+	}
+	sort.Strings(set)
+
+	return set
+}
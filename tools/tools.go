@@ -21,6 +21,7 @@ package tools
 
 import (
 	_ "github.com/elastic/crd-ref-docs"
+	_ "k8s.io/code-generator"
 	_ "sigs.k8s.io/controller-runtime/tools/setup-envtest"
 	_ "sigs.k8s.io/controller-tools/cmd/controller-gen"
 	_ "sigs.k8s.io/kustomize/kustomize/v4"